@@ -4,12 +4,24 @@ import (
 
 	// "database/sql"
 
-	// "github.com/xuri/excelize/v2"
 	"net/http"
 
 	"github.com/VoyakinH/lokle_backend/config"
+	admission_delivery "github.com/VoyakinH/lokle_backend/internal/admission/delivery"
+	admission_repository "github.com/VoyakinH/lokle_backend/internal/admission/repository"
+	admission_usecase "github.com/VoyakinH/lokle_backend/internal/admission/usecase"
+	audit_repository "github.com/VoyakinH/lokle_backend/internal/audit/repository"
+	events_delivery "github.com/VoyakinH/lokle_backend/internal/events/delivery"
+	events_repository "github.com/VoyakinH/lokle_backend/internal/events/repository"
+	events_usecase "github.com/VoyakinH/lokle_backend/internal/events/usecase"
 	file_manager "github.com/VoyakinH/lokle_backend/internal/file"
+	idempotency_repository "github.com/VoyakinH/lokle_backend/internal/idempotency/repository"
+	notification_delivery "github.com/VoyakinH/lokle_backend/internal/notification/delivery"
+	notification_repository "github.com/VoyakinH/lokle_backend/internal/notification/repository"
+	notification_usecase "github.com/VoyakinH/lokle_backend/internal/notification/usecase"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/middleware"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/workflow"
 	reg_req_delivery "github.com/VoyakinH/lokle_backend/internal/reg_req/delivery"
 	reg_req_repository "github.com/VoyakinH/lokle_backend/internal/reg_req/repository"
 	reg_req_usecase "github.com/VoyakinH/lokle_backend/internal/reg_req/usecase"
@@ -27,31 +39,53 @@ func main() {
 	// logger
 	logger := logrus.New()
 
+	// registration workflow
+	err := workflow.Load(config.RegReq.Workflow)
+	if err != nil {
+		logger.Fatalf("invalid registration workflow config: %s", err)
+	}
+
+	// database
+	pool := database.NewConnPool(config.Postgres, *logger)
+	uow := database.NewUnitOfWork(pool, *logger)
+
 	// repository
-	ur := user_repository.NewPostgresqlRepository(config.Postgres, *logger)
+	ur := user_repository.NewPostgresqlRepository(pool, *logger)
 	rsr := user_repository.NewRedisSessionRepository(config.RedisSession, *logger)
 	rur := user_repository.NewRedisUserRepository(config.RedisUser, *logger)
-	rrr := reg_req_repository.NewPostgresqlRepository(config.Postgres, *logger)
+	rrr := reg_req_repository.NewPostgresqlRepository(pool, *logger)
+	rer := events_repository.NewRedisEventsRepository(config.RedisEvents, *logger)
+	rir := idempotency_repository.NewRedisIdempotencyRepository(config.RedisIdem, *logger)
+	nr := notification_repository.NewPostgresqlRepository(pool, *logger)
+	ar := audit_repository.NewPostgresqlRepository(pool, *logger)
+	adr := admission_repository.NewPostgresqlRepository(pool, *logger)
 
 	// router
 	router := mux.NewRouter()
 
 	// usecase
 	uu := user_usecase.NewUserUsecase(ur, rsr, rur, *logger)
+	nu := notification_usecase.NewNotificationUsecase(nr, *logger)
+	eu := events_usecase.NewEventsUsecase(rer, *logger)
+	adu := admission_usecase.NewAdmissionUsecase(adr, ur, *logger)
 
 	// middlewars
 	auth := middleware.NewAuthMiddleware(uu, *logger)
 	roleMw := middleware.NewRoleMiddleware(uu, *logger)
+	idem := middleware.NewIdempotencyMiddleware(rir, config.Idempotency, *logger)
 
 	// files
-	fm := file_manager.SetFileRouting(router, uu, auth, *logger)
+	fm := file_manager.SetFileRouting(router, uu, adu, auth, idem, *logger)
 
 	// usecase
-	rru := reg_req_usecase.NewRegReqUsecase(rrr, ur, fm, *logger)
+	rru := reg_req_usecase.NewRegReqUsecase(rrr, ur, ar, fm, nu, eu, uow, *logger)
 
 	// delivery
-	user_delivery.SetUserRouting(router, uu, auth, roleMw, *logger)
-	reg_req_delivery.SetRegReqRouting(router, rru, auth, roleMw, *logger)
+	user_delivery.SetUserRouting(router, uu, rru, auth, roleMw, *logger)
+	reg_req_delivery.SetRegReqRouting(router, rru, auth, roleMw, idem, *logger)
+	notification_delivery.SetNotificationRouting(router, nu, auth, *logger)
+	events_delivery.SetEventsRouting(router, eu, auth, *logger)
+	admission_delivery.SetAdmissionRouting(router, adu, auth, roleMw, *logger)
 
 	srv := &http.Server{
 		Handler:      router,
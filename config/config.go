@@ -36,6 +36,50 @@ type FileConfig struct {
 	RootPath string
 }
 
+type RejectionReasonConfig struct {
+	Code  string `mapstructure:"code"`
+	Title string `mapstructure:"title"`
+}
+
+// WorkflowStageConfig describes one registration request type
+type WorkflowStageConfig struct {
+	Type  int8   `mapstructure:"type"`
+	Title string `mapstructure:"title"`
+	// parent or child
+	Owner             string   `mapstructure:"owner"`
+	RequiredFields    []string `mapstructure:"required_fields"`
+	RequiredDocuments []string `mapstructure:"required_documents"`
+	// verify_passport, set_stage, issue_credentials
+	Action        string `mapstructure:"action"`
+	RequiresStage int8   `mapstructure:"requires_stage"`
+	GrantsStage   int8   `mapstructure:"grants_stage"`
+	NextType      int8   `mapstructure:"next_type"`
+	// documents which are removed after approval
+	DeleteDocuments []string `mapstructure:"delete_documents"`
+}
+
+type RegReqConfig struct {
+	RejectionReasons []RejectionReasonConfig
+	Workflow         []WorkflowStageConfig
+}
+
+type UserConfig struct {
+	// child profile fields which child can change without admissions
+	ChildSelfEditFields []string
+}
+
+type IdempotencyConfig struct {
+	// how long completed responses are replayed
+	TTL time.Duration
+	// how long key is held by request in progress
+	LockTTL time.Duration
+}
+
+type AdmissionConfig struct {
+	// ttf font with cyrillic glyphs for generated applications
+	FontPath string
+}
+
 type TimeoutsConfig struct {
 	WriteTimeout   time.Duration
 	ReadTimeout    time.Duration
@@ -46,12 +90,73 @@ var (
 	Lokle        ServerConfig
 	RedisSession RedisConfig
 	RedisUser    RedisConfig
+	RedisEvents  RedisConfig
+	RedisIdem    RedisConfig
 	Postgres     PostgresConfig
 	Mailer       MailerConfig
 	Timeouts     TimeoutsConfig
 	File         FileConfig
+	RegReq       RegReqConfig
+	User         UserConfig
+	Idempotency  IdempotencyConfig
+	Admission    AdmissionConfig
 )
 
+var defaultRejectionReasons = []RejectionReasonConfig{
+	{Code: "unreadable", Title: "Документ нечитаем"},
+	{Code: "mismatch", Title: "Данные не совпадают с документом"},
+	{Code: "incomplete", Title: "Данные указаны не полностью"},
+	{Code: "expired", Title: "Срок действия документа истёк"},
+	{Code: "wrong_document", Title: "Загружен не тот документ"},
+	{Code: "other", Title: "Другое"},
+}
+
+// default workflow keeps built-in registration stages
+var defaultWorkflow = []WorkflowStageConfig{
+	{
+		Type:              1,
+		Title:             "Подтверждение паспорта родителя",
+		Owner:             "parent",
+		RequiredDocuments: []string{"passport"},
+		Action:            "verify_passport",
+		DeleteDocuments:   []string{"passport"},
+	},
+	{
+		Type:        2,
+		Title:       "Регистрация ребенка (этап 1 / старый ученик)",
+		Owner:       "child",
+		Action:      "issue_credentials",
+		GrantsStage: 3,
+	},
+	{
+		Type:        3,
+		Title:       "Регистрация ребенка (этап 1 / новый ученик)",
+		Owner:       "child",
+		Action:      "set_stage",
+		GrantsStage: 1,
+		NextType:    4,
+	},
+	{
+		Type:          4,
+		Title:         "Регистрация ребенка (этап 2)",
+		Owner:         "child",
+		Action:        "set_stage",
+		RequiresStage: 1,
+		GrantsStage:   2,
+		NextType:      5,
+	},
+	{
+		Type:              5,
+		Title:             "Регистрация ребенка (этап 3)",
+		Owner:             "child",
+		RequiredDocuments: []string{"passport"},
+		Action:            "issue_credentials",
+		RequiresStage:     2,
+		GrantsStage:       3,
+		DeleteDocuments:   []string{"passport"},
+	},
+}
+
 func SetConfig() {
 	viper.SetConfigFile("config.json")
 	err := viper.ReadInConfig()
@@ -75,6 +180,18 @@ func SetConfig() {
 		DB:       viper.GetInt(`redis.user_db_name`),
 	}
 
+	RedisEvents = RedisConfig{
+		Addr:     viper.GetString(`redis.address`),
+		Password: viper.GetString(`redis.password`),
+		DB:       viper.GetInt(`redis.events_db_name`),
+	}
+
+	RedisIdem = RedisConfig{
+		Addr:     viper.GetString(`redis.address`),
+		Password: viper.GetString(`redis.password`),
+		DB:       viper.GetInt(`redis.idempotency_db_name`),
+	}
+
 	Postgres = PostgresConfig{
 		Port:     viper.GetString(`postgres.port`),
 		Host:     viper.GetString(`postgres.host`),
@@ -94,6 +211,46 @@ func SetConfig() {
 		RootPath: viper.GetString(`file.root_path`),
 	}
 
+	var rejectionReasons []RejectionReasonConfig
+	err = viper.UnmarshalKey(`reg_req.rejection_reasons`, &rejectionReasons)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(rejectionReasons) == 0 {
+		rejectionReasons = defaultRejectionReasons
+	}
+
+	var workflow []WorkflowStageConfig
+	err = viper.UnmarshalKey(`reg_req.workflow`, &workflow)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(workflow) == 0 {
+		workflow = defaultWorkflow
+	}
+
+	RegReq = RegReqConfig{
+		RejectionReasons: rejectionReasons,
+		Workflow:         workflow,
+	}
+
+	viper.SetDefault(`user.child_self_edit_fields`, []string{"phone"})
+	User = UserConfig{
+		ChildSelfEditFields: viper.GetStringSlice(`user.child_self_edit_fields`),
+	}
+
+	viper.SetDefault(`idempotency.ttl`, 24*time.Hour)
+	viper.SetDefault(`idempotency.lock_ttl`, time.Minute)
+	Idempotency = IdempotencyConfig{
+		TTL:     viper.GetDuration(`idempotency.ttl`),
+		LockTTL: viper.GetDuration(`idempotency.lock_ttl`),
+	}
+
+	viper.SetDefault(`admission.font_path`, "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf")
+	Admission = AdmissionConfig{
+		FontPath: viper.GetString(`admission.font_path`),
+	}
+
 	Timeouts = TimeoutsConfig{
 		WriteTimeout:   5 * time.Second,
 		ReadTimeout:    5 * time.Second,
@@ -1,5 +1,7 @@
 create extension if not exists citext;
 
+create extension if not exists pg_trgm;
+
 -- auto-generated definition
 create table users
 (
@@ -13,7 +15,9 @@ create table users
     phone          varchar(16)           not null,
     email          citext                not null,
     email_verified boolean default false not null,
-    password       varchar(64)           not null
+    password       varchar(64)           not null,
+    deactivated    boolean default false not null,
+    create_time    bigint  default (date_part('epoch'::text, now()))::bigint not null
 );
 
 alter table users
@@ -25,6 +29,9 @@ create unique index users_email_uindex
 create unique index users_id_uindex
     on users (id);
 
+create index users_search_index
+    on users using gin ((lower(coalesce(last_name, '') || ' ' || first_name || ' ' || second_name || ' ' || email::text || ' ' || phone)) gin_trgm_ops);
+
 
 
 -- auto-generated definition
@@ -116,31 +123,349 @@ create unique index parents_children_id_uindex
 -- auto-generated definition
 create table registration_requests
 (
-    id          bigserial
+    id                bigserial
         constraint registration_requests_pk
             primary key,
-    user_id     bigint                                           not null
+    user_id           bigint                                           not null
         constraint registration_requests_users_id_fk
             references users
             on update cascade on delete cascade,
-    manager_id  bigint
+    manager_id        bigint
         constraint registration_requests_users_id_fk_2
             references users
-            on update cascade on delete cascade,
-    type        smallint                                         not null,
-    status      varchar(16) default 'pending'::character varying not null,
-    create_time bigint                                           not null,
-    message     varchar(1024) default ''::character varying
+            on update cascade on delete set null,
+    type              smallint                                         not null,
+    status            varchar(16) default 'pending'::character varying not null,
+    create_time       bigint                                           not null,
+    message           varchar(1024) default ''::character varying,
+    escalation_reason varchar(1024) default ''::character varying not null
 );
 
 alter table registration_requests
     owner to lokle_admin;
 
+create index registration_requests_status_index
+    on registration_requests (status);
+
 create unique index registration_requests_id_uindex
     on registration_requests (id);
 
 
 
+-- auto-generated definition
+create table registration_requests_history
+(
+    id              bigserial
+        constraint registration_requests_history_pk
+            primary key,
+    req_id          bigint                                        not null,
+    user_id         bigint                                        not null,
+    manager_id      bigint,
+    type            smallint                                      not null,
+    action          varchar(16)                                   not null,
+    message         varchar(1024) default ''::character varying,
+    req_create_time bigint                                        not null,
+    create_time     bigint                                        not null
+);
+
+alter table registration_requests_history
+    owner to lokle_admin;
+
+create index registration_requests_history_req_id_index
+    on registration_requests_history (req_id);
+
+create index registration_requests_history_create_time_index
+    on registration_requests_history (create_time);
+
+
+
+-- auto-generated definition
+create table registration_request_issues
+(
+    id          bigserial
+        constraint registration_request_issues_pk
+            primary key,
+    req_id      bigint                                       not null
+        constraint registration_request_issues_registration_requests_id_fk
+            references registration_requests
+            on update cascade on delete cascade,
+    field       varchar(32)                                  not null,
+    document    varchar(128)  default ''::character varying not null,
+    reason_code varchar(32)                                  not null,
+    comment     varchar(1024) default ''::character varying not null
+);
+
+alter table registration_request_issues
+    owner to lokle_admin;
+
+create index registration_request_issues_req_id_index
+    on registration_request_issues (req_id);
+
+-- auto-generated definition
+create table registration_request_changes
+(
+    id        bigserial
+        constraint registration_request_changes_pk
+            primary key,
+    req_id    bigint      not null
+        constraint registration_request_changes_registration_requests_id_fk
+            references registration_requests
+            on update cascade on delete cascade,
+    field     varchar(32) not null,
+    old_value text        not null,
+    new_value text        not null
+);
+
+alter table registration_request_changes
+    owner to lokle_admin;
+
+create index registration_request_changes_req_id_index
+    on registration_request_changes (req_id);
+
+-- auto-generated definition
+create table registration_request_messages
+(
+    id          bigserial
+        constraint registration_request_messages_pk
+            primary key,
+    req_id      bigint                      not null,
+    author_id   bigint
+        constraint registration_request_messages_users_id_fk
+            references users
+            on update cascade on delete set null,
+    author_role smallint                    not null,
+    body        varchar(4096)               not null,
+    internal    boolean default false       not null,
+    attachments text[]  default '{}'::text[] not null,
+    create_time bigint                      not null
+);
+
+alter table registration_request_messages
+    owner to lokle_admin;
+
+create index registration_request_messages_req_id_index
+    on registration_request_messages (req_id);
+
+-- auto-generated definition
+create table registration_request_message_reads
+(
+    message_id bigint not null
+        constraint registration_request_message_reads_messages_id_fk
+            references registration_request_messages
+            on update cascade on delete cascade,
+    user_id    bigint not null
+        constraint registration_request_message_reads_users_id_fk
+            references users
+            on update cascade on delete cascade,
+    read_time  bigint not null,
+    constraint registration_request_message_reads_pk
+        primary key (message_id, user_id)
+);
+
+alter table registration_request_message_reads
+    owner to lokle_admin;
+
+-- auto-generated definition
+create table notification_settings
+(
+    user_id        bigint                not null
+        constraint notification_settings_pk
+            primary key
+        constraint notification_settings_users_id_fk
+            references users
+            on update cascade on delete cascade,
+    req_approved   boolean default true not null,
+    req_failed     boolean default true not null,
+    stage_unlocked boolean default true not null
+);
+
+alter table notification_settings
+    owner to lokle_admin;
+
+-- auto-generated definition
+create table notifications
+(
+    id          bigserial
+        constraint notifications_pk
+            primary key,
+    user_id     bigint                 not null
+        constraint notifications_users_id_fk
+            references users
+            on update cascade on delete cascade,
+    type        varchar(32)            not null,
+    title       varchar(256)           not null,
+    body        varchar(1024)          not null,
+    req_id      bigint  default 0      not null,
+    read        boolean default false  not null,
+    create_time bigint                 not null
+);
+
+alter table notifications
+    owner to lokle_admin;
+
+create index notifications_user_id_read_index
+    on notifications (user_id, read);
+
+-- auto-generated definition
+create table parent_invitations
+(
+    id           bigserial
+        constraint parent_invitations_pk
+            primary key,
+    child_id     bigint                 not null
+        constraint parent_invitations_children_id_fk
+            references children
+            on update cascade on delete cascade,
+    inviter_id   bigint                 not null
+        constraint parent_invitations_parents_id_fk
+            references parents
+            on update cascade on delete cascade,
+    invitee_id   bigint
+        constraint parent_invitations_parents_id_fk_2
+            references parents
+            on update cascade on delete cascade,
+    email        citext                 not null,
+    relationship varchar(16) default '' not null,
+    status       varchar(16)            not null,
+    manager_id   bigint
+        constraint parent_invitations_users_id_fk
+            references users
+            on update cascade on delete set null,
+    create_time  bigint                 not null,
+    update_time  bigint                 not null
+);
+
+alter table parent_invitations
+    owner to lokle_admin;
+
+create index parent_invitations_child_id_index
+    on parent_invitations (child_id);
+
+create index parent_invitations_email_status_index
+    on parent_invitations (email, status);
+
+-- auto-generated definition
+create table audit_log
+(
+    id          bigserial
+        constraint audit_log_pk
+            primary key,
+    actor_id    bigint
+        constraint audit_log_users_id_fk
+            references users
+            on update cascade on delete set null,
+    action      varchar(32)              not null,
+    entity_type varchar(16)              not null,
+    entity_id   bigint                   not null,
+    details     varchar(1024) default '' not null,
+    create_time bigint                   not null
+);
+
+alter table audit_log
+    owner to lokle_admin;
+
+create index audit_log_entity_index
+    on audit_log (entity_type, entity_id);
+
+-- auto-generated definition
+create table child_duplicates
+(
+    id           bigserial
+        constraint child_duplicates_pk
+            primary key,
+    child_id     bigint                     not null
+        constraint child_duplicates_users_id_fk
+            references users
+            on update cascade on delete cascade,
+    duplicate_id bigint                     not null
+        constraint child_duplicates_users_id_fk_2
+            references users
+            on update cascade on delete cascade,
+    similarity   real                       not null,
+    passport_match boolean default false    not null,
+    status       varchar(16) default 'open' not null,
+    manager_id   bigint
+        constraint child_duplicates_users_id_fk_3
+            references users
+            on update cascade on delete set null,
+    create_time  bigint                     not null
+);
+
+alter table child_duplicates
+    owner to lokle_admin;
+
+create unique index child_duplicates_pair_uindex
+    on child_duplicates (child_id, duplicate_id);
+
+create index children_birth_date_index
+    on children (birth_date);
+
+create index children_passport_index
+    on children (passport);
+
+-- auto-generated definition
+create table admission_templates
+(
+    id          bigserial
+        constraint admission_templates_pk
+            primary key,
+    title       varchar(128)          not null,
+    body        text                  not null,
+    active      boolean default false not null,
+    author_id   bigint
+        constraint admission_templates_users_id_fk
+            references users
+            on update cascade on delete set null,
+    create_time bigint                not null,
+    update_time bigint                not null
+);
+
+alter table admission_templates
+    owner to lokle_admin;
+
+-- auto-generated definition
+create table user_permissions
+(
+    user_id     bigint      not null
+        constraint user_permissions_users_id_fk
+            references users
+            on update cascade on delete cascade,
+    permission  varchar(32) not null,
+    granted_by  bigint
+        constraint user_permissions_users_id_fk_2
+            references users
+            on update cascade on delete set null,
+    create_time bigint      not null,
+    constraint user_permissions_pk
+        primary key (user_id, permission)
+);
+
+alter table user_permissions
+    owner to lokle_admin;
+
+
+
+drop table if exists user_permissions cascade;
+drop table if exists admission_templates cascade;
+drop table if exists registration_request_changes cascade;
+drop table if exists child_duplicates cascade;
+
+drop table if exists audit_log cascade;
+
+drop table if exists parent_invitations cascade;
+
+drop table if exists notifications cascade;
+
+drop table if exists notification_settings cascade;
+
+drop table if exists registration_request_message_reads cascade;
+
+drop table if exists registration_request_messages cascade;
+
+drop table if exists registration_request_issues cascade;
+
+drop table if exists registration_requests_history cascade;
+
 drop table if exists registration_requests cascade;
 
 drop table if exists parents_children cascade;
@@ -0,0 +1,222 @@
+package delivery
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+
+	"github.com/VoyakinH/lokle_backend/internal/admission/usecase"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/middleware"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type AdmissionDelivery struct {
+	admissionUseCase usecase.IAdmissionUsecase
+	logger           logrus.Logger
+}
+
+func SetAdmissionRouting(router *mux.Router,
+	au usecase.IAdmissionUsecase,
+	auth middleware.AuthMiddleware,
+	roleMw middleware.RoleMiddleware,
+	logger logrus.Logger) {
+	admissionDelivery := &AdmissionDelivery{
+		admissionUseCase: au,
+		logger:           logger,
+	}
+
+	admissionAPI := router.PathPrefix("/api/v1/admission/").Subrouter()
+	admissionAPI.Use(middleware.WithJSON)
+	admissionAPI.Use(auth.WithAuth)
+
+	admissionAPI.Handle("/admin/templates", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.GetTemplates))).Methods(http.MethodGet)
+	admissionAPI.Handle("/admin/template", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.CreateTemplate))).Methods(http.MethodPost)
+	admissionAPI.Handle("/admin/template", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.UpdateTemplate))).Methods(http.MethodPut)
+	admissionAPI.Handle("/admin/template", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.DeleteTemplate))).Methods(http.MethodDelete)
+	admissionAPI.Handle("/admin/template/activate", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.ActivateTemplate))).Methods(http.MethodPost)
+	admissionAPI.Handle("/admin/template/deactivate", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.DeactivateTemplate))).Methods(http.MethodPost)
+	admissionAPI.Handle("/admin/template/preview", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.PreviewTemplate))).Methods(http.MethodGet)
+	admissionAPI.Handle("/admin/placeholders", roleMw.CheckAdmin(http.HandlerFunc(admissionDelivery.GetPlaceholders))).Methods(http.MethodGet)
+}
+
+func (ad *AdmissionDelivery) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templates, status, err := ad.admissionUseCase.GetTemplates(ctx)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.AdmissionTemplatesToRespList(templates))
+}
+
+func (ad *AdmissionDelivery) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ad.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var req models.AdmissionTemplateReq
+	err := ioutils.ReadJSON(r, &req)
+	if err != nil {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	template, status, err := ad.admissionUseCase.CreateTemplate(ctx, admin.ID, req)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.AdmissionTemplateToResp(template))
+}
+
+func (ad *AdmissionDelivery) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ad.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	templateID, err := strconv.ParseUint(r.URL.Query().Get("template"), 10, 64)
+	if err != nil {
+		ad.logger.Errorf("%s invalid template id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	var req models.AdmissionTemplateReq
+	err = ioutils.ReadJSON(r, &req)
+	if err != nil {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	template, status, err := ad.admissionUseCase.UpdateTemplate(ctx, templateID, admin.ID, req)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.AdmissionTemplateToResp(template))
+}
+
+func (ad *AdmissionDelivery) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, err := strconv.ParseUint(r.URL.Query().Get("template"), 10, 64)
+	if err != nil {
+		ad.logger.Errorf("%s invalid template id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ad.admissionUseCase.DeleteTemplate(ctx, templateID)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ad *AdmissionDelivery) ActivateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, err := strconv.ParseUint(r.URL.Query().Get("template"), 10, 64)
+	if err != nil {
+		ad.logger.Errorf("%s invalid template id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ad.admissionUseCase.ActivateTemplate(ctx, templateID)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ad *AdmissionDelivery) DeactivateTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, err := strconv.ParseUint(r.URL.Query().Get("template"), 10, 64)
+	if err != nil {
+		ad.logger.Errorf("%s invalid template id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ad.admissionUseCase.DeactivateTemplate(ctx, templateID)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+// PreviewTemplate sends pdf in the same format as file download
+func (ad *AdmissionDelivery) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ad.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	templateID, err := strconv.ParseUint(query.Get("template"), 10, 64)
+	if err != nil {
+		ad.logger.Errorf("%s invalid template id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	childUID, err := strconv.ParseUint(query.Get("child"), 10, 64)
+	if err != nil {
+		ad.logger.Errorf("%s invalid child id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	pdf, status, err := ad.admissionUseCase.PreviewTemplate(ctx, *admin, templateID, childUID)
+	if err != nil || status != http.StatusOK {
+		ad.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.DonwloadResp{
+		Files: []models.FileStruct{{
+			File: base64.StdEncoding.EncodeToString(pdf),
+			Type: http.DetectContentType(pdf),
+		}},
+	})
+}
+
+func (ad *AdmissionDelivery) GetPlaceholders(w http.ResponseWriter, r *http.Request) {
+	ioutils.Send(w, http.StatusOK, ad.admissionUseCase.GetPlaceholders())
+}
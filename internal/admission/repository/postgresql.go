@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+type IPostgresqlRepository interface {
+	GetTemplates(context.Context) ([]models.AdmissionTemplate, error)
+	GetTemplateByID(context.Context, uint64) (models.AdmissionTemplate, error)
+	GetActiveTemplate(context.Context) (models.AdmissionTemplate, error)
+	CreateTemplate(context.Context, models.AdmissionTemplate) (models.AdmissionTemplate, error)
+	UpdateTemplate(context.Context, models.AdmissionTemplate) (models.AdmissionTemplate, error)
+	DeleteTemplate(context.Context, uint64) error
+	ActivateTemplate(context.Context, uint64) error
+	DeactivateTemplate(context.Context, uint64) error
+}
+
+type postgresqlRepository struct {
+	conn   *pgx.ConnPool
+	logger logrus.Logger
+}
+
+func NewPostgresqlRepository(pool *pgx.ConnPool, logger logrus.Logger) IPostgresqlRepository {
+	return &postgresqlRepository{conn: pool, logger: logger}
+}
+
+// db returns transaction if method is called inside unit of work
+func (pr *postgresqlRepository) db(ctx context.Context) database.Querier {
+	return database.GetQuerier(ctx, pr.conn)
+}
+
+func (pr *postgresqlRepository) GetTemplates(ctx context.Context) ([]models.AdmissionTemplate, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT id, title, body, active, COALESCE(author_id, 0), create_time, update_time
+		FROM admission_templates
+		ORDER BY id;`,
+	)
+	if err != nil {
+		return []models.AdmissionTemplate{}, err
+	}
+	defer rows.Close()
+
+	templates := []models.AdmissionTemplate{}
+	var template models.AdmissionTemplate
+	for rows.Next() {
+		err := rows.Scan(
+			&template.ID,
+			&template.Title,
+			&template.Body,
+			&template.Active,
+			&template.AuthorID,
+			&template.CreateTime,
+			&template.UpdateTime,
+		)
+		if err != nil {
+			return []models.AdmissionTemplate{}, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.AdmissionTemplate{}, err
+	}
+	return templates, nil
+}
+
+func (pr *postgresqlRepository) GetTemplateByID(ctx context.Context, id uint64) (models.AdmissionTemplate, error) {
+	var template models.AdmissionTemplate
+	err := pr.db(ctx).QueryRow(
+		`SELECT id, title, body, active, COALESCE(author_id, 0), create_time, update_time
+		FROM admission_templates
+		WHERE id = $1;`,
+		id,
+	).Scan(
+		&template.ID,
+		&template.Title,
+		&template.Body,
+		&template.Active,
+		&template.AuthorID,
+		&template.CreateTime,
+		&template.UpdateTime,
+	)
+	if err != nil {
+		return models.AdmissionTemplate{}, err
+	}
+	return template, nil
+}
+
+func (pr *postgresqlRepository) GetActiveTemplate(ctx context.Context) (models.AdmissionTemplate, error) {
+	var template models.AdmissionTemplate
+	err := pr.db(ctx).QueryRow(
+		`SELECT id, title, body, active, COALESCE(author_id, 0), create_time, update_time
+		FROM admission_templates
+		WHERE active
+		ORDER BY update_time DESC, id DESC
+		LIMIT 1;`,
+	).Scan(
+		&template.ID,
+		&template.Title,
+		&template.Body,
+		&template.Active,
+		&template.AuthorID,
+		&template.CreateTime,
+		&template.UpdateTime,
+	)
+	if err != nil {
+		return models.AdmissionTemplate{}, err
+	}
+	return template, nil
+}
+
+func (pr *postgresqlRepository) CreateTemplate(ctx context.Context, template models.AdmissionTemplate) (models.AdmissionTemplate, error) {
+	now := uint64(time.Now().Unix())
+	template.CreateTime = now
+	template.UpdateTime = now
+	template.Active = false
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO admission_templates (title, body, author_id, create_time, update_time)
+		VALUES ($1, $2, NULLIF($3::bigint, 0), $4, $5)
+		RETURNING id;`,
+		template.Title,
+		template.Body,
+		template.AuthorID,
+		template.CreateTime,
+		template.UpdateTime,
+	).Scan(
+		&template.ID,
+	)
+	if err != nil {
+		return models.AdmissionTemplate{}, err
+	}
+	return template, nil
+}
+
+func (pr *postgresqlRepository) UpdateTemplate(ctx context.Context, template models.AdmissionTemplate) (models.AdmissionTemplate, error) {
+	err := pr.db(ctx).QueryRow(
+		`UPDATE admission_templates
+		SET title = $2, body = $3, author_id = NULLIF($4::bigint, 0), update_time = $5
+		WHERE id = $1
+		RETURNING active, create_time, update_time;`,
+		template.ID,
+		template.Title,
+		template.Body,
+		template.AuthorID,
+		time.Now().Unix(),
+	).Scan(
+		&template.Active,
+		&template.CreateTime,
+		&template.UpdateTime,
+	)
+	if err != nil {
+		return models.AdmissionTemplate{}, err
+	}
+	return template, nil
+}
+
+func (pr *postgresqlRepository) DeleteTemplate(ctx context.Context, id uint64) error {
+	var deletedID uint64
+	err := pr.db(ctx).QueryRow(
+		`DELETE FROM admission_templates
+		WHERE id = $1
+		RETURNING id;`,
+		id,
+	).Scan(
+		&deletedID,
+	)
+	return err
+}
+
+// ActivateTemplate makes template the only active one with single statement
+func (pr *postgresqlRepository) ActivateTemplate(ctx context.Context, id uint64) error {
+	var activatedID uint64
+	err := pr.db(ctx).QueryRow(
+		`WITH activated AS (
+			UPDATE admission_templates
+			SET active = (id = $1), update_time = $2
+			WHERE active OR id = $1
+			RETURNING id
+		)
+		SELECT id FROM activated WHERE id = $1;`,
+		id,
+		time.Now().Unix(),
+	).Scan(
+		&activatedID,
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) DeactivateTemplate(ctx context.Context, id uint64) error {
+	var deactivatedID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE admission_templates
+		SET active = false, update_time = $2
+		WHERE id = $1
+		RETURNING id;`,
+		id,
+		time.Now().Unix(),
+	).Scan(
+		&deactivatedID,
+	)
+	return err
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/go-pdf/fpdf"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([a-z_.]+)\s*\}\}`)
+
+type placeholder struct {
+	name  string
+	title string
+	value func(models.AdmissionApplication) string
+}
+
+var placeholders = []placeholder{
+	{"child.full_name", "ФИО ребенка", func(a models.AdmissionApplication) string {
+		return tools.FullName(a.Child.LastName, a.Child.FirstName, a.Child.SecondName)
+	}},
+	{"child.last_name", "Фамилия ребенка", func(a models.AdmissionApplication) string { return a.Child.LastName }},
+	{"child.first_name", "Имя ребенка", func(a models.AdmissionApplication) string { return a.Child.FirstName }},
+	{"child.second_name", "Отчество ребенка", func(a models.AdmissionApplication) string { return a.Child.SecondName }},
+	{"child.birth_date", "Дата рождения ребенка", func(a models.AdmissionApplication) string { return tools.FormatDate(a.Child.BirthDate) }},
+	{"child.passport", "Паспорт ребенка", func(a models.AdmissionApplication) string { return a.Child.Passport }},
+	{"child.place_of_residence", "Адрес проживания ребенка", func(a models.AdmissionApplication) string { return a.Child.PlaceOfResidence }},
+	{"child.place_of_registration", "Адрес регистрации ребенка", func(a models.AdmissionApplication) string { return a.Child.PlaceOfRegistration }},
+	{"child.phone", "Телефон ребенка", func(a models.AdmissionApplication) string { return a.Child.Phone }},
+	{"child.email", "Почта ребенка", func(a models.AdmissionApplication) string { return a.Child.Email }},
+	{"parent.full_name", "ФИО родителя", func(a models.AdmissionApplication) string {
+		return tools.FullName(a.Parent.LastName, a.Parent.FirstName, a.Parent.SecondName)
+	}},
+	{"parent.last_name", "Фамилия родителя", func(a models.AdmissionApplication) string { return a.Parent.LastName }},
+	{"parent.first_name", "Имя родителя", func(a models.AdmissionApplication) string { return a.Parent.FirstName }},
+	{"parent.second_name", "Отчество родителя", func(a models.AdmissionApplication) string { return a.Parent.SecondName }},
+	{"parent.passport", "Паспорт родителя", func(a models.AdmissionApplication) string { return a.Parent.Passport }},
+	{"parent.phone", "Телефон родителя", func(a models.AdmissionApplication) string { return a.Parent.Phone }},
+	{"parent.email", "Почта родителя", func(a models.AdmissionApplication) string { return a.Parent.Email }},
+	{"parent.relationship", "Кем приходится ребенку", func(a models.AdmissionApplication) string { return a.Relationship }},
+	{"date", "Дата формирования заявления", func(a models.AdmissionApplication) string {
+		return tools.FormatDate(uint64(time.Now().Unix()))
+	}},
+}
+
+var placeholderValues = func() map[string]func(models.AdmissionApplication) string {
+	values := make(map[string]func(models.AdmissionApplication) string, len(placeholders))
+	for _, placeholder := range placeholders {
+		values[placeholder.name] = placeholder.value
+	}
+	return values
+}()
+
+// defaultTemplate is used while admins haven't activated own template
+const defaultTemplate = `Директору
+
+ЗАЯВЛЕНИЕ
+
+Я, {{parent.full_name}} ({{parent.relationship}}), паспорт {{parent.passport}}, телефон {{parent.phone}}, электронная почта {{parent.email}}, прошу принять моего ребенка {{child.full_name}}, дата рождения {{child.birth_date}}, паспорт {{child.passport}}, на обучение.
+
+Адрес регистрации ребенка: {{child.place_of_registration}}
+Адрес проживания ребенка: {{child.place_of_residence}}
+
+Дата: {{date}}                                        Подпись: ____________________`
+
+func fillTemplate(body string, app models.AdmissionApplication) string {
+	return placeholderRegexp.ReplaceAllStringFunc(body, func(match string) string {
+		name := placeholderRegexp.FindStringSubmatch(match)[1]
+		value, ok := placeholderValues[name]
+		if !ok {
+			return match
+		}
+		return value(app)
+	})
+}
+
+const applicationFont = "application"
+
+// renderPDF writes text to A4 pages, font must support cyrillic
+func renderPDF(text string) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", filepath.Dir(config.Admission.FontPath))
+	pdf.AddUTF8Font(applicationFont, "", filepath.Base(config.Admission.FontPath))
+	pdf.SetFont(applicationFont, "", 12)
+	pdf.SetMargins(20, 20, 15)
+	pdf.AddPage()
+	pdf.MultiCell(0, 6, text, "", "L", false)
+
+	var buf bytes.Buffer
+	err := pdf.Output(&buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
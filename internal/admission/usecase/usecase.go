@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/VoyakinH/lokle_backend/internal/admission/repository"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/crypt"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	user_repository "github.com/VoyakinH/lokle_backend/internal/user/repository"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxTemplateTitleLen = 128
+	maxTemplateBodyLen  = 16384
+)
+
+type IAdmissionUsecase interface {
+	GetTemplates(context.Context) ([]models.AdmissionTemplate, int, error)
+	CreateTemplate(context.Context, uint64, models.AdmissionTemplateReq) (models.AdmissionTemplate, int, error)
+	UpdateTemplate(context.Context, uint64, uint64, models.AdmissionTemplateReq) (models.AdmissionTemplate, int, error)
+	DeleteTemplate(context.Context, uint64) (int, error)
+	ActivateTemplate(context.Context, uint64) (int, error)
+	DeactivateTemplate(context.Context, uint64) (int, error)
+	GetPlaceholders() models.AdmissionPlaceholderRespList
+	PreviewTemplate(context.Context, models.User, uint64, uint64) ([]byte, int, error)
+	GenerateApplication(context.Context, models.User, uint64) ([]byte, int, error)
+}
+
+type admissionUsecase struct {
+	psql     repository.IPostgresqlRepository
+	userPsql user_repository.IPostgresqlRepository
+	logger   logrus.Logger
+}
+
+func NewAdmissionUsecase(pr repository.IPostgresqlRepository, ur user_repository.IPostgresqlRepository, logger logrus.Logger) IAdmissionUsecase {
+	return &admissionUsecase{
+		psql:     pr,
+		userPsql: ur,
+		logger:   logger,
+	}
+}
+
+func (au *admissionUsecase) GetTemplates(ctx context.Context) ([]models.AdmissionTemplate, int, error) {
+	templates, err := au.psql.GetTemplates(ctx)
+	if err != nil {
+		return []models.AdmissionTemplate{}, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.GetTemplates: failed to get templates with err: %s", err)
+	}
+	return templates, http.StatusOK, nil
+}
+
+func validateTemplate(req models.AdmissionTemplateReq) error {
+	titleLen := utf8.RuneCountInString(strings.TrimSpace(req.Title))
+	if titleLen == 0 || titleLen > maxTemplateTitleLen {
+		return fmt.Errorf("invalid template title length %d", titleLen)
+	}
+	bodyLen := utf8.RuneCountInString(strings.TrimSpace(req.Body))
+	if bodyLen == 0 || bodyLen > maxTemplateBodyLen {
+		return fmt.Errorf("invalid template body length %d", bodyLen)
+	}
+	for _, match := range placeholderRegexp.FindAllStringSubmatch(req.Body, -1) {
+		if _, ok := placeholderValues[match[1]]; !ok {
+			return fmt.Errorf("unknown placeholder %s", match[0])
+		}
+	}
+	return nil
+}
+
+func (au *admissionUsecase) CreateTemplate(ctx context.Context, authorID uint64, req models.AdmissionTemplateReq) (models.AdmissionTemplate, int, error) {
+	err := validateTemplate(req)
+	if err != nil {
+		return models.AdmissionTemplate{}, http.StatusBadRequest, fmt.Errorf("AdmissionUsecase.CreateTemplate: %s", err)
+	}
+	template, err := au.psql.CreateTemplate(ctx, models.AdmissionTemplate{
+		Title:    strings.TrimSpace(req.Title),
+		Body:     req.Body,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		return models.AdmissionTemplate{}, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.CreateTemplate: failed to create template with err: %s", err)
+	}
+	return template, http.StatusOK, nil
+}
+
+func (au *admissionUsecase) UpdateTemplate(ctx context.Context, id uint64, authorID uint64, req models.AdmissionTemplateReq) (models.AdmissionTemplate, int, error) {
+	err := validateTemplate(req)
+	if err != nil {
+		return models.AdmissionTemplate{}, http.StatusBadRequest, fmt.Errorf("AdmissionUsecase.UpdateTemplate: %s", err)
+	}
+	template, err := au.psql.UpdateTemplate(ctx, models.AdmissionTemplate{
+		ID:       id,
+		Title:    strings.TrimSpace(req.Title),
+		Body:     req.Body,
+		AuthorID: authorID,
+	})
+	if err == pgx.ErrNoRows {
+		return models.AdmissionTemplate{}, http.StatusNotFound, fmt.Errorf("AdmissionUsecase.UpdateTemplate: template not found")
+	} else if err != nil {
+		return models.AdmissionTemplate{}, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.UpdateTemplate: failed to update template with err: %s", err)
+	}
+	return template, http.StatusOK, nil
+}
+
+func (au *admissionUsecase) DeleteTemplate(ctx context.Context, id uint64) (int, error) {
+	err := au.psql.DeleteTemplate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("AdmissionUsecase.DeleteTemplate: template not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.DeleteTemplate: failed to delete template with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// ActivateTemplate makes template used for all generated applications
+func (au *admissionUsecase) ActivateTemplate(ctx context.Context, id uint64) (int, error) {
+	err := au.psql.ActivateTemplate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("AdmissionUsecase.ActivateTemplate: template not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.ActivateTemplate: failed to activate template with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// DeactivateTemplate returns built-in template if there is no other active template
+func (au *admissionUsecase) DeactivateTemplate(ctx context.Context, id uint64) (int, error) {
+	err := au.psql.DeactivateTemplate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("AdmissionUsecase.DeactivateTemplate: template not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.DeactivateTemplate: failed to deactivate template with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+func (au *admissionUsecase) GetPlaceholders() models.AdmissionPlaceholderRespList {
+	resp := make(models.AdmissionPlaceholderRespList, 0, len(placeholders))
+	for _, placeholder := range placeholders {
+		resp = append(resp, models.AdmissionPlaceholderResp{
+			Name:  "{{" + placeholder.name + "}}",
+			Title: placeholder.title,
+		})
+	}
+	return resp
+}
+
+// PreviewTemplate lets admin check any template with data of real child
+func (au *admissionUsecase) PreviewTemplate(ctx context.Context, admin models.User, templateID uint64, childUID uint64) ([]byte, int, error) {
+	template, err := au.psql.GetTemplateByID(ctx, templateID)
+	if err == pgx.ErrNoRows {
+		return nil, http.StatusNotFound, fmt.Errorf("AdmissionUsecase.PreviewTemplate: template not found")
+	} else if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.PreviewTemplate: failed to get template with err: %s", err)
+	}
+	app, status, err := au.getApplication(ctx, admin, childUID)
+	if err != nil {
+		return nil, status, fmt.Errorf("AdmissionUsecase.PreviewTemplate: %s", err)
+	}
+	pdf, err := renderPDF(fillTemplate(template.Body, app))
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.PreviewTemplate: failed to render pdf with err: %s", err)
+	}
+	return pdf, http.StatusOK, nil
+}
+
+// GenerateApplication fills active template with data of child and his parent
+func (au *admissionUsecase) GenerateApplication(ctx context.Context, user models.User, childUID uint64) ([]byte, int, error) {
+	app, status, err := au.getApplication(ctx, user, childUID)
+	if err != nil {
+		return nil, status, fmt.Errorf("AdmissionUsecase.GenerateApplication: %s", err)
+	}
+
+	body := defaultTemplate
+	template, err := au.psql.GetActiveTemplate(ctx)
+	if err == nil {
+		body = template.Body
+	} else if err != pgx.ErrNoRows {
+		return nil, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.GenerateApplication: failed to get active template with err: %s", err)
+	}
+
+	pdf, err := renderPDF(fillTemplate(body, app))
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("AdmissionUsecase.GenerateApplication: failed to render pdf with err: %s", err)
+	}
+	return pdf, http.StatusOK, nil
+}
+
+// getApplication checks user access to child and collects data for template,
+// parent fills application for himself, for others first linked parent is used.
+// Passports are masked for staff without permission to view them and parent's passport is masked for child
+func (au *admissionUsecase) getApplication(ctx context.Context, user models.User, childUID uint64) (models.AdmissionApplication, int, error) {
+	child, err := au.userPsql.GetChildByUID(ctx, childUID)
+	if err == pgx.ErrNoRows {
+		return models.AdmissionApplication{}, http.StatusNotFound, fmt.Errorf("child not found")
+	} else if err != nil {
+		return models.AdmissionApplication{}, http.StatusInternalServerError, fmt.Errorf("failed to get child with err: %s", err)
+	}
+
+	childParents, err := au.userPsql.GetChildParents(ctx, child.UserID)
+	if err != nil {
+		return models.AdmissionApplication{}, http.StatusInternalServerError, fmt.Errorf("failed to get child's parents with err: %s", err)
+	}
+
+	var parentUID uint64
+	switch user.Role {
+	case models.ParentRole:
+		parentUID = user.ID
+	case models.ChildRole:
+		if user.ID != child.UserID {
+			return models.AdmissionApplication{}, http.StatusForbidden, fmt.Errorf("child can get only own application")
+		}
+	case models.ManagerRole, models.AdminRole:
+	default:
+		return models.AdmissionApplication{}, http.StatusForbidden, fmt.Errorf("unknown role %s", user.Role.String())
+	}
+	if parentUID == 0 && len(childParents) != 0 {
+		parentUID = childParents[0].User.ID
+	}
+
+	app := models.AdmissionApplication{Child: child}
+	linked := false
+	for _, childParent := range childParents {
+		if childParent.User.ID == parentUID {
+			app.Relationship = childParent.Relationship
+			linked = true
+		}
+	}
+	if user.Role == models.ParentRole && !linked {
+		return models.AdmissionApplication{}, http.StatusForbidden, fmt.Errorf("current child isn't child of current parent")
+	}
+
+	if linked {
+		app.Parent, err = au.userPsql.GetParentByUID(ctx, parentUID)
+		if err != nil {
+			return models.AdmissionApplication{}, http.StatusInternalServerError, fmt.Errorf("failed to get parent with err: %s", err)
+		}
+	}
+
+	showPassports := true
+	if user.Role == models.ManagerRole || user.Role == models.AdminRole {
+		showPassports, err = au.userPsql.HasPermission(ctx, user.ID, models.ViewPassportsPermission)
+		if err != nil {
+			return models.AdmissionApplication{}, http.StatusInternalServerError, fmt.Errorf("failed to check permission with err: %s", err)
+		}
+	}
+	// child sees only own passport
+	showParentPassport := showPassports && user.Role != models.ChildRole
+	for _, passport := range []struct {
+		value *string
+		show  bool
+	}{
+		{&app.Child.Passport, showPassports},
+		{&app.Parent.Passport, showParentPassport},
+	} {
+		if *passport.value == "" {
+			continue
+		}
+		*passport.value, err = crypt.Decrypt(*passport.value)
+		if err != nil {
+			return models.AdmissionApplication{}, http.StatusInternalServerError, fmt.Errorf("failed to decrypt passport with err: %s", err)
+		}
+		if !passport.show {
+			*passport.value = tools.MaskPassport(*passport.value)
+		}
+	}
+	return app, http.StatusOK, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+type IPostgresqlRepository interface {
+	AddRecord(context.Context, models.AuditRecord) error
+}
+
+type postgresqlRepository struct {
+	conn   *pgx.ConnPool
+	logger logrus.Logger
+}
+
+func NewPostgresqlRepository(pool *pgx.ConnPool, logger logrus.Logger) IPostgresqlRepository {
+	return &postgresqlRepository{conn: pool, logger: logger}
+}
+
+// db returns transaction if method is called inside unit of work
+func (pr *postgresqlRepository) db(ctx context.Context) database.Querier {
+	return database.GetQuerier(ctx, pr.conn)
+}
+
+func (pr *postgresqlRepository) AddRecord(ctx context.Context, record models.AuditRecord) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO audit_log (actor_id, action, entity_type, entity_id, details, create_time)
+		VALUES (NULLIF($1::bigint, 0), $2, $3, $4, $5, $6)
+		RETURNING id;`,
+		record.ActorID,
+		record.Action,
+		record.EntityType,
+		record.EntityID,
+		record.Details,
+		time.Now().Unix(),
+	).Scan(
+		&id,
+	)
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/events/usecase"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/middleware"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// keeps connection alive through proxies
+const heartbeatPeriod = 30 * time.Second
+
+type EventsDelivery struct {
+	eventsUseCase usecase.IEventsUsecase
+	logger        logrus.Logger
+}
+
+func SetEventsRouting(router *mux.Router,
+	eu usecase.IEventsUsecase,
+	auth middleware.AuthMiddleware,
+	logger logrus.Logger) {
+	eventsDelivery := &EventsDelivery{
+		eventsUseCase: eu,
+		logger:        logger,
+	}
+
+	eventsAPI := router.PathPrefix("/api/v1/events").Subrouter()
+	eventsAPI.Use(auth.WithAuth)
+
+	eventsAPI.HandleFunc("/stream", eventsDelivery.Stream).Methods(http.MethodGet)
+}
+
+// Stream sends request events to client as Server-Sent Events
+func (ed *EventsDelivery) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		ed.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		ed.logger.Errorf("%s streaming is not supported [status=%d]", r.URL, http.StatusInternalServerError)
+		ioutils.SendDefaultError(w, http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe := ed.eventsUseCase.Subscribe(*user)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatPeriod)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event := <-events:
+			data, err := tools.RegReqEventToResp(event).MarshalJSON()
+			if err != nil {
+				ed.logger.Errorf("%s failed to marshal event for request %d with [error=%s]", r.URL, event.ReqID, err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+const regReqEventsChannel = "reg_req_events"
+
+type IRedisEventsRepository interface {
+	PublishRegReqEvent(context.Context, models.RegReqEvent) error
+	SubscribeRegReqEvents(context.Context) <-chan models.RegReqEvent
+}
+
+type redisEventsRepository struct {
+	client *redis.Client
+	logger logrus.Logger
+}
+
+func NewRedisEventsRepository(cfg config.RedisConfig, logger logrus.Logger) IRedisEventsRepository {
+	return &redisEventsRepository{
+		client: redis.NewClient(&redis.Options{
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}),
+		logger: logger,
+	}
+}
+
+func (rer *redisEventsRepository) PublishRegReqEvent(ctx context.Context, event models.RegReqEvent) error {
+	payload, err := event.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return rer.client.Publish(ctx, regReqEventsChannel, payload).Err()
+}
+
+// returned channel is closed when ctx is done
+func (rer *redisEventsRepository) SubscribeRegReqEvents(ctx context.Context) <-chan models.RegReqEvent {
+	events := make(chan models.RegReqEvent)
+	pubsub := rer.client.Subscribe(ctx, regReqEventsChannel)
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		msgs := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				var event models.RegReqEvent
+				err := event.UnmarshalJSON([]byte(msg.Payload))
+				if err != nil {
+					rer.logger.Errorf("RedisEventsRepository.SubscribeRegReqEvents: failed to unmarshal event with err: %s", err)
+					continue
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
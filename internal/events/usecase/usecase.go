@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/VoyakinH/lokle_backend/internal/events/repository"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/sirupsen/logrus"
+)
+
+// events which subscriber doesn't read in time are dropped
+const subscriberBufferSize = 64
+
+type IEventsUsecase interface {
+	PublishRegReqEvent(context.Context, models.RegReqEvent) error
+	Subscribe(models.User) (<-chan models.RegReqEvent, func())
+}
+
+type subscriber struct {
+	user   models.User
+	events chan models.RegReqEvent
+}
+
+type eventsUsecase struct {
+	rds         repository.IRedisEventsRepository
+	logger      logrus.Logger
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// every api instance has single redis subscription
+// which is shared by all local stream connections
+func NewEventsUsecase(rer repository.IRedisEventsRepository, logger logrus.Logger) IEventsUsecase {
+	eu := &eventsUsecase{
+		rds:         rer,
+		logger:      logger,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+	go eu.listen(context.Background())
+	return eu
+}
+
+func (eu *eventsUsecase) listen(ctx context.Context) {
+	for event := range eu.rds.SubscribeRegReqEvents(ctx) {
+		eu.broadcast(event)
+	}
+}
+
+func canReceive(user models.User, event models.RegReqEvent) bool {
+	if user.Role == models.ManagerRole || user.Role == models.AdminRole {
+		return true
+	}
+	for _, uid := range event.Recipients {
+		if uid == user.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (eu *eventsUsecase) broadcast(event models.RegReqEvent) {
+	eu.mu.RLock()
+	defer eu.mu.RUnlock()
+	for sub := range eu.subscribers {
+		if !canReceive(sub.user, event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			eu.logger.Errorf("EventsUsecase.broadcast: subscriber buffer is full, event for request %d dropped for user %d", event.ReqID, sub.user.ID)
+		}
+	}
+}
+
+func (eu *eventsUsecase) PublishRegReqEvent(ctx context.Context, event models.RegReqEvent) error {
+	err := eu.rds.PublishRegReqEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf("EventsUsecase.PublishRegReqEvent: failed to publish event with err: %s", err)
+	}
+	return nil
+}
+
+func (eu *eventsUsecase) Subscribe(user models.User) (<-chan models.RegReqEvent, func()) {
+	sub := &subscriber{
+		user:   user,
+		events: make(chan models.RegReqEvent, subscriberBufferSize),
+	}
+	eu.mu.Lock()
+	eu.subscribers[sub] = struct{}{}
+	eu.mu.Unlock()
+
+	unsubscribe := func() {
+		eu.mu.Lock()
+		delete(eu.subscribers, sub)
+		eu.mu.Unlock()
+	}
+	return sub.events, unsubscribe
+}
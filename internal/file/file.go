@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/VoyakinH/lokle_backend/config"
+	admission_usecase "github.com/VoyakinH/lokle_backend/internal/admission/usecase"
 	"github.com/VoyakinH/lokle_backend/internal/models"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/hasher"
@@ -27,25 +28,30 @@ import (
 )
 
 type FileManager struct {
-	rootPath    string
-	userUseCase usecase.IUserUsecase
-	logger      logrus.Logger
+	rootPath         string
+	userUseCase      usecase.IUserUsecase
+	admissionUseCase admission_usecase.IAdmissionUsecase
+	logger           logrus.Logger
 }
 
 func SetFileRouting(router *mux.Router,
 	uu usecase.IUserUsecase,
+	au admission_usecase.IAdmissionUsecase,
 	auth middleware.AuthMiddleware,
+	idem middleware.IdempotencyMiddleware,
 	logger logrus.Logger) FileManager {
 	fileManager := FileManager{
-		rootPath:    config.File.RootPath,
-		userUseCase: uu,
-		logger:      logger,
+		rootPath:         config.File.RootPath,
+		userUseCase:      uu,
+		admissionUseCase: au,
+		logger:           logger,
 	}
 
 	fileAPI := router.PathPrefix("/api/v1/file/").Subrouter()
-	fileAPI.Handle("/upload", auth.WithAuth(http.HandlerFunc(fileManager.Upload))).Methods(http.MethodPost)
+	fileAPI.Handle("/upload", auth.WithAuth(idem.WithIdempotency(http.HandlerFunc(fileManager.Upload)))).Methods(http.MethodPost)
 	fileAPI.Handle("/download", auth.WithAuth(http.HandlerFunc(fileManager.Download))).Methods(http.MethodPost)
 	fileAPI.Handle("/delete", auth.WithAuth(http.HandlerFunc(fileManager.Delete))).Methods(http.MethodPost)
+	fileAPI.Handle("/list", auth.WithAuth(http.HandlerFunc(fileManager.List))).Methods(http.MethodGet)
 
 	return fileManager
 }
@@ -59,6 +65,10 @@ const (
 	DownloadResponseZipType = "zip"
 )
 
+// files uploaded by managers as message attachments are kept in request owner's dir,
+// prefix doesn't let them be taken for documents required by stages
+const ManagerFilePrefix = "manager_"
+
 func isEnabledFileType(fileType string) bool {
 	imgTypes := map[string]bool{
 		"image/jpg":       true,
@@ -169,6 +179,37 @@ func (fm *FileManager) Upload(w http.ResponseWriter, r *http.Request) {
 			ioutils.SendDefaultError(w, http.StatusForbidden)
 			return
 		}
+	case models.ManagerRole:
+		ownerUser, status, err := fm.userUseCase.GetUserByID(ctx, userID)
+		if err != nil || status != http.StatusOK {
+			fm.logger.Errorf("%s failed get user [role=%s] [status=%d] [error=%s]", r.URL, user.Role.String(), status, err)
+			ioutils.SendDefaultError(w, status)
+			return
+		}
+		if ownerUser.Role == models.ParentRole {
+			parent, status, err := fm.userUseCase.GetParentByUID(ctx, ownerUser.ID)
+			if err != nil || status != http.StatusOK {
+				fm.logger.Errorf("%s failed get parent user [role=%s] [status=%d] [error=%s]", r.URL, user.Role.String(), status, err)
+				ioutils.SendDefaultError(w, status)
+				return
+			}
+			uploadUser.DirPath = parent.DirPath
+		} else if ownerUser.Role == models.ChildRole {
+			child, status, err := fm.userUseCase.GetChildByUID(ctx, ownerUser.ID)
+			if err != nil || status != http.StatusOK {
+				fm.logger.Errorf("%s failed get child user [role=%s] [status=%d] [error=%s]", r.URL, user.Role.String(), status, err)
+				ioutils.SendDefaultError(w, status)
+				return
+			}
+			uploadUser.DirPath = child.DirPath
+		} else {
+			fm.logger.Errorf("%s manager try to upload file not for parent or child [role=%s] [status=%d]", r.URL, user.Role.String(), http.StatusForbidden)
+			ioutils.SendDefaultError(w, http.StatusForbidden)
+			return
+		}
+		uploadUser.Email = ownerUser.Email
+		uploadUser.Role = ownerUser.Role
+		commonFilename = ManagerFilePrefix + commonFilename
 	default:
 		fm.logger.Errorf("%s unknown role while getting dir path [role=%s] [status=%d]", r.URL, user.Role.String(), http.StatusInternalServerError)
 		ioutils.SendDefaultError(w, http.StatusInternalServerError)
@@ -229,7 +270,7 @@ func (fm *FileManager) Upload(w http.ResponseWriter, r *http.Request) {
 	})
 
 	if err != nil {
-		fm.logger.Errorf("%s failed check files in user dir %s [status=%d]", r.URL, http.StatusInternalServerError)
+		fm.logger.Errorf("%s failed check files in user dir %s [status=%d]", r.URL, uploadUser.DirPath, http.StatusInternalServerError)
 		ioutils.SendDefaultError(w, http.StatusInternalServerError)
 		return
 	}
@@ -312,19 +353,23 @@ func (fm *FileManager) sendFile(w http.ResponseWriter, filePaths []string, handl
 			ioutils.SendDefaultError(w, http.StatusInternalServerError)
 		}
 
-		// Determine the content type of the file
-		mimeType := http.DetectContentType(bytes)
-
-		// Append the base64 encoded output
-		base64Encoding := base64.StdEncoding.EncodeToString(bytes)
-		resp.Files = append(resp.Files, models.FileStruct{
-			File: base64Encoding,
-			Type: mimeType,
-		})
+		resp.Files = append(resp.Files, newFileStruct(bytes))
 	}
 	ioutils.Send(w, http.StatusOK, resp)
 }
 
+func newFileStruct(bytes []byte) models.FileStruct {
+	// Determine the content type of the file
+	mimeType := http.DetectContentType(bytes)
+
+	// Append the base64 encoded output
+	base64Encoding := base64.StdEncoding.EncodeToString(bytes)
+	return models.FileStruct{
+		File: base64Encoding,
+		Type: mimeType,
+	}
+}
+
 func (fm *FileManager) sendZip(w http.ResponseWriter, filePaths []string, handlerURL string) {
 	zipWriter := zip.NewWriter(w)
 
@@ -377,8 +422,27 @@ func (fm *FileManager) Download(w http.ResponseWriter, r *http.Request) {
 
 	userFiles := make([]string, 0)
 	for _, fileName := range req.FileName {
-		// static file
+		// application is filled with child data, other users get blank static file
 		if fileName == applicationForAdmissionFileName {
+			owner, status, err := fm.userUseCase.GetUserByID(ctx, req.UserID)
+			if err != nil && status != http.StatusNotFound {
+				fm.logger.Errorf("%s failed get user [role=%s] [status=%d] [error=%s]", r.URL, user.Role.String(), status, err)
+				ioutils.SendDefaultError(w, status)
+				return
+			}
+			if err == nil && owner.Role == models.ChildRole {
+				application, status, err := fm.admissionUseCase.GenerateApplication(ctx, *user, owner.ID)
+				if err != nil || status != http.StatusOK {
+					fm.logger.Errorf("%s failed generate application with [status=%d] [error=%s]", r.URL, status, err)
+					ioutils.SendDefaultError(w, status)
+					return
+				}
+				ioutils.Send(w, http.StatusOK, models.DonwloadResp{
+					Files: []models.FileStruct{newFileStruct(application)},
+				})
+				return
+			}
+
 			filePath := fmt.Sprintf("%s/%s%s%s", fm.rootPath, staticFilesFolder, applicationForAdmissionFileName, applicationForAdmissionExt)
 			fm.sendFile(w, []string{filePath}, r.URL.String())
 			return
@@ -568,6 +632,31 @@ func (fm *FileManager) Delete(w http.ResponseWriter, r *http.Request) {
 	ioutils.SendWithoutBody(w, http.StatusOK)
 }
 
+// List returns names of own uploaded files of parent or child
+func (fm *FileManager) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		fm.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+	if user.Role != models.ParentRole && user.Role != models.ChildRole {
+		fm.logger.Errorf("%s role %s hasn't own files [status=%d]", r.URL, user.Role.String(), http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	files, err := fm.ListFiles(ctx, user.ID, user.Role)
+	if err != nil {
+		fm.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusInternalServerError, err)
+		ioutils.SendDefaultError(w, http.StatusInternalServerError)
+		return
+	}
+
+	ioutils.Send(w, http.StatusOK, models.FileListResp{Files: files})
+}
+
 func isDirEmpty(name string) (bool, error) {
 	f, err := os.Open(name)
 	if err != nil {
@@ -609,7 +698,7 @@ func (fm *FileManager) DeleteFile(ctx context.Context, uid uint64, userRole mode
 	var sameFilesCount int64
 	err := filepath.Walk(userDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
-			fm.logger.Errorf("FileManager.DeleteFile: file walk failed with [role=%d] [error=%s]", userRole.String(), err)
+			fm.logger.Errorf("FileManager.DeleteFile: file walk failed with [role=%s] [error=%s]", userRole.String(), err)
 			return nil
 		}
 		if !info.IsDir() && isEnabledExt(filepath.Ext(path)) && strings.Contains(path, fileName) {
@@ -708,3 +797,110 @@ func (fm *FileManager) DeleteDir(ctx context.Context, uid uint64, userRole model
 
 	return nil
 }
+
+// RemoveDir removes dir of user who has been already deleted from db
+func (fm *FileManager) RemoveDir(userDirPath string) error {
+	if userDirPath == "" {
+		return nil
+	}
+	err := os.RemoveAll(fmt.Sprintf("%s/%s", fm.rootPath, userDirPath))
+	if err != nil {
+		return fmt.Errorf("FileManager.RemoveDir: failed to rm user dir [error=%s]", err)
+	}
+	return nil
+}
+
+// MoveDir moves files of user who has been already deleted from db into dir of another user,
+// moved files are numbered after files with the same name which are already there
+func (fm *FileManager) MoveDir(fromDirPath string, toDirPath string) error {
+	if fromDirPath == "" || fromDirPath == toDirPath {
+		return nil
+	}
+	fromDir := fmt.Sprintf("%s/%s", fm.rootPath, fromDirPath)
+	toDir := fmt.Sprintf("%s/%s", fm.rootPath, toDirPath)
+	entries, err := os.ReadDir(fromDir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("FileManager.MoveDir: failed to read user dir [error=%s]", err)
+	}
+	toEntries, err := os.ReadDir(toDir)
+	if err != nil {
+		return fmt.Errorf("FileManager.MoveDir: failed to read target dir [error=%s]", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isEnabledExt(filepath.Ext(entry.Name())) {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		commonFilename := strings.TrimSuffix(entry.Name(), ext)
+		if i := strings.LastIndex(commonFilename, "_"); i > 0 {
+			commonFilename = commonFilename[:i]
+		}
+
+		// the same way as upload does
+		sameFilesCount := 0
+		for _, toEntry := range toEntries {
+			if !toEntry.IsDir() && isEnabledExt(filepath.Ext(toEntry.Name())) && strings.Contains(toEntry.Name(), commonFilename) {
+				sameFilesCount += 1
+			}
+		}
+		curFilename := fmt.Sprintf("%s_%d%s", commonFilename, sameFilesCount, ext)
+		err = os.Rename(fmt.Sprintf("%s/%s", fromDir, entry.Name()), fmt.Sprintf("%s/%s", toDir, curFilename))
+		if err != nil {
+			return fmt.Errorf("FileManager.MoveDir: failed to move user file %s [error=%s]", entry.Name(), err)
+		}
+		toEntries, err = os.ReadDir(toDir)
+		if err != nil {
+			return fmt.Errorf("FileManager.MoveDir: failed to read target dir [error=%s]", err)
+		}
+	}
+
+	err = os.RemoveAll(fromDir)
+	if err != nil {
+		return fmt.Errorf("FileManager.MoveDir: failed to rm user dir [error=%s]", err)
+	}
+	return nil
+}
+
+// ListFiles returns names of all user's uploaded files
+func (fm *FileManager) ListFiles(ctx context.Context, uid uint64, userRole models.Role) ([]string, error) {
+	var userDirPath string
+	switch userRole {
+	case models.ParentRole:
+		parent, status, err := fm.userUseCase.GetParentByUID(ctx, uid)
+		if err != nil || status != http.StatusOK {
+			return []string{}, fmt.Errorf("FileManager.ListFiles: failed get parent user [role=%s] [error=%s]", userRole.String(), err)
+		}
+		userDirPath = parent.DirPath
+	case models.ChildRole:
+		child, status, err := fm.userUseCase.GetChildByUID(ctx, uid)
+		if err != nil || status != http.StatusOK {
+			return []string{}, fmt.Errorf("FileManager.ListFiles: failed get child user [role=%s] [error=%s]", userRole.String(), err)
+		}
+		userDirPath = child.DirPath
+	default:
+		return []string{}, fmt.Errorf("FileManager.ListFiles: unknown role while getting dir path [role=%s]", userRole.String())
+	}
+
+	// user hasn't uploaded any file yet
+	if userDirPath == "" {
+		return []string{}, nil
+	}
+
+	entries, err := os.ReadDir(fmt.Sprintf("%s/%s", fm.rootPath, userDirPath))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	} else if err != nil {
+		return []string{}, fmt.Errorf("FileManager.ListFiles: failed to read user dir [role=%s] [error=%s]", userRole.String(), err)
+	}
+
+	userFiles := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && isEnabledExt(filepath.Ext(entry.Name())) {
+			userFiles = append(userFiles, entry.Name())
+		}
+	}
+	return userFiles, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+type IRedisIdempotencyRepository interface {
+	Reserve(context.Context, string, models.IdempotencyRecord, time.Duration) (bool, error)
+	Get(context.Context, string) (models.IdempotencyRecord, error)
+	Save(context.Context, string, models.IdempotencyRecord, time.Duration) error
+	Delete(context.Context, string) error
+}
+
+type redisIdempotencyRepository struct {
+	client *redis.Client
+	logger logrus.Logger
+}
+
+func NewRedisIdempotencyRepository(cfg config.RedisConfig, logger logrus.Logger) IRedisIdempotencyRepository {
+	return &redisIdempotencyRepository{
+		client: redis.NewClient(&redis.Options{
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}),
+		logger: logger,
+	}
+}
+
+// Reserve stores record only if key is free and reports whether it was stored
+func (rir *redisIdempotencyRepository) Reserve(ctx context.Context, key string, record models.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	payload, err := record.MarshalJSON()
+	if err != nil {
+		return false, err
+	}
+	return rir.client.SetNX(ctx, key, payload, ttl).Result()
+}
+
+// returns redis.Nil if key has expired
+func (rir *redisIdempotencyRepository) Get(ctx context.Context, key string) (models.IdempotencyRecord, error) {
+	var record models.IdempotencyRecord
+	payload, err := rir.client.Get(ctx, key).Bytes()
+	if err != nil {
+		return record, err
+	}
+	err = record.UnmarshalJSON(payload)
+	return record, err
+}
+
+func (rir *redisIdempotencyRepository) Save(ctx context.Context, key string, record models.IdempotencyRecord, ttl time.Duration) error {
+	payload, err := record.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return rir.client.Set(ctx, key, payload, ttl).Err()
+}
+
+func (rir *redisIdempotencyRepository) Delete(ctx context.Context, key string) error {
+	return rir.client.Del(ctx, key).Err()
+}
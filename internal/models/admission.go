@@ -0,0 +1,47 @@
+package models
+
+type AdmissionTemplate struct {
+	ID         uint64
+	Title      string
+	Body       string
+	Active     bool
+	AuthorID   uint64
+	CreateTime uint64
+	UpdateTime uint64
+}
+
+// AdmissionApplication keeps data which is substituted into template
+type AdmissionApplication struct {
+	Child        Child
+	Parent       Parent
+	Relationship string
+}
+
+//easyjson:json
+type AdmissionTemplateReq struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+//easyjson:json
+type AdmissionTemplateResp struct {
+	ID         uint64 `json:"id"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	Active     bool   `json:"active"`
+	AuthorID   uint64 `json:"author_id,omitempty"`
+	CreateTime uint64 `json:"create_time"`
+	UpdateTime uint64 `json:"update_time"`
+}
+
+//easyjson:json
+type AdmissionTemplateRespList []AdmissionTemplateResp
+
+//easyjson:json
+type AdmissionPlaceholderResp struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+//easyjson:json
+type AdmissionPlaceholderRespList []AdmissionPlaceholderResp
@@ -0,0 +1,405 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexer.Lexer, out *AdmissionTemplateRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(AdmissionTemplateRespList, 0, 0)
+			} else {
+				*out = AdmissionTemplateRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 AdmissionTemplateResp
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels(out *jwriter.Writer, in AdmissionTemplateRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdmissionTemplateRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdmissionTemplateRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdmissionTemplateRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdmissionTemplateRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels(l, v)
+}
+func easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlexer.Lexer, out *AdmissionTemplateResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "title":
+			out.Title = string(in.String())
+		case "body":
+			out.Body = string(in.String())
+		case "active":
+			out.Active = bool(in.Bool())
+		case "author_id":
+			out.AuthorID = uint64(in.Uint64())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "update_time":
+			out.UpdateTime = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwriter.Writer, in AdmissionTemplateResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"active\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Active))
+	}
+	if in.AuthorID != 0 {
+		const prefix string = ",\"author_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.AuthorID))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"update_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UpdateTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdmissionTemplateResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdmissionTemplateResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdmissionTemplateResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdmissionTemplateResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels1(l, v)
+}
+func easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *AdmissionTemplateReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "title":
+			out.Title = string(in.String())
+		case "body":
+			out.Body = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in AdmissionTemplateReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdmissionTemplateReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdmissionTemplateReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdmissionTemplateReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdmissionTemplateReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels2(l, v)
+}
+func easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *AdmissionPlaceholderRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(AdmissionPlaceholderRespList, 0, 2)
+			} else {
+				*out = AdmissionPlaceholderRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 AdmissionPlaceholderResp
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in AdmissionPlaceholderRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdmissionPlaceholderRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdmissionPlaceholderRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdmissionPlaceholderRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdmissionPlaceholderRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels3(l, v)
+}
+func easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlexer.Lexer, out *AdmissionPlaceholderResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwriter.Writer, in AdmissionPlaceholderResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AdmissionPlaceholderResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AdmissionPlaceholderResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonEf7a56cdEncodeGithubComVoyakinHLokleBackendInternalModels4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AdmissionPlaceholderResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AdmissionPlaceholderResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonEf7a56cdDecodeGithubComVoyakinHLokleBackendInternalModels4(l, v)
+}
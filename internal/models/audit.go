@@ -0,0 +1,21 @@
+package models
+
+type AuditAction string
+
+const (
+	ChildTransferredAudit  AuditAction = "child_transferred"
+	ChildMergedAudit       AuditAction = "child_merged"
+	ChildDataChangedAudit  AuditAction = "child_data_changed"
+	ParentDataChangedAudit AuditAction = "parent_data_changed"
+)
+
+// AuditRecord keeps who did administrative action and on which entity
+type AuditRecord struct {
+	ID         uint64
+	ActorID    uint64
+	Action     AuditAction
+	EntityType string
+	EntityID   uint64
+	Details    string
+	CreateTime uint64
+}
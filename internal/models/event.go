@@ -0,0 +1,38 @@
+package models
+
+type RegReqEventType string
+
+const (
+	RegReqCreatedEvent   RegReqEventType = "req_created"
+	RegReqFixedEvent     RegReqEventType = "req_fixed"
+	RegReqClaimedEvent   RegReqEventType = "req_claimed"
+	RegReqApprovedEvent  RegReqEventType = "req_approved"
+	RegReqFailedEvent    RegReqEventType = "req_failed"
+	RegReqCancelledEvent RegReqEventType = "req_cancelled"
+	RegReqEscalatedEvent RegReqEventType = "req_escalated"
+	RegReqAssignedEvent  RegReqEventType = "req_assigned"
+)
+
+// RegReqEvent is published to all api instances,
+// recipients are users except managers who are allowed to see this event
+//
+//easyjson:json
+type RegReqEvent struct {
+	Type       RegReqEventType `json:"type"`
+	ReqID      uint64          `json:"req_id"`
+	UserID     uint64          `json:"user_id"`
+	ManagerID  uint64          `json:"manager_id,omitempty"`
+	ReqType    RegReqType      `json:"req_type"`
+	CreateTime uint64          `json:"create_time"`
+	Recipients []uint64        `json:"recipients"`
+}
+
+//easyjson:json
+type RegReqEventResp struct {
+	Type       string `json:"type"`
+	ReqID      uint64 `json:"req_id"`
+	UserID     uint64 `json:"user_id"`
+	ManagerID  uint64 `json:"manager_id,omitempty"`
+	ReqType    string `json:"req_type"`
+	CreateTime uint64 `json:"create_time"`
+}
@@ -0,0 +1,260 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF642ad3eDecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexer.Lexer, out *RegReqEventResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "manager_id":
+			out.ManagerID = uint64(in.Uint64())
+		case "req_type":
+			out.ReqType = string(in.String())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF642ad3eEncodeGithubComVoyakinHLokleBackendInternalModels(out *jwriter.Writer, in RegReqEventResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UserID))
+	}
+	if in.ManagerID != 0 {
+		const prefix string = ",\"manager_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ManagerID))
+	}
+	{
+		const prefix string = ",\"req_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ReqType))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqEventResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF642ad3eEncodeGithubComVoyakinHLokleBackendInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqEventResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF642ad3eEncodeGithubComVoyakinHLokleBackendInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqEventResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF642ad3eDecodeGithubComVoyakinHLokleBackendInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqEventResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF642ad3eDecodeGithubComVoyakinHLokleBackendInternalModels(l, v)
+}
+func easyjsonF642ad3eDecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlexer.Lexer, out *RegReqEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = RegReqEventType(in.String())
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "manager_id":
+			out.ManagerID = uint64(in.Uint64())
+		case "req_type":
+			out.ReqType = RegReqType(in.Int8())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "recipients":
+			if in.IsNull() {
+				in.Skip()
+				out.Recipients = nil
+			} else {
+				in.Delim('[')
+				if out.Recipients == nil {
+					if !in.IsDelim(']') {
+						out.Recipients = make([]uint64, 0, 8)
+					} else {
+						out.Recipients = []uint64{}
+					}
+				} else {
+					out.Recipients = (out.Recipients)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 uint64
+					v1 = uint64(in.Uint64())
+					out.Recipients = append(out.Recipients, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF642ad3eEncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwriter.Writer, in RegReqEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UserID))
+	}
+	if in.ManagerID != 0 {
+		const prefix string = ",\"manager_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ManagerID))
+	}
+	{
+		const prefix string = ",\"req_type\":"
+		out.RawString(prefix)
+		out.Int8(int8(in.ReqType))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"recipients\":"
+		out.RawString(prefix)
+		if in.Recipients == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Recipients {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint64(uint64(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF642ad3eEncodeGithubComVoyakinHLokleBackendInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF642ad3eEncodeGithubComVoyakinHLokleBackendInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF642ad3eDecodeGithubComVoyakinHLokleBackendInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF642ad3eDecodeGithubComVoyakinHLokleBackendInternalModels1(l, v)
+}
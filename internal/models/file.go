@@ -23,3 +23,8 @@ type FileStruct struct {
 	File string `json:"file"`
 	Type string `json:"type"`
 }
+
+//easyjson:json
+type FileListResp struct {
+	Files []string `json:"files"`
+}
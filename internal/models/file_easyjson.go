@@ -90,7 +90,7 @@ func (v *FileStruct) UnmarshalJSON(data []byte) error {
 func (v *FileStruct) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels(l, v)
 }
-func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlexer.Lexer, out *DonwloadResp) {
+func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlexer.Lexer, out *FileListResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -117,16 +117,16 @@ func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlex
 				in.Delim('[')
 				if out.Files == nil {
 					if !in.IsDelim(']') {
-						out.Files = make([]FileStruct, 0, 2)
+						out.Files = make([]string, 0, 4)
 					} else {
-						out.Files = []FileStruct{}
+						out.Files = []string{}
 					}
 				} else {
 					out.Files = (out.Files)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 FileStruct
-					(v1).UnmarshalEasyJSON(in)
+					var v1 string
+					v1 = string(in.String())
 					out.Files = append(out.Files, v1)
 					in.WantComma()
 				}
@@ -142,7 +142,7 @@ func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwriter.Writer, in DonwloadResp) {
+func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwriter.Writer, in FileListResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -157,7 +157,7 @@ func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwr
 				if v2 > 0 {
 					out.RawByte(',')
 				}
-				(v3).MarshalEasyJSON(out)
+				out.String(string(v3))
 			}
 			out.RawByte(']')
 		}
@@ -166,29 +166,127 @@ func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwr
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v DonwloadResp) MarshalJSON() ([]byte, error) {
+func (v FileListResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v DonwloadResp) MarshalEasyJSON(w *jwriter.Writer) {
+func (v FileListResp) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *DonwloadResp) UnmarshalJSON(data []byte) error {
+func (v *FileListResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *DonwloadResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *FileListResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels1(l, v)
 }
-func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *DonwloadReq) {
+func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *DonwloadResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]FileStruct, 0, 2)
+					} else {
+						out.Files = []FileStruct{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 FileStruct
+					(v4).UnmarshalEasyJSON(in)
+					out.Files = append(out.Files, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in DonwloadResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix[1:])
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Files {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DonwloadResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DonwloadResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DonwloadResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DonwloadResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(l, v)
+}
+func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *DonwloadReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -225,9 +323,9 @@ func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 					out.FileName = (out.FileName)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v4 string
-					v4 = string(in.String())
-					out.FileName = append(out.FileName, v4)
+					var v7 string
+					v7 = string(in.String())
+					out.FileName = append(out.FileName, v7)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -244,7 +342,7 @@ func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in DonwloadReq) {
+func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in DonwloadReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -260,11 +358,11 @@ func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwr
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v5, v6 := range in.FileName {
-				if v5 > 0 {
+			for v8, v9 := range in.FileName {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v6))
+				out.String(string(v9))
 			}
 			out.RawByte(']')
 		}
@@ -280,27 +378,27 @@ func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v DonwloadReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(&w, v)
+	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DonwloadReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels2(w, v)
+	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DonwloadReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(&r, v)
+	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DonwloadReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels2(l, v)
+	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(l, v)
 }
-func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *DeleteReq) {
+func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlexer.Lexer, out *DeleteReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -333,7 +431,7 @@ func easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in DeleteReq) {
+func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwriter.Writer, in DeleteReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -353,23 +451,23 @@ func easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v DeleteReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(&w, v)
+	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DeleteReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels3(w, v)
+	easyjson8ceb9162EncodeGithubComVoyakinHLokleBackendInternalModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DeleteReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(&r, v)
+	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DeleteReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels3(l, v)
+	easyjson8ceb9162DecodeGithubComVoyakinHLokleBackendInternalModels4(l, v)
 }
@@ -0,0 +1,13 @@
+package models
+
+// IdempotencyRecord is stored by idempotency key,
+// response fields are empty until request is completed
+//
+//easyjson:json
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
@@ -0,0 +1,118 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4caa8515DecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexer.Lexer, out *IdempotencyRecord) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "fingerprint":
+			out.Fingerprint = string(in.String())
+		case "completed":
+			out.Completed = bool(in.Bool())
+		case "status_code":
+			out.StatusCode = int(in.Int())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "body":
+			if in.IsNull() {
+				in.Skip()
+				out.Body = nil
+			} else {
+				out.Body = in.Bytes()
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4caa8515EncodeGithubComVoyakinHLokleBackendInternalModels(out *jwriter.Writer, in IdempotencyRecord) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"fingerprint\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Fingerprint))
+	}
+	{
+		const prefix string = ",\"completed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Completed))
+	}
+	if in.StatusCode != 0 {
+		const prefix string = ",\"status_code\":"
+		out.RawString(prefix)
+		out.Int(int(in.StatusCode))
+	}
+	if in.ContentType != "" {
+		const prefix string = ",\"content_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	if len(in.Body) != 0 {
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Body)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IdempotencyRecord) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4caa8515EncodeGithubComVoyakinHLokleBackendInternalModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IdempotencyRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4caa8515EncodeGithubComVoyakinHLokleBackendInternalModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IdempotencyRecord) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4caa8515DecodeGithubComVoyakinHLokleBackendInternalModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IdempotencyRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4caa8515DecodeGithubComVoyakinHLokleBackendInternalModels(l, v)
+}
@@ -0,0 +1,50 @@
+package models
+
+type NotificationType string
+
+const (
+	RegReqFailedNotification      NotificationType = "req_failed"
+	StageApprovedNotification     NotificationType = "stage_approved"
+	CredentialsIssuedNotification NotificationType = "credentials_issued"
+	RegReqCreatedNotification     NotificationType = "req_created"
+	RegReqFixedNotification       NotificationType = "req_fixed"
+	RegReqEscalatedNotification   NotificationType = "req_escalated"
+	RegReqAssignedNotification    NotificationType = "req_assigned"
+	ChildTransferredNotification  NotificationType = "child_transferred"
+)
+
+type Notification struct {
+	ID         uint64
+	UserID     uint64
+	Type       NotificationType
+	Title      string
+	Body       string
+	ReqID      uint64
+	Read       bool
+	CreateTime uint64
+}
+
+type NotificationFilter struct {
+	UnreadOnly bool
+	Limit      uint64
+	Offset     uint64
+}
+
+//easyjson:json
+type NotificationResp struct {
+	ID         uint64 `json:"id"`
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	ReqID      uint64 `json:"req_id,omitempty"`
+	Read       bool   `json:"read"`
+	CreateTime uint64 `json:"create_time"`
+}
+
+//easyjson:json
+type NotificationRespList []NotificationResp
+
+//easyjson:json
+type NotificationCountResp struct {
+	Unread uint64 `json:"unread"`
+}
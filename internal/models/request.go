@@ -10,20 +10,31 @@ const (
 	ChildThirdStage
 )
 
+// ChildDataChange changes child data after registration is completed,
+// it isn't workflow stage, so this type can't be declared in workflow config
+const ChildDataChange RegReqType = 100
+
+// titles of request types, workflow stages defined in config are added on startup
+var regReqTypeTitles = map[RegReqType]string{
+	ParentPassportVerification: "Подтверждение паспорта родителя",
+	ChildFirstStageForStudent:  "Регистрация ребенка (этап 1 / старый ученик)",
+	ChildFirstStage:            "Регистрация ребенка (этап 1 / новый ученик)",
+	ChildSecondStage:           "Регистрация ребенка (этап 2)",
+	ChildThirdStage:            "Регистрация ребенка (этап 3)",
+	ChildDataChange:            "Изменение данных ребенка",
+}
+
+// SetRegReqTypeTitle must be called only on startup
+func SetRegReqTypeTitle(r RegReqType, title string) {
+	regReqTypeTitles[r] = title
+}
+
 func (r RegReqType) String() string {
-	switch r {
-	case ParentPassportVerification:
-		return "Подтверждение паспорта родителя"
-	case ChildFirstStageForStudent:
-		return "Регистрация ребенка (этап 1 / старый ученик)"
-	case ChildFirstStage:
-		return "Регистрация ребенка (этап 1 / новый ученик)"
-	case ChildSecondStage:
-		return "Регистрация ребенка (этап 2)"
-	case ChildThirdStage:
-		return "Регистрация ребенка (этап 3)"
+	title, ok := regReqTypeTitles[r]
+	if !ok {
+		return "UNKNOWN"
 	}
-	return "UNKNOWN"
+	return title
 }
 
 //easyjson:json
@@ -76,24 +87,28 @@ type FixChildThirdRegReq struct {
 
 //easyjson:json
 type RegReqFull struct {
-	ID         uint64     `json:"id"`
-	UserID     uint64     `json:"user_id"`
-	ManagerID  uint64     `json:"manager_id,omitempty"`
-	Type       RegReqType `json:"type"`
-	Status     string     `json:"status"`
-	CreateTime uint64     `json:"create_time"`
-	Message    string     `json:"message"`
+	ID             uint64         `json:"id"`
+	UserID         uint64         `json:"user_id"`
+	ManagerID      uint64         `json:"manager_id,omitempty"`
+	Type           RegReqType     `json:"type"`
+	Status         string         `json:"status"`
+	CreateTime     uint64         `json:"create_time"`
+	Message        string         `json:"message"`
+	Issues         FieldIssueList `json:"issues,omitempty"`
+	UnreadMessages uint64         `json:"unread_messages"`
 }
 
 //easyjson:json
 type RegReqResp struct {
-	ID         uint64 `json:"id"`
-	UserID     uint64 `json:"user_id"`
-	ManagerID  uint64 `json:"manager_id,omitempty"`
-	Type       string `json:"type"`
-	Status     string `json:"status"`
-	CreateTime uint64 `json:"create_time"`
-	Message    string `json:"message"`
+	ID             uint64         `json:"id"`
+	UserID         uint64         `json:"user_id"`
+	ManagerID      uint64         `json:"manager_id,omitempty"`
+	Type           string         `json:"type"`
+	Status         string         `json:"status"`
+	CreateTime     uint64         `json:"create_time"`
+	Message        string         `json:"message"`
+	Issues         FieldIssueList `json:"issues,omitempty"`
+	UnreadMessages uint64         `json:"unread_messages"`
 }
 
 //easyjson:json
@@ -101,33 +116,427 @@ type RegReqRespList []RegReqResp
 
 //easyjson:json
 type RegReqWithUser struct {
-	ID          uint64     `json:"id"`
-	User        User       `json:"user"`
-	Manager     *User      `json:"manager,omitempty"`
-	Type        RegReqType `json:"type"`
-	Status      string     `json:"status"`
-	TimeInQueue uint32     `json:"time_in_queue"`
-	CreateTime  uint64     `json:"create_time"`
-	Message     string     `json:"message"`
+	ID               uint64     `json:"id"`
+	User             User       `json:"user"`
+	Manager          *User      `json:"manager,omitempty"`
+	Type             RegReqType `json:"type"`
+	Status           string     `json:"status"`
+	TimeInQueue      uint32     `json:"time_in_queue"`
+	CreateTime       uint64     `json:"create_time"`
+	Message          string     `json:"message"`
+	EscalationReason string     `json:"escalation_reason"`
+	UnreadMessages   uint64     `json:"unread_messages"`
+	// number of open possible duplicates of request owner
+	PossibleDuplicates uint64 `json:"possible_duplicates"`
 }
 
 //easyjson:json
 type RegReqWithUserResp struct {
-	ID          uint64   `json:"id"`
-	User        UserRes  `json:"user"`
-	Manager     *UserRes `json:"manager,omitempty"`
-	Type        string   `json:"type"`
-	Status      string   `json:"status"`
-	TimeInQueue uint32   `json:"time_in_queue"`
-	CreateTime  uint64   `json:"create_time"`
-	Message     string   `json:"message"`
+	ID               uint64   `json:"id"`
+	User             UserRes  `json:"user"`
+	Manager          *UserRes `json:"manager,omitempty"`
+	Type             string   `json:"type"`
+	Status           string   `json:"status"`
+	TimeInQueue      uint32   `json:"time_in_queue"`
+	CreateTime       uint64   `json:"create_time"`
+	Message          string   `json:"message"`
+	EscalationReason string   `json:"escalation_reason,omitempty"`
+	UnreadMessages   uint64   `json:"unread_messages"`
+	// number of open possible duplicates of request owner
+	PossibleDuplicates uint64 `json:"possible_duplicates"`
 }
 
 //easyjson:json
 type RegReqWithUserRespList []RegReqWithUserResp
 
+// FieldIssue points parent to the exact field or document which must be fixed
+//
+//easyjson:json
+type FieldIssue struct {
+	Field      string `json:"field"`
+	Document   string `json:"document,omitempty"`
+	ReasonCode string `json:"reason_code"`
+	Comment    string `json:"comment"`
+}
+
+//easyjson:json
+type FieldIssueList []FieldIssue
+
 //easyjson:json
 type FailedReq struct {
-	ReqId         uint64 `json:"req_id"`
-	FailedMessage string `json:"failed_message"`
+	ReqId         uint64         `json:"req_id"`
+	FailedMessage string         `json:"failed_message"`
+	Issues        FieldIssueList `json:"issues,omitempty"`
+}
+
+//easyjson:json
+type EscalateReq struct {
+	ReqID  uint64 `json:"req_id"`
+	Reason string `json:"reason"`
+}
+
+//easyjson:json
+type ReassignReq struct {
+	ReqID     uint64 `json:"req_id"`
+	ManagerID uint64 `json:"manager_id"`
+}
+
+type EscalationDecision string
+
+const (
+	ApproveEscalationDecision EscalationDecision = "approve"
+	FailEscalationDecision    EscalationDecision = "fail"
+	ReturnEscalationDecision  EscalationDecision = "return"
+)
+
+// ResolveEscalationReq is admin decision on escalated request,
+// returned request goes back to manager_id or to common queue
+//
+//easyjson:json
+type ResolveEscalationReq struct {
+	ReqID     uint64             `json:"req_id"`
+	Decision  EscalationDecision `json:"decision"`
+	Message   string             `json:"message"`
+	Issues    FieldIssueList     `json:"issues,omitempty"`
+	ManagerID uint64             `json:"manager_id,omitempty"`
+}
+
+//easyjson:json
+type RejectionReason struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+}
+
+//easyjson:json
+type RejectionReasonList []RejectionReason
+
+// ChildStageReq submits workflow stage which needs only uploaded documents
+//
+//easyjson:json
+type ChildStageReq struct {
+	Type    RegReqType `json:"type"`
+	ChildID uint64     `json:"child_id"`
+}
+
+//easyjson:json
+type FixChildStageReq struct {
+	ReqID uint64 `json:"req_id"`
+}
+
+//easyjson:json
+type WorkflowStageResp struct {
+	Type              RegReqType `json:"type"`
+	Title             string     `json:"title"`
+	Owner             string     `json:"owner"`
+	RequiredFields    []string   `json:"required_fields"`
+	RequiredDocuments []string   `json:"required_documents"`
+	RequiresStage     Stage      `json:"requires_stage"`
+	GrantsStage       Stage      `json:"grants_stage"`
+	NextType          RegReqType `json:"next_type,omitempty"`
+	Builtin           bool       `json:"builtin"`
+}
+
+//easyjson:json
+type WorkflowStageRespList []WorkflowStageResp
+
+//easyjson:json
+type ChecklistDocument struct {
+	Category string   `json:"category"`
+	Uploaded bool     `json:"uploaded"`
+	Files    []string `json:"files"`
+}
+
+//easyjson:json
+type ChecklistStage struct {
+	Type      RegReqType          `json:"type"`
+	Title     string              `json:"title"`
+	ReqID     uint64              `json:"req_id,omitempty"`
+	Documents []ChecklistDocument `json:"documents"`
+	Complete  bool                `json:"complete"`
+}
+
+// DocumentChecklist shows documents of parent or child required by stages they can submit
+//
+//easyjson:json
+type DocumentChecklist struct {
+	UserID     uint64           `json:"user_id"`
+	Role       string           `json:"role"`
+	FirstName  string           `json:"first_name"`
+	SecondName string           `json:"second_name"`
+	Stages     []ChecklistStage `json:"stages"`
+}
+
+//easyjson:json
+type DocumentChecklistList []DocumentChecklist
+
+type RegReqHistory struct {
+	ReqID         uint64
+	UserID        uint64
+	ManagerID     uint64
+	Type          RegReqType
+	Action        string
+	Message       string
+	ReqCreateTime uint64
+	CreateTime    uint64
+}
+
+const (
+	OpenDuplicateStatus      = "open"
+	DismissedDuplicateStatus = "dismissed"
+)
+
+//easyjson:json
+type DuplicateChild struct {
+	UserID     uint64 `json:"user_id"`
+	FirstName  string `json:"first_name"`
+	SecondName string `json:"second_name"`
+	LastName   string `json:"last_name"`
+	Email      string `json:"email"`
+	BirthDate  uint64 `json:"birth_date"`
+	DoneStage  Stage  `json:"done_stage"`
+}
+
+// ChildDuplicate is pair of children with similar names and the same birth date
+//
+//easyjson:json
+type ChildDuplicate struct {
+	ID            uint64         `json:"id"`
+	Child         DuplicateChild `json:"child"`
+	Duplicate     DuplicateChild `json:"duplicate"`
+	Similarity    float32        `json:"similarity"`
+	PassportMatch bool           `json:"passport_match"`
+	Status        string         `json:"status"`
+	CreateTime    uint64         `json:"create_time"`
+}
+
+//easyjson:json
+type ChildDuplicateList []ChildDuplicate
+
+// MergeChildrenReq keeps one child of duplicate pair, another one is merged into it
+//
+//easyjson:json
+type MergeChildrenReq struct {
+	DuplicateID uint64 `json:"duplicate_id"`
+	KeepChildID uint64 `json:"keep_child_id"`
+}
+
+//easyjson:json
+type RegReqHistoryResp struct {
+	ReqID         uint64 `json:"req_id"`
+	Type          string `json:"type"`
+	Action        string `json:"action"`
+	Message       string `json:"message"`
+	ReqCreateTime uint64 `json:"req_create_time"`
+	CreateTime    uint64 `json:"create_time"`
+}
+
+//easyjson:json
+type RegReqHistoryRespList []RegReqHistoryResp
+
+type RegReqStatsFilter struct {
+	From      uint64
+	To        uint64
+	ManagerID uint64
+}
+
+type PendingRegReqStat struct {
+	Type  RegReqType
+	Count uint64
+}
+
+type ManagerDecisionsStat struct {
+	Manager  User
+	Day      string
+	Approved uint64
+	Failed   uint64
+}
+
+type RegReqStats struct {
+	From                 uint64
+	To                   uint64
+	Pending              []PendingRegReqStat
+	MedianProcessingTime float64
+	P90ProcessingTime    float64
+	Decisions            []ManagerDecisionsStat
+	MultiFixShare        float64
+}
+
+//easyjson:json
+type PendingRegReqStatResp struct {
+	Type  string `json:"type"`
+	Count uint64 `json:"count"`
+}
+
+//easyjson:json
+type ManagerDecisionsStatResp struct {
+	Manager  UserRes `json:"manager"`
+	Day      string  `json:"day"`
+	Approved uint64  `json:"approved"`
+	Failed   uint64  `json:"failed"`
+}
+
+//easyjson:json
+type RegReqStatsResp struct {
+	From                 uint64                     `json:"from"`
+	To                   uint64                     `json:"to"`
+	Pending              []PendingRegReqStatResp    `json:"pending"`
+	MedianProcessingTime float64                    `json:"median_processing_time"`
+	P90ProcessingTime    float64                    `json:"p90_processing_time"`
+	Decisions            []ManagerDecisionsStatResp `json:"decisions"`
+	MultiFixShare        float64                    `json:"multi_fix_share"`
+}
+
+//easyjson:json
+type BatchCompleteReq struct {
+	ReqIDs []uint64 `json:"req_ids"`
+}
+
+//easyjson:json
+type BatchFailedReq struct {
+	ReqIDs        []uint64    `json:"req_ids"`
+	FailedMessage string      `json:"failed_message"`
+	Items         []FailedReq `json:"items"`
+}
+
+type BatchItemResult struct {
+	ReqID  uint64
+	Status int
+	Err    error
+	// Message is shown to manager instead of error text, empty for internal errors
+	Message string
+}
+
+//easyjson:json
+type BatchItemResultResp struct {
+	ReqID  uint64 `json:"req_id"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+//easyjson:json
+type BatchResultResp struct {
+	Succeeded uint64                `json:"succeeded"`
+	Failed    uint64                `json:"failed"`
+	Items     []BatchItemResultResp `json:"items"`
+}
+
+//easyjson:json
+type RegReqMessageReq struct {
+	ReqID       uint64   `json:"req_id"`
+	Body        string   `json:"body"`
+	Internal    bool     `json:"internal"`
+	Attachments []string `json:"attachments"`
+}
+
+//easyjson:json
+type MessageReceipt struct {
+	UserID   uint64 `json:"user_id"`
+	ReadTime uint64 `json:"read_time"`
+}
+
+//easyjson:json
+type MessageReceiptList []MessageReceipt
+
+type RegReqMessage struct {
+	ID          uint64
+	ReqID       uint64
+	Author      User
+	Body        string
+	Internal    bool
+	Attachments []string
+	CreateTime  uint64
+	Receipts    MessageReceiptList
+}
+
+//easyjson:json
+type RegReqMessageResp struct {
+	ID          uint64             `json:"id"`
+	ReqID       uint64             `json:"req_id"`
+	Author      UserRes            `json:"author"`
+	Body        string             `json:"body"`
+	Internal    bool               `json:"internal"`
+	Attachments []string           `json:"attachments"`
+	CreateTime  uint64             `json:"create_time"`
+	Receipts    MessageReceiptList `json:"receipts"`
+}
+
+//easyjson:json
+type RegReqMessageRespList []RegReqMessageResp
+
+//easyjson:json
+type ChildDataChangeReq struct {
+	ChildID   uint64            `json:"child_id"`
+	Changes   map[string]string `json:"changes"`
+	Comment   string            `json:"comment"`
+	Documents []string          `json:"documents"`
+}
+
+// RegReqChange keeps stored and proposed value of child field, passport values are encrypted
+type RegReqChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+//easyjson:json
+type RegReqChangeResp struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+//easyjson:json
+type RegReqChangeRespList []RegReqChangeResp
+
+const (
+	ImportCreateAction = "create"
+	ImportMatchAction  = "match"
+)
+
+// StudentImportRow is child with parent from one row of students import file
+type StudentImportRow struct {
+	Row          int
+	Child        Child
+	Parent       User
+	Relationship string
+}
+
+// StudentImportResult describes what is done or would be done with one row
+type StudentImportResult struct {
+	Row          int
+	ChildName    string
+	ParentEmail  string
+	ChildAction  string
+	ParentAction string
+	LinkAction   string
+	ChildID      uint64
+	ParentID     uint64
+	Errors       []string
+}
+
+type StudentImportReport struct {
+	DryRun  bool
+	Results []StudentImportResult
+}
+
+//easyjson:json
+type StudentImportResultResp struct {
+	Row          int      `json:"row"`
+	ChildName    string   `json:"child_name"`
+	ParentEmail  string   `json:"parent_email"`
+	ChildAction  string   `json:"child_action,omitempty"`
+	ParentAction string   `json:"parent_action,omitempty"`
+	LinkAction   string   `json:"link_action,omitempty"`
+	ChildID      uint64   `json:"child_id,omitempty"`
+	ParentID     uint64   `json:"parent_id,omitempty"`
+	Errors       []string `json:"errors,omitempty"`
+}
+
+//easyjson:json
+type StudentImportReportResp struct {
+	DryRun  bool                      `json:"dry_run"`
+	Total   uint64                    `json:"total"`
+	Valid   uint64                    `json:"valid"`
+	Invalid uint64                    `json:"invalid"`
+	Items   []StudentImportResultResp `json:"items"`
 }
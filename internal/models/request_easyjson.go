@@ -17,7 +17,7 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexer.Lexer, out *RegReqWithUserRespList) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexer.Lexer, out *WorkflowStageRespList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -26,15 +26,15 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexe
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(RegReqWithUserRespList, 0, 0)
+				*out = make(WorkflowStageRespList, 0, 0)
 			} else {
-				*out = RegReqWithUserRespList{}
+				*out = WorkflowStageRespList{}
 			}
 		} else {
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v1 RegReqWithUserResp
+			var v1 WorkflowStageResp
 			(v1).UnmarshalEasyJSON(in)
 			*out = append(*out, v1)
 			in.WantComma()
@@ -45,7 +45,7 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels(in *jlexe
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels(out *jwriter.Writer, in RegReqWithUserRespList) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels(out *jwriter.Writer, in WorkflowStageRespList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -61,29 +61,29 @@ func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels(out *jwri
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegReqWithUserRespList) MarshalJSON() ([]byte, error) {
+func (v WorkflowStageRespList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegReqWithUserRespList) MarshalEasyJSON(w *jwriter.Writer) {
+func (v WorkflowStageRespList) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegReqWithUserRespList) UnmarshalJSON(data []byte) error {
+func (v *WorkflowStageRespList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegReqWithUserRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *WorkflowStageRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlexer.Lexer, out *RegReqWithUserResp) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlexer.Lexer, out *WorkflowStageResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -102,30 +102,66 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlex
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = uint64(in.Uint64())
-		case "user":
-			(out.User).UnmarshalEasyJSON(in)
-		case "manager":
+		case "type":
+			out.Type = RegReqType(in.Int8())
+		case "title":
+			out.Title = string(in.String())
+		case "owner":
+			out.Owner = string(in.String())
+		case "required_fields":
 			if in.IsNull() {
 				in.Skip()
-				out.Manager = nil
+				out.RequiredFields = nil
 			} else {
-				if out.Manager == nil {
-					out.Manager = new(UserRes)
+				in.Delim('[')
+				if out.RequiredFields == nil {
+					if !in.IsDelim(']') {
+						out.RequiredFields = make([]string, 0, 4)
+					} else {
+						out.RequiredFields = []string{}
+					}
+				} else {
+					out.RequiredFields = (out.RequiredFields)[:0]
 				}
-				(*out.Manager).UnmarshalEasyJSON(in)
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.RequiredFields = append(out.RequiredFields, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "type":
-			out.Type = string(in.String())
-		case "status":
-			out.Status = string(in.String())
-		case "time_in_queue":
-			out.TimeInQueue = uint32(in.Uint32())
-		case "create_time":
-			out.CreateTime = uint64(in.Uint64())
-		case "message":
-			out.Message = string(in.String())
+		case "required_documents":
+			if in.IsNull() {
+				in.Skip()
+				out.RequiredDocuments = nil
+			} else {
+				in.Delim('[')
+				if out.RequiredDocuments == nil {
+					if !in.IsDelim(']') {
+						out.RequiredDocuments = make([]string, 0, 4)
+					} else {
+						out.RequiredDocuments = []string{}
+					}
+				} else {
+					out.RequiredDocuments = (out.RequiredDocuments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.RequiredDocuments = append(out.RequiredDocuments, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "requires_stage":
+			out.RequiresStage = Stage(in.Int8())
+		case "grants_stage":
+			out.GrantsStage = Stage(in.Int8())
+		case "next_type":
+			out.NextType = RegReqType(in.Int8())
+		case "builtin":
+			out.Builtin = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -136,77 +172,104 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwriter.Writer, in RegReqWithUserResp) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwriter.Writer, in WorkflowStageResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ID))
+		out.Int8(int8(in.Type))
 	}
 	{
-		const prefix string = ",\"user\":"
+		const prefix string = ",\"title\":"
 		out.RawString(prefix)
-		(in.User).MarshalEasyJSON(out)
+		out.String(string(in.Title))
 	}
-	if in.Manager != nil {
-		const prefix string = ",\"manager\":"
+	{
+		const prefix string = ",\"owner\":"
 		out.RawString(prefix)
-		(*in.Manager).MarshalEasyJSON(out)
+		out.String(string(in.Owner))
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"required_fields\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		if in.RequiredFields == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v6, v7 := range in.RequiredFields {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v7))
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"required_documents\":"
 		out.RawString(prefix)
-		out.String(string(in.Status))
+		if in.RequiredDocuments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.RequiredDocuments {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"time_in_queue\":"
+		const prefix string = ",\"requires_stage\":"
 		out.RawString(prefix)
-		out.Uint32(uint32(in.TimeInQueue))
+		out.Int8(int8(in.RequiresStage))
 	}
 	{
-		const prefix string = ",\"create_time\":"
+		const prefix string = ",\"grants_stage\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.CreateTime))
+		out.Int8(int8(in.GrantsStage))
+	}
+	if in.NextType != 0 {
+		const prefix string = ",\"next_type\":"
+		out.RawString(prefix)
+		out.Int8(int8(in.NextType))
 	}
 	{
-		const prefix string = ",\"message\":"
+		const prefix string = ",\"builtin\":"
 		out.RawString(prefix)
-		out.String(string(in.Message))
+		out.Bool(bool(in.Builtin))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegReqWithUserResp) MarshalJSON() ([]byte, error) {
+func (v WorkflowStageResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegReqWithUserResp) MarshalEasyJSON(w *jwriter.Writer) {
+func (v WorkflowStageResp) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegReqWithUserResp) UnmarshalJSON(data []byte) error {
+func (v *WorkflowStageResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegReqWithUserResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *WorkflowStageResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels1(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *RegReqWithUser) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *StudentImportResultResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -225,30 +288,45 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = uint64(in.Uint64())
-		case "user":
-			(out.User).UnmarshalEasyJSON(in)
-		case "manager":
+		case "row":
+			out.Row = int(in.Int())
+		case "child_name":
+			out.ChildName = string(in.String())
+		case "parent_email":
+			out.ParentEmail = string(in.String())
+		case "child_action":
+			out.ChildAction = string(in.String())
+		case "parent_action":
+			out.ParentAction = string(in.String())
+		case "link_action":
+			out.LinkAction = string(in.String())
+		case "child_id":
+			out.ChildID = uint64(in.Uint64())
+		case "parent_id":
+			out.ParentID = uint64(in.Uint64())
+		case "errors":
 			if in.IsNull() {
 				in.Skip()
-				out.Manager = nil
+				out.Errors = nil
 			} else {
-				if out.Manager == nil {
-					out.Manager = new(User)
+				in.Delim('[')
+				if out.Errors == nil {
+					if !in.IsDelim(']') {
+						out.Errors = make([]string, 0, 4)
+					} else {
+						out.Errors = []string{}
+					}
+				} else {
+					out.Errors = (out.Errors)[:0]
 				}
-				(*out.Manager).UnmarshalEasyJSON(in)
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Errors = append(out.Errors, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "type":
-			out.Type = RegReqType(in.Int8())
-		case "status":
-			out.Status = string(in.String())
-		case "time_in_queue":
-			out.TimeInQueue = uint32(in.Uint32())
-		case "create_time":
-			out.CreateTime = uint64(in.Uint64())
-		case "message":
-			out.Message = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -259,143 +337,217 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in RegReqWithUser) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in StudentImportResultResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"row\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ID))
+		out.Int(int(in.Row))
 	}
 	{
-		const prefix string = ",\"user\":"
+		const prefix string = ",\"child_name\":"
 		out.RawString(prefix)
-		(in.User).MarshalEasyJSON(out)
+		out.String(string(in.ChildName))
 	}
-	if in.Manager != nil {
-		const prefix string = ",\"manager\":"
+	{
+		const prefix string = ",\"parent_email\":"
 		out.RawString(prefix)
-		(*in.Manager).MarshalEasyJSON(out)
+		out.String(string(in.ParentEmail))
 	}
-	{
-		const prefix string = ",\"type\":"
+	if in.ChildAction != "" {
+		const prefix string = ",\"child_action\":"
 		out.RawString(prefix)
-		out.Int8(int8(in.Type))
+		out.String(string(in.ChildAction))
 	}
-	{
-		const prefix string = ",\"status\":"
+	if in.ParentAction != "" {
+		const prefix string = ",\"parent_action\":"
 		out.RawString(prefix)
-		out.String(string(in.Status))
+		out.String(string(in.ParentAction))
 	}
-	{
-		const prefix string = ",\"time_in_queue\":"
+	if in.LinkAction != "" {
+		const prefix string = ",\"link_action\":"
 		out.RawString(prefix)
-		out.Uint32(uint32(in.TimeInQueue))
+		out.String(string(in.LinkAction))
 	}
-	{
-		const prefix string = ",\"create_time\":"
+	if in.ChildID != 0 {
+		const prefix string = ",\"child_id\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.CreateTime))
+		out.Uint64(uint64(in.ChildID))
 	}
-	{
-		const prefix string = ",\"message\":"
+	if in.ParentID != 0 {
+		const prefix string = ",\"parent_id\":"
 		out.RawString(prefix)
-		out.String(string(in.Message))
+		out.Uint64(uint64(in.ParentID))
+	}
+	if len(in.Errors) != 0 {
+		const prefix string = ",\"errors\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.Errors {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegReqWithUser) MarshalJSON() ([]byte, error) {
+func (v StudentImportResultResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegReqWithUser) MarshalEasyJSON(w *jwriter.Writer) {
+func (v StudentImportResultResp) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegReqWithUser) UnmarshalJSON(data []byte) error {
+func (v *StudentImportResultResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegReqWithUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *StudentImportResultResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels2(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *RegReqRespList) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *StudentImportReportResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
 		in.Skip()
-		*out = nil
-	} else {
-		in.Delim('[')
-		if *out == nil {
-			if !in.IsDelim(']') {
-				*out = make(RegReqRespList, 0, 0)
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "dry_run":
+			out.DryRun = bool(in.Bool())
+		case "total":
+			out.Total = uint64(in.Uint64())
+		case "valid":
+			out.Valid = uint64(in.Uint64())
+		case "invalid":
+			out.Invalid = uint64(in.Uint64())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
 			} else {
-				*out = RegReqRespList{}
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]StudentImportResultResp, 0, 0)
+					} else {
+						out.Items = []StudentImportResultResp{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 StudentImportResultResp
+					(v13).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		} else {
-			*out = (*out)[:0]
-		}
-		for !in.IsDelim(']') {
-			var v4 RegReqResp
-			(v4).UnmarshalEasyJSON(in)
-			*out = append(*out, v4)
-			in.WantComma()
+		default:
+			in.SkipRecursive()
 		}
-		in.Delim(']')
+		in.WantComma()
 	}
+	in.Delim('}')
 	if isTopLevel {
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in RegReqRespList) {
-	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-		out.RawString("null")
-	} else {
-		out.RawByte('[')
-		for v5, v6 := range in {
-			if v5 > 0 {
-				out.RawByte(',')
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in StudentImportReportResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"dry_run\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.DryRun))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Total))
+	}
+	{
+		const prefix string = ",\"valid\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Valid))
+	}
+	{
+		const prefix string = ",\"invalid\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Invalid))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.Items {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalEasyJSON(out)
 			}
-			(v6).MarshalEasyJSON(out)
+			out.RawByte(']')
 		}
-		out.RawByte(']')
 	}
+	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegReqRespList) MarshalJSON() ([]byte, error) {
+func (v StudentImportReportResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegReqRespList) MarshalEasyJSON(w *jwriter.Writer) {
+func (v StudentImportReportResp) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegReqRespList) UnmarshalJSON(data []byte) error {
+func (v *StudentImportReportResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegReqRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *StudentImportReportResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels3(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlexer.Lexer, out *RegReqResp) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlexer.Lexer, out *ResolveEscalationReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -414,20 +566,16 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlex
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = uint64(in.Uint64())
-		case "user_id":
-			out.UserID = uint64(in.Uint64())
-		case "manager_id":
-			out.ManagerID = uint64(in.Uint64())
-		case "type":
-			out.Type = string(in.String())
-		case "status":
-			out.Status = string(in.String())
-		case "create_time":
-			out.CreateTime = uint64(in.Uint64())
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "decision":
+			out.Decision = EscalationDecision(in.String())
 		case "message":
 			out.Message = string(in.String())
+		case "issues":
+			(out.Issues).UnmarshalEasyJSON(in)
+		case "manager_id":
+			out.ManagerID = uint64(in.Uint64())
 		default:
 			in.SkipRecursive()
 		}
@@ -438,83 +586,3248 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwriter.Writer, in RegReqResp) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwriter.Writer, in ResolveEscalationReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"req_id\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ID))
-	}
-	{
-		const prefix string = ",\"user_id\":"
-		out.RawString(prefix)
-		out.Uint64(uint64(in.UserID))
-	}
-	if in.ManagerID != 0 {
-		const prefix string = ",\"manager_id\":"
-		out.RawString(prefix)
-		out.Uint64(uint64(in.ManagerID))
+		out.Uint64(uint64(in.ReqID))
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"decision\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.String(string(in.Decision))
 	}
 	{
-		const prefix string = ",\"status\":"
+		const prefix string = ",\"message\":"
 		out.RawString(prefix)
-		out.String(string(in.Status))
+		out.String(string(in.Message))
 	}
-	{
-		const prefix string = ",\"create_time\":"
+	if len(in.Issues) != 0 {
+		const prefix string = ",\"issues\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.CreateTime))
+		(in.Issues).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"message\":"
+	if in.ManagerID != 0 {
+		const prefix string = ",\"manager_id\":"
 		out.RawString(prefix)
-		out.String(string(in.Message))
+		out.Uint64(uint64(in.ManagerID))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegReqResp) MarshalJSON() ([]byte, error) {
+func (v ResolveEscalationReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegReqResp) MarshalEasyJSON(w *jwriter.Writer) {
+func (v ResolveEscalationReq) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegReqResp) UnmarshalJSON(data []byte) error {
+func (v *ResolveEscalationReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegReqResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *ResolveEscalationReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels4(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlexer.Lexer, out *RegReqFull) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlexer.Lexer, out *RejectionReasonList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
 		in.Skip()
-		return
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RejectionReasonList, 0, 2)
+			} else {
+				*out = RejectionReasonList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v16 RejectionReason
+			(v16).UnmarshalEasyJSON(in)
+			*out = append(*out, v16)
+			in.WantComma()
+		}
+		in.Delim(']')
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels5(out *jwriter.Writer, in RejectionReasonList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v17, v18 := range in {
+			if v17 > 0 {
+				out.RawByte(',')
+			}
+			(v18).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RejectionReasonList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RejectionReasonList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RejectionReasonList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RejectionReasonList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlexer.Lexer, out *RejectionReason) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels6(out *jwriter.Writer, in RejectionReason) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RejectionReason) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RejectionReason) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RejectionReason) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RejectionReason) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlexer.Lexer, out *RegReqWithUserRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RegReqWithUserRespList, 0, 0)
+			} else {
+				*out = RegReqWithUserRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v19 RegReqWithUserResp
+			(v19).UnmarshalEasyJSON(in)
+			*out = append(*out, v19)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels7(out *jwriter.Writer, in RegReqWithUserRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v20, v21 := range in {
+			if v20 > 0 {
+				out.RawByte(',')
+			}
+			(v21).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqWithUserRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqWithUserRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqWithUserRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqWithUserRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlexer.Lexer, out *RegReqWithUserResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "manager":
+			if in.IsNull() {
+				in.Skip()
+				out.Manager = nil
+			} else {
+				if out.Manager == nil {
+					out.Manager = new(UserRes)
+				}
+				(*out.Manager).UnmarshalEasyJSON(in)
+			}
+		case "type":
+			out.Type = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "time_in_queue":
+			out.TimeInQueue = uint32(in.Uint32())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "message":
+			out.Message = string(in.String())
+		case "escalation_reason":
+			out.EscalationReason = string(in.String())
+		case "unread_messages":
+			out.UnreadMessages = uint64(in.Uint64())
+		case "possible_duplicates":
+			out.PossibleDuplicates = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels8(out *jwriter.Writer, in RegReqWithUserResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		(in.User).MarshalEasyJSON(out)
+	}
+	if in.Manager != nil {
+		const prefix string = ",\"manager\":"
+		out.RawString(prefix)
+		(*in.Manager).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"time_in_queue\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.TimeInQueue))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if in.EscalationReason != "" {
+		const prefix string = ",\"escalation_reason\":"
+		out.RawString(prefix)
+		out.String(string(in.EscalationReason))
+	}
+	{
+		const prefix string = ",\"unread_messages\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UnreadMessages))
+	}
+	{
+		const prefix string = ",\"possible_duplicates\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.PossibleDuplicates))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqWithUserResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqWithUserResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqWithUserResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqWithUserResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlexer.Lexer, out *RegReqWithUser) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "manager":
+			if in.IsNull() {
+				in.Skip()
+				out.Manager = nil
+			} else {
+				if out.Manager == nil {
+					out.Manager = new(User)
+				}
+				(*out.Manager).UnmarshalEasyJSON(in)
+			}
+		case "type":
+			out.Type = RegReqType(in.Int8())
+		case "status":
+			out.Status = string(in.String())
+		case "time_in_queue":
+			out.TimeInQueue = uint32(in.Uint32())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "message":
+			out.Message = string(in.String())
+		case "escalation_reason":
+			out.EscalationReason = string(in.String())
+		case "unread_messages":
+			out.UnreadMessages = uint64(in.Uint64())
+		case "possible_duplicates":
+			out.PossibleDuplicates = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels9(out *jwriter.Writer, in RegReqWithUser) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		(in.User).MarshalEasyJSON(out)
+	}
+	if in.Manager != nil {
+		const prefix string = ",\"manager\":"
+		out.RawString(prefix)
+		(*in.Manager).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.Int8(int8(in.Type))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"time_in_queue\":"
+		out.RawString(prefix)
+		out.Uint32(uint32(in.TimeInQueue))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"escalation_reason\":"
+		out.RawString(prefix)
+		out.String(string(in.EscalationReason))
+	}
+	{
+		const prefix string = ",\"unread_messages\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UnreadMessages))
+	}
+	{
+		const prefix string = ",\"possible_duplicates\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.PossibleDuplicates))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqWithUser) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqWithUser) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqWithUser) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqWithUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(in *jlexer.Lexer, out *RegReqStatsResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from":
+			out.From = uint64(in.Uint64())
+		case "to":
+			out.To = uint64(in.Uint64())
+		case "pending":
+			if in.IsNull() {
+				in.Skip()
+				out.Pending = nil
+			} else {
+				in.Delim('[')
+				if out.Pending == nil {
+					if !in.IsDelim(']') {
+						out.Pending = make([]PendingRegReqStatResp, 0, 2)
+					} else {
+						out.Pending = []PendingRegReqStatResp{}
+					}
+				} else {
+					out.Pending = (out.Pending)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 PendingRegReqStatResp
+					(v22).UnmarshalEasyJSON(in)
+					out.Pending = append(out.Pending, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "median_processing_time":
+			out.MedianProcessingTime = float64(in.Float64())
+		case "p90_processing_time":
+			out.P90ProcessingTime = float64(in.Float64())
+		case "decisions":
+			if in.IsNull() {
+				in.Skip()
+				out.Decisions = nil
+			} else {
+				in.Delim('[')
+				if out.Decisions == nil {
+					if !in.IsDelim(']') {
+						out.Decisions = make([]ManagerDecisionsStatResp, 0, 0)
+					} else {
+						out.Decisions = []ManagerDecisionsStatResp{}
+					}
+				} else {
+					out.Decisions = (out.Decisions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v23 ManagerDecisionsStatResp
+					(v23).UnmarshalEasyJSON(in)
+					out.Decisions = append(out.Decisions, v23)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "multi_fix_share":
+			out.MultiFixShare = float64(in.Float64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels10(out *jwriter.Writer, in RegReqStatsResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.To))
+	}
+	{
+		const prefix string = ",\"pending\":"
+		out.RawString(prefix)
+		if in.Pending == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v24, v25 := range in.Pending {
+				if v24 > 0 {
+					out.RawByte(',')
+				}
+				(v25).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"median_processing_time\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MedianProcessingTime))
+	}
+	{
+		const prefix string = ",\"p90_processing_time\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.P90ProcessingTime))
+	}
+	{
+		const prefix string = ",\"decisions\":"
+		out.RawString(prefix)
+		if in.Decisions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v26, v27 := range in.Decisions {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				(v27).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"multi_fix_share\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MultiFixShare))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqStatsResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqStatsResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqStatsResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqStatsResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(in *jlexer.Lexer, out *RegReqRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RegReqRespList, 0, 0)
+			} else {
+				*out = RegReqRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v28 RegReqResp
+			(v28).UnmarshalEasyJSON(in)
+			*out = append(*out, v28)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels11(out *jwriter.Writer, in RegReqRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v29, v30 := range in {
+			if v29 > 0 {
+				out.RawByte(',')
+			}
+			(v30).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(in *jlexer.Lexer, out *RegReqResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "manager_id":
+			out.ManagerID = uint64(in.Uint64())
+		case "type":
+			out.Type = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "message":
+			out.Message = string(in.String())
+		case "issues":
+			(out.Issues).UnmarshalEasyJSON(in)
+		case "unread_messages":
+			out.UnreadMessages = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels12(out *jwriter.Writer, in RegReqResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UserID))
+	}
+	if in.ManagerID != 0 {
+		const prefix string = ",\"manager_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ManagerID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if len(in.Issues) != 0 {
+		const prefix string = ",\"issues\":"
+		out.RawString(prefix)
+		(in.Issues).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"unread_messages\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UnreadMessages))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(in *jlexer.Lexer, out *RegReqMessageRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RegReqMessageRespList, 0, 0)
+			} else {
+				*out = RegReqMessageRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v31 RegReqMessageResp
+			(v31).UnmarshalEasyJSON(in)
+			*out = append(*out, v31)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels13(out *jwriter.Writer, in RegReqMessageRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v32, v33 := range in {
+			if v32 > 0 {
+				out.RawByte(',')
+			}
+			(v33).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqMessageRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqMessageRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqMessageRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqMessageRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(in *jlexer.Lexer, out *RegReqMessageResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "author":
+			(out.Author).UnmarshalEasyJSON(in)
+		case "body":
+			out.Body = string(in.String())
+		case "internal":
+			out.Internal = bool(in.Bool())
+		case "attachments":
+			if in.IsNull() {
+				in.Skip()
+				out.Attachments = nil
+			} else {
+				in.Delim('[')
+				if out.Attachments == nil {
+					if !in.IsDelim(']') {
+						out.Attachments = make([]string, 0, 4)
+					} else {
+						out.Attachments = []string{}
+					}
+				} else {
+					out.Attachments = (out.Attachments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v34 string
+					v34 = string(in.String())
+					out.Attachments = append(out.Attachments, v34)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "receipts":
+			(out.Receipts).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels14(out *jwriter.Writer, in RegReqMessageResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		(in.Author).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"internal\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Internal))
+	}
+	{
+		const prefix string = ",\"attachments\":"
+		out.RawString(prefix)
+		if in.Attachments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.Attachments {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v36))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"receipts\":"
+		out.RawString(prefix)
+		(in.Receipts).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqMessageResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqMessageResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqMessageResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqMessageResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels15(in *jlexer.Lexer, out *RegReqMessageReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "body":
+			out.Body = string(in.String())
+		case "internal":
+			out.Internal = bool(in.Bool())
+		case "attachments":
+			if in.IsNull() {
+				in.Skip()
+				out.Attachments = nil
+			} else {
+				in.Delim('[')
+				if out.Attachments == nil {
+					if !in.IsDelim(']') {
+						out.Attachments = make([]string, 0, 4)
+					} else {
+						out.Attachments = []string{}
+					}
+				} else {
+					out.Attachments = (out.Attachments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 string
+					v37 = string(in.String())
+					out.Attachments = append(out.Attachments, v37)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels15(out *jwriter.Writer, in RegReqMessageReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"internal\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Internal))
+	}
+	{
+		const prefix string = ",\"attachments\":"
+		out.RawString(prefix)
+		if in.Attachments == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v38, v39 := range in.Attachments {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v39))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqMessageReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqMessageReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqMessageReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqMessageReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels15(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels16(in *jlexer.Lexer, out *RegReqHistoryRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RegReqHistoryRespList, 0, 0)
+			} else {
+				*out = RegReqHistoryRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v40 RegReqHistoryResp
+			(v40).UnmarshalEasyJSON(in)
+			*out = append(*out, v40)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels16(out *jwriter.Writer, in RegReqHistoryRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v41, v42 := range in {
+			if v41 > 0 {
+				out.RawByte(',')
+			}
+			(v42).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqHistoryRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqHistoryRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqHistoryRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqHistoryRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels16(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels17(in *jlexer.Lexer, out *RegReqHistoryResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "type":
+			out.Type = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "req_create_time":
+			out.ReqCreateTime = uint64(in.Uint64())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels17(out *jwriter.Writer, in RegReqHistoryResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"req_create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ReqCreateTime))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqHistoryResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqHistoryResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqHistoryResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqHistoryResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels17(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels18(in *jlexer.Lexer, out *RegReqFull) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "manager_id":
+			out.ManagerID = uint64(in.Uint64())
+		case "type":
+			out.Type = RegReqType(in.Int8())
+		case "status":
+			out.Status = string(in.String())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		case "message":
+			out.Message = string(in.String())
+		case "issues":
+			(out.Issues).UnmarshalEasyJSON(in)
+		case "unread_messages":
+			out.UnreadMessages = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels18(out *jwriter.Writer, in RegReqFull) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UserID))
+	}
+	if in.ManagerID != 0 {
+		const prefix string = ",\"manager_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ManagerID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.Int8(int8(in.Type))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if len(in.Issues) != 0 {
+		const prefix string = ",\"issues\":"
+		out.RawString(prefix)
+		(in.Issues).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"unread_messages\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UnreadMessages))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqFull) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqFull) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqFull) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqFull) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels18(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels19(in *jlexer.Lexer, out *RegReqChangeRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(RegReqChangeRespList, 0, 1)
+			} else {
+				*out = RegReqChangeRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v43 RegReqChangeResp
+			(v43).UnmarshalEasyJSON(in)
+			*out = append(*out, v43)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels19(out *jwriter.Writer, in RegReqChangeRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v44, v45 := range in {
+			if v44 > 0 {
+				out.RawByte(',')
+			}
+			(v45).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqChangeRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqChangeRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqChangeRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqChangeRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels19(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels20(in *jlexer.Lexer, out *RegReqChangeResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "field":
+			out.Field = string(in.String())
+		case "before":
+			out.Before = string(in.String())
+		case "after":
+			out.After = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels20(out *jwriter.Writer, in RegReqChangeResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"field\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Field))
+	}
+	{
+		const prefix string = ",\"before\":"
+		out.RawString(prefix)
+		out.String(string(in.Before))
+	}
+	{
+		const prefix string = ",\"after\":"
+		out.RawString(prefix)
+		out.String(string(in.After))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RegReqChangeResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RegReqChangeResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RegReqChangeResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RegReqChangeResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels20(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels21(in *jlexer.Lexer, out *ReassignReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "manager_id":
+			out.ManagerID = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels21(out *jwriter.Writer, in ReassignReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"manager_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ManagerID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReassignReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReassignReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReassignReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReassignReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels21(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels22(in *jlexer.Lexer, out *PendingRegReqStatResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "count":
+			out.Count = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels22(out *jwriter.Writer, in PendingRegReqStatResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PendingRegReqStatResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PendingRegReqStatResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PendingRegReqStatResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PendingRegReqStatResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels22(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels23(in *jlexer.Lexer, out *ParentPassportReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "passport":
+			out.Passport = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels23(out *jwriter.Writer, in ParentPassportReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"passport\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Passport))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentPassportReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentPassportReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentPassportReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentPassportReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels23(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels24(in *jlexer.Lexer, out *MessageReceiptList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(MessageReceiptList, 0, 4)
+			} else {
+				*out = MessageReceiptList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v46 MessageReceipt
+			(v46).UnmarshalEasyJSON(in)
+			*out = append(*out, v46)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels24(out *jwriter.Writer, in MessageReceiptList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v47, v48 := range in {
+			if v47 > 0 {
+				out.RawByte(',')
+			}
+			(v48).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageReceiptList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageReceiptList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageReceiptList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageReceiptList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels24(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels25(in *jlexer.Lexer, out *MessageReceipt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "read_time":
+			out.ReadTime = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels25(out *jwriter.Writer, in MessageReceipt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.UserID))
+	}
+	{
+		const prefix string = ",\"read_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ReadTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageReceipt) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageReceipt) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageReceipt) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageReceipt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels25(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels26(in *jlexer.Lexer, out *MergeChildrenReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "duplicate_id":
+			out.DuplicateID = uint64(in.Uint64())
+		case "keep_child_id":
+			out.KeepChildID = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels26(out *jwriter.Writer, in MergeChildrenReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"duplicate_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.DuplicateID))
+	}
+	{
+		const prefix string = ",\"keep_child_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.KeepChildID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MergeChildrenReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MergeChildrenReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MergeChildrenReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MergeChildrenReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels26(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels27(in *jlexer.Lexer, out *ManagerDecisionsStatResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "manager":
+			(out.Manager).UnmarshalEasyJSON(in)
+		case "day":
+			out.Day = string(in.String())
+		case "approved":
+			out.Approved = uint64(in.Uint64())
+		case "failed":
+			out.Failed = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels27(out *jwriter.Writer, in ManagerDecisionsStatResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"manager\":"
+		out.RawString(prefix[1:])
+		(in.Manager).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"day\":"
+		out.RawString(prefix)
+		out.String(string(in.Day))
+	}
+	{
+		const prefix string = ",\"approved\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Approved))
+	}
+	{
+		const prefix string = ",\"failed\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Failed))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ManagerDecisionsStatResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ManagerDecisionsStatResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ManagerDecisionsStatResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ManagerDecisionsStatResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels27(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels28(in *jlexer.Lexer, out *FixParentPassportReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "passport":
+			out.Passport = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels28(out *jwriter.Writer, in FixParentPassportReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"passport\":"
+		out.RawString(prefix)
+		out.String(string(in.Passport))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FixParentPassportReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FixParentPassportReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FixParentPassportReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FixParentPassportReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels28(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels29(in *jlexer.Lexer, out *FixChildThirdRegReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels29(out *jwriter.Writer, in FixChildThirdRegReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"child\":"
+		out.RawString(prefix)
+		(in.Child).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FixChildThirdRegReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FixChildThirdRegReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FixChildThirdRegReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FixChildThirdRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels29(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels30(in *jlexer.Lexer, out *FixChildStageReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels30(out *jwriter.Writer, in FixChildStageReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FixChildStageReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FixChildStageReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FixChildStageReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FixChildStageReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels30(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels31(in *jlexer.Lexer, out *FixChildSecondRegReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		case "relationship":
+			out.Relationship = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels31(out *jwriter.Writer, in FixChildSecondRegReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"child\":"
+		out.RawString(prefix)
+		(in.Child).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"relationship\":"
+		out.RawString(prefix)
+		out.String(string(in.Relationship))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FixChildSecondRegReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FixChildSecondRegReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FixChildSecondRegReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FixChildSecondRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels31(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels32(in *jlexer.Lexer, out *FixChildFirstRegReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		case "is_student":
+			out.IsStudent = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels32(out *jwriter.Writer, in FixChildFirstRegReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"child\":"
+		out.RawString(prefix)
+		(in.Child).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"is_student\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsStudent))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FixChildFirstRegReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FixChildFirstRegReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FixChildFirstRegReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FixChildFirstRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels32(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels33(in *jlexer.Lexer, out *FieldIssueList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(FieldIssueList, 0, 1)
+			} else {
+				*out = FieldIssueList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v49 FieldIssue
+			(v49).UnmarshalEasyJSON(in)
+			*out = append(*out, v49)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels33(out *jwriter.Writer, in FieldIssueList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v50, v51 := range in {
+			if v50 > 0 {
+				out.RawByte(',')
+			}
+			(v51).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FieldIssueList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FieldIssueList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FieldIssueList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FieldIssueList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels33(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels34(in *jlexer.Lexer, out *FieldIssue) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "field":
+			out.Field = string(in.String())
+		case "document":
+			out.Document = string(in.String())
+		case "reason_code":
+			out.ReasonCode = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels34(out *jwriter.Writer, in FieldIssue) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"field\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Field))
+	}
+	if in.Document != "" {
+		const prefix string = ",\"document\":"
+		out.RawString(prefix)
+		out.String(string(in.Document))
+	}
+	{
+		const prefix string = ",\"reason_code\":"
+		out.RawString(prefix)
+		out.String(string(in.ReasonCode))
+	}
+	{
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FieldIssue) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FieldIssue) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FieldIssue) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FieldIssue) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels34(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels35(in *jlexer.Lexer, out *FailedReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqId = uint64(in.Uint64())
+		case "failed_message":
+			out.FailedMessage = string(in.String())
+		case "issues":
+			(out.Issues).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels35(out *jwriter.Writer, in FailedReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqId))
+	}
+	{
+		const prefix string = ",\"failed_message\":"
+		out.RawString(prefix)
+		out.String(string(in.FailedMessage))
+	}
+	if len(in.Issues) != 0 {
+		const prefix string = ",\"issues\":"
+		out.RawString(prefix)
+		(in.Issues).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FailedReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FailedReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FailedReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FailedReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels35(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels36(in *jlexer.Lexer, out *EscalateReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels36(out *jwriter.Writer, in EscalateReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EscalateReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels36(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EscalateReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels36(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EscalateReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels36(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EscalateReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels36(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels37(in *jlexer.Lexer, out *DuplicateChild) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "last_name":
+			out.LastName = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "birth_date":
+			out.BirthDate = uint64(in.Uint64())
+		case "done_stage":
+			out.DoneStage = Stage(in.Int8())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels37(out *jwriter.Writer, in DuplicateChild) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.UserID))
+	}
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.LastName))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"birth_date\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.BirthDate))
+	}
+	{
+		const prefix string = ",\"done_stage\":"
+		out.RawString(prefix)
+		out.Int8(int8(in.DoneStage))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DuplicateChild) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels37(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DuplicateChild) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels37(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DuplicateChild) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels37(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DuplicateChild) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels37(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels38(in *jlexer.Lexer, out *DocumentChecklistList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(DocumentChecklistList, 0, 0)
+			} else {
+				*out = DocumentChecklistList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v52 DocumentChecklist
+			(v52).UnmarshalEasyJSON(in)
+			*out = append(*out, v52)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels38(out *jwriter.Writer, in DocumentChecklistList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v53, v54 := range in {
+			if v53 > 0 {
+				out.RawByte(',')
+			}
+			(v54).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DocumentChecklistList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DocumentChecklistList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DocumentChecklistList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DocumentChecklistList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels38(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels39(in *jlexer.Lexer, out *DocumentChecklist) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "role":
+			out.Role = string(in.String())
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "stages":
+			if in.IsNull() {
+				in.Skip()
+				out.Stages = nil
+			} else {
+				in.Delim('[')
+				if out.Stages == nil {
+					if !in.IsDelim(']') {
+						out.Stages = make([]ChecklistStage, 0, 1)
+					} else {
+						out.Stages = []ChecklistStage{}
+					}
+				} else {
+					out.Stages = (out.Stages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v55 ChecklistStage
+					(v55).UnmarshalEasyJSON(in)
+					out.Stages = append(out.Stages, v55)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels39(out *jwriter.Writer, in DocumentChecklist) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.UserID))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
+	}
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"stages\":"
+		out.RawString(prefix)
+		if in.Stages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v56, v57 := range in.Stages {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				(v57).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DocumentChecklist) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DocumentChecklist) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DocumentChecklist) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DocumentChecklist) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels39(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels40(in *jlexer.Lexer, out *ChildThirdRegReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels40(out *jwriter.Writer, in ChildThirdRegReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"child\":"
+		out.RawString(prefix[1:])
+		(in.Child).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChildThirdRegReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChildThirdRegReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChildThirdRegReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChildThirdRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels40(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels41(in *jlexer.Lexer, out *ChildStageReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
 		in.WantColon()
 		if in.IsNull() {
 			in.Skip()
@@ -522,20 +3835,10 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlex
 			continue
 		}
 		switch key {
-		case "id":
-			out.ID = uint64(in.Uint64())
-		case "user_id":
-			out.UserID = uint64(in.Uint64())
-		case "manager_id":
-			out.ManagerID = uint64(in.Uint64())
 		case "type":
 			out.Type = RegReqType(in.Int8())
-		case "status":
-			out.Status = string(in.String())
-		case "create_time":
-			out.CreateTime = uint64(in.Uint64())
-		case "message":
-			out.Message = string(in.String())
+		case "child_id":
+			out.ChildID = uint64(in.Uint64())
 		default:
 			in.SkipRecursive()
 		}
@@ -546,72 +3849,120 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels5(out *jwriter.Writer, in RegReqFull) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels41(out *jwriter.Writer, in ChildStageReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ID))
+		out.Int8(int8(in.Type))
 	}
 	{
-		const prefix string = ",\"user_id\":"
+		const prefix string = ",\"child_id\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.UserID))
+		out.Uint64(uint64(in.ChildID))
 	}
-	if in.ManagerID != 0 {
-		const prefix string = ",\"manager_id\":"
-		out.RawString(prefix)
-		out.Uint64(uint64(in.ManagerID))
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChildStageReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels41(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChildStageReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels41(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChildStageReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels41(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChildStageReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels41(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels42(in *jlexer.Lexer, out *ChildSecondRegReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
 	}
-	{
-		const prefix string = ",\"type\":"
-		out.RawString(prefix)
-		out.Int8(int8(in.Type))
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		case "relationship":
+			out.Relationship = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
-	{
-		const prefix string = ",\"status\":"
-		out.RawString(prefix)
-		out.String(string(in.Status))
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels42(out *jwriter.Writer, in ChildSecondRegReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"create_time\":"
-		out.RawString(prefix)
-		out.Uint64(uint64(in.CreateTime))
+		const prefix string = ",\"child\":"
+		out.RawString(prefix[1:])
+		(in.Child).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"message\":"
+		const prefix string = ",\"relationship\":"
 		out.RawString(prefix)
-		out.String(string(in.Message))
+		out.String(string(in.Relationship))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RegReqFull) MarshalJSON() ([]byte, error) {
+func (v ChildSecondRegReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels5(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels42(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RegReqFull) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels5(w, v)
+func (v ChildSecondRegReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels42(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RegReqFull) UnmarshalJSON(data []byte) error {
+func (v *ChildSecondRegReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels42(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RegReqFull) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels5(l, v)
+func (v *ChildSecondRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels42(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlexer.Lexer, out *ParentPassportReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels43(in *jlexer.Lexer, out *ChildFirstRegReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -630,8 +3981,10 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlex
 			continue
 		}
 		switch key {
-		case "passport":
-			out.Passport = string(in.String())
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		case "is_student":
+			out.IsStudent = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -642,42 +3995,113 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels6(out *jwriter.Writer, in ParentPassportReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels43(out *jwriter.Writer, in ChildFirstRegReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"passport\":"
+		const prefix string = ",\"child\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Passport))
+		(in.Child).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"is_student\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsStudent))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ParentPassportReq) MarshalJSON() ([]byte, error) {
+func (v ChildFirstRegReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels6(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels43(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ParentPassportReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels6(w, v)
+func (v ChildFirstRegReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels43(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ParentPassportReq) UnmarshalJSON(data []byte) error {
+func (v *ChildFirstRegReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels43(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ParentPassportReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels6(l, v)
+func (v *ChildFirstRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels43(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels44(in *jlexer.Lexer, out *ChildDuplicateList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ChildDuplicateList, 0, 0)
+			} else {
+				*out = ChildDuplicateList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v58 ChildDuplicate
+			(v58).UnmarshalEasyJSON(in)
+			*out = append(*out, v58)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels44(out *jwriter.Writer, in ChildDuplicateList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v59, v60 := range in {
+			if v59 > 0 {
+				out.RawByte(',')
+			}
+			(v60).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChildDuplicateList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels44(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChildDuplicateList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels44(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChildDuplicateList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels44(&r, v)
+	return r.Error()
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlexer.Lexer, out *FixParentPassportReq) {
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChildDuplicateList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels44(l, v)
+}
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels45(in *jlexer.Lexer, out *ChildDuplicate) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -696,10 +4120,20 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlex
 			continue
 		}
 		switch key {
-		case "req_id":
-			out.ReqID = uint64(in.Uint64())
-		case "passport":
-			out.Passport = string(in.String())
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "child":
+			(out.Child).UnmarshalEasyJSON(in)
+		case "duplicate":
+			(out.Duplicate).UnmarshalEasyJSON(in)
+		case "similarity":
+			out.Similarity = float32(in.Float32())
+		case "passport_match":
+			out.PassportMatch = bool(in.Bool())
+		case "status":
+			out.Status = string(in.String())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
 		default:
 			in.SkipRecursive()
 		}
@@ -709,48 +4143,73 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlex
 	if isTopLevel {
 		in.Consumed()
 	}
-}
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels7(out *jwriter.Writer, in FixParentPassportReq) {
-	out.RawByte('{')
-	first := true
-	_ = first
+}
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels45(out *jwriter.Writer, in ChildDuplicate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"child\":"
+		out.RawString(prefix)
+		(in.Child).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"duplicate\":"
+		out.RawString(prefix)
+		(in.Duplicate).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"similarity\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Similarity))
+	}
+	{
+		const prefix string = ",\"passport_match\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassportMatch))
+	}
 	{
-		const prefix string = ",\"req_id\":"
-		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ReqID))
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
 	}
 	{
-		const prefix string = ",\"passport\":"
+		const prefix string = ",\"create_time\":"
 		out.RawString(prefix)
-		out.String(string(in.Passport))
+		out.Uint64(uint64(in.CreateTime))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v FixParentPassportReq) MarshalJSON() ([]byte, error) {
+func (v ChildDuplicate) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels7(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels45(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FixParentPassportReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels7(w, v)
+func (v ChildDuplicate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels45(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *FixParentPassportReq) UnmarshalJSON(data []byte) error {
+func (v *ChildDuplicate) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels45(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FixParentPassportReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels7(l, v)
+func (v *ChildDuplicate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels45(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlexer.Lexer, out *FixChildThirdRegReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels46(in *jlexer.Lexer, out *ChildDataChangeReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -769,10 +4228,49 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlex
 			continue
 		}
 		switch key {
-		case "req_id":
-			out.ReqID = uint64(in.Uint64())
-		case "child":
-			(out.Child).UnmarshalEasyJSON(in)
+		case "child_id":
+			out.ChildID = uint64(in.Uint64())
+		case "changes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Changes = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v61 string
+					v61 = string(in.String())
+					(out.Changes)[key] = v61
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "comment":
+			out.Comment = string(in.String())
+		case "documents":
+			if in.IsNull() {
+				in.Skip()
+				out.Documents = nil
+			} else {
+				in.Delim('[')
+				if out.Documents == nil {
+					if !in.IsDelim(']') {
+						out.Documents = make([]string, 0, 4)
+					} else {
+						out.Documents = []string{}
+					}
+				} else {
+					out.Documents = (out.Documents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v62 string
+					v62 = string(in.String())
+					out.Documents = append(out.Documents, v62)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -783,47 +4281,84 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels8(out *jwriter.Writer, in FixChildThirdRegReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels46(out *jwriter.Writer, in ChildDataChangeReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"req_id\":"
+		const prefix string = ",\"child_id\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ReqID))
+		out.Uint64(uint64(in.ChildID))
 	}
 	{
-		const prefix string = ",\"child\":"
+		const prefix string = ",\"changes\":"
 		out.RawString(prefix)
-		(in.Child).MarshalEasyJSON(out)
+		if in.Changes == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v63First := true
+			for v63Name, v63Value := range in.Changes {
+				if v63First {
+					v63First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v63Name))
+				out.RawByte(':')
+				out.String(string(v63Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	{
+		const prefix string = ",\"documents\":"
+		out.RawString(prefix)
+		if in.Documents == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v64, v65 := range in.Documents {
+				if v64 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v65))
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v FixChildThirdRegReq) MarshalJSON() ([]byte, error) {
+func (v ChildDataChangeReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels8(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels46(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FixChildThirdRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels8(w, v)
+func (v ChildDataChangeReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels46(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *FixChildThirdRegReq) UnmarshalJSON(data []byte) error {
+func (v *ChildDataChangeReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels46(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FixChildThirdRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels8(l, v)
+func (v *ChildDataChangeReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels46(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlexer.Lexer, out *FixChildSecondRegReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels47(in *jlexer.Lexer, out *ChecklistStage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -842,12 +4377,37 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlex
 			continue
 		}
 		switch key {
+		case "type":
+			out.Type = RegReqType(in.Int8())
+		case "title":
+			out.Title = string(in.String())
 		case "req_id":
 			out.ReqID = uint64(in.Uint64())
-		case "child":
-			(out.Child).UnmarshalEasyJSON(in)
-		case "relationship":
-			out.Relationship = string(in.String())
+		case "documents":
+			if in.IsNull() {
+				in.Skip()
+				out.Documents = nil
+			} else {
+				in.Delim('[')
+				if out.Documents == nil {
+					if !in.IsDelim(']') {
+						out.Documents = make([]ChecklistDocument, 0, 1)
+					} else {
+						out.Documents = []ChecklistDocument{}
+					}
+				} else {
+					out.Documents = (out.Documents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v66 ChecklistDocument
+					(v66).UnmarshalEasyJSON(in)
+					out.Documents = append(out.Documents, v66)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "complete":
+			out.Complete = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -858,52 +4418,73 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels9(out *jwriter.Writer, in FixChildSecondRegReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels47(out *jwriter.Writer, in ChecklistStage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"req_id\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
+		out.Int8(int8(in.Type))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if in.ReqID != 0 {
+		const prefix string = ",\"req_id\":"
+		out.RawString(prefix)
 		out.Uint64(uint64(in.ReqID))
 	}
 	{
-		const prefix string = ",\"child\":"
+		const prefix string = ",\"documents\":"
 		out.RawString(prefix)
-		(in.Child).MarshalEasyJSON(out)
+		if in.Documents == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v67, v68 := range in.Documents {
+				if v67 > 0 {
+					out.RawByte(',')
+				}
+				(v68).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"relationship\":"
+		const prefix string = ",\"complete\":"
 		out.RawString(prefix)
-		out.String(string(in.Relationship))
+		out.Bool(bool(in.Complete))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v FixChildSecondRegReq) MarshalJSON() ([]byte, error) {
+func (v ChecklistStage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels9(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels47(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FixChildSecondRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels9(w, v)
+func (v ChecklistStage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels47(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *FixChildSecondRegReq) UnmarshalJSON(data []byte) error {
+func (v *ChecklistStage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels47(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FixChildSecondRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels9(l, v)
+func (v *ChecklistStage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels47(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(in *jlexer.Lexer, out *FixChildFirstRegReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels48(in *jlexer.Lexer, out *ChecklistDocument) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -922,12 +4503,33 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(in *jle
 			continue
 		}
 		switch key {
-		case "req_id":
-			out.ReqID = uint64(in.Uint64())
-		case "child":
-			(out.Child).UnmarshalEasyJSON(in)
-		case "is_student":
-			out.IsStudent = bool(in.Bool())
+		case "category":
+			out.Category = string(in.String())
+		case "uploaded":
+			out.Uploaded = bool(in.Bool())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.Files = nil
+			} else {
+				in.Delim('[')
+				if out.Files == nil {
+					if !in.IsDelim(']') {
+						out.Files = make([]string, 0, 4)
+					} else {
+						out.Files = []string{}
+					}
+				} else {
+					out.Files = (out.Files)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v69 string
+					v69 = string(in.String())
+					out.Files = append(out.Files, v69)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -938,52 +4540,63 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(in *jle
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels10(out *jwriter.Writer, in FixChildFirstRegReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels48(out *jwriter.Writer, in ChecklistDocument) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"req_id\":"
+		const prefix string = ",\"category\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ReqID))
+		out.String(string(in.Category))
 	}
 	{
-		const prefix string = ",\"child\":"
+		const prefix string = ",\"uploaded\":"
 		out.RawString(prefix)
-		(in.Child).MarshalEasyJSON(out)
+		out.Bool(bool(in.Uploaded))
 	}
 	{
-		const prefix string = ",\"is_student\":"
+		const prefix string = ",\"files\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsStudent))
+		if in.Files == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v70, v71 := range in.Files {
+				if v70 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v71))
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v FixChildFirstRegReq) MarshalJSON() ([]byte, error) {
+func (v ChecklistDocument) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels10(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels48(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FixChildFirstRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels10(w, v)
+func (v ChecklistDocument) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels48(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *FixChildFirstRegReq) UnmarshalJSON(data []byte) error {
+func (v *ChecklistDocument) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels48(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FixChildFirstRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels10(l, v)
+func (v *ChecklistDocument) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels48(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(in *jlexer.Lexer, out *FailedReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels49(in *jlexer.Lexer, out *BatchResultResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1002,10 +4615,33 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(in *jle
 			continue
 		}
 		switch key {
-		case "req_id":
-			out.ReqId = uint64(in.Uint64())
-		case "failed_message":
-			out.FailedMessage = string(in.String())
+		case "succeeded":
+			out.Succeeded = uint64(in.Uint64())
+		case "failed":
+			out.Failed = uint64(in.Uint64())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]BatchItemResultResp, 0, 2)
+					} else {
+						out.Items = []BatchItemResultResp{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v72 BatchItemResultResp
+					(v72).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v72)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1016,47 +4652,63 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(in *jle
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels11(out *jwriter.Writer, in FailedReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels49(out *jwriter.Writer, in BatchResultResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"req_id\":"
+		const prefix string = ",\"succeeded\":"
 		out.RawString(prefix[1:])
-		out.Uint64(uint64(in.ReqId))
+		out.Uint64(uint64(in.Succeeded))
 	}
 	{
-		const prefix string = ",\"failed_message\":"
+		const prefix string = ",\"failed\":"
 		out.RawString(prefix)
-		out.String(string(in.FailedMessage))
+		out.Uint64(uint64(in.Failed))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v73, v74 := range in.Items {
+				if v73 > 0 {
+					out.RawByte(',')
+				}
+				(v74).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v FailedReq) MarshalJSON() ([]byte, error) {
+func (v BatchResultResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels11(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels49(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FailedReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels11(w, v)
+func (v BatchResultResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels49(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *FailedReq) UnmarshalJSON(data []byte) error {
+func (v *BatchResultResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels49(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FailedReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels11(l, v)
+func (v *BatchResultResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels49(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(in *jlexer.Lexer, out *ChildThirdRegReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels50(in *jlexer.Lexer, out *BatchItemResultResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1075,8 +4727,12 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(in *jle
 			continue
 		}
 		switch key {
-		case "child":
-			(out.Child).UnmarshalEasyJSON(in)
+		case "req_id":
+			out.ReqID = uint64(in.Uint64())
+		case "status":
+			out.Status = int(in.Int())
+		case "error":
+			out.Error = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1087,42 +4743,52 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(in *jle
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels12(out *jwriter.Writer, in ChildThirdRegReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels50(out *jwriter.Writer, in BatchItemResultResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"child\":"
+		const prefix string = ",\"req_id\":"
 		out.RawString(prefix[1:])
-		(in.Child).MarshalEasyJSON(out)
+		out.Uint64(uint64(in.ReqID))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int(int(in.Status))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChildThirdRegReq) MarshalJSON() ([]byte, error) {
+func (v BatchItemResultResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels12(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels50(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChildThirdRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels12(w, v)
+func (v BatchItemResultResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels50(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChildThirdRegReq) UnmarshalJSON(data []byte) error {
+func (v *BatchItemResultResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels50(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChildThirdRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels12(l, v)
+func (v *BatchItemResultResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels50(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(in *jlexer.Lexer, out *ChildSecondRegReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels51(in *jlexer.Lexer, out *BatchFailedReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1141,10 +4807,54 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(in *jle
 			continue
 		}
 		switch key {
-		case "child":
-			(out.Child).UnmarshalEasyJSON(in)
-		case "relationship":
-			out.Relationship = string(in.String())
+		case "req_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.ReqIDs = nil
+			} else {
+				in.Delim('[')
+				if out.ReqIDs == nil {
+					if !in.IsDelim(']') {
+						out.ReqIDs = make([]uint64, 0, 8)
+					} else {
+						out.ReqIDs = []uint64{}
+					}
+				} else {
+					out.ReqIDs = (out.ReqIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v75 uint64
+					v75 = uint64(in.Uint64())
+					out.ReqIDs = append(out.ReqIDs, v75)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "failed_message":
+			out.FailedMessage = string(in.String())
+		case "items":
+			if in.IsNull() {
+				in.Skip()
+				out.Items = nil
+			} else {
+				in.Delim('[')
+				if out.Items == nil {
+					if !in.IsDelim(']') {
+						out.Items = make([]FailedReq, 0, 1)
+					} else {
+						out.Items = []FailedReq{}
+					}
+				} else {
+					out.Items = (out.Items)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v76 FailedReq
+					(v76).UnmarshalEasyJSON(in)
+					out.Items = append(out.Items, v76)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1155,47 +4865,74 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(in *jle
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels13(out *jwriter.Writer, in ChildSecondRegReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels51(out *jwriter.Writer, in BatchFailedReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"child\":"
+		const prefix string = ",\"req_ids\":"
 		out.RawString(prefix[1:])
-		(in.Child).MarshalEasyJSON(out)
+		if in.ReqIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v77, v78 := range in.ReqIDs {
+				if v77 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint64(uint64(v78))
+			}
+			out.RawByte(']')
+		}
 	}
 	{
-		const prefix string = ",\"relationship\":"
+		const prefix string = ",\"failed_message\":"
 		out.RawString(prefix)
-		out.String(string(in.Relationship))
+		out.String(string(in.FailedMessage))
+	}
+	{
+		const prefix string = ",\"items\":"
+		out.RawString(prefix)
+		if in.Items == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v79, v80 := range in.Items {
+				if v79 > 0 {
+					out.RawByte(',')
+				}
+				(v80).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChildSecondRegReq) MarshalJSON() ([]byte, error) {
+func (v BatchFailedReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels13(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels51(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChildSecondRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels13(w, v)
+func (v BatchFailedReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels51(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChildSecondRegReq) UnmarshalJSON(data []byte) error {
+func (v *BatchFailedReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels51(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChildSecondRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels13(l, v)
+func (v *BatchFailedReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels51(l, v)
 }
-func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(in *jlexer.Lexer, out *ChildFirstRegReq) {
+func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels52(in *jlexer.Lexer, out *BatchCompleteReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1214,10 +4951,29 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(in *jle
 			continue
 		}
 		switch key {
-		case "child":
-			(out.Child).UnmarshalEasyJSON(in)
-		case "is_student":
-			out.IsStudent = bool(in.Bool())
+		case "req_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.ReqIDs = nil
+			} else {
+				in.Delim('[')
+				if out.ReqIDs == nil {
+					if !in.IsDelim(']') {
+						out.ReqIDs = make([]uint64, 0, 8)
+					} else {
+						out.ReqIDs = []uint64{}
+					}
+				} else {
+					out.ReqIDs = (out.ReqIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v81 uint64
+					v81 = uint64(in.Uint64())
+					out.ReqIDs = append(out.ReqIDs, v81)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1228,43 +4984,49 @@ func easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(in *jle
 		in.Consumed()
 	}
 }
-func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels14(out *jwriter.Writer, in ChildFirstRegReq) {
+func easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels52(out *jwriter.Writer, in BatchCompleteReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"child\":"
+		const prefix string = ",\"req_ids\":"
 		out.RawString(prefix[1:])
-		(in.Child).MarshalEasyJSON(out)
-	}
-	{
-		const prefix string = ",\"is_student\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsStudent))
+		if in.ReqIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v82, v83 := range in.ReqIDs {
+				if v82 > 0 {
+					out.RawByte(',')
+				}
+				out.Uint64(uint64(v83))
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ChildFirstRegReq) MarshalJSON() ([]byte, error) {
+func (v BatchCompleteReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels14(&w, v)
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels52(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ChildFirstRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels14(w, v)
+func (v BatchCompleteReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3c9d2b01EncodeGithubComVoyakinHLokleBackendInternalModels52(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ChildFirstRegReq) UnmarshalJSON(data []byte) error {
+func (v *BatchCompleteReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(&r, v)
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels52(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ChildFirstRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels14(l, v)
+func (v *BatchCompleteReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3c9d2b01DecodeGithubComVoyakinHLokleBackendInternalModels52(l, v)
 }
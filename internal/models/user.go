@@ -29,6 +29,16 @@ func (r Role) String() string {
 	return "UNKNOWN"
 }
 
+// ParseRole returns role by its name from Role.String
+func ParseRole(name string) (Role, bool) {
+	for _, role := range []Role{ParentRole, ChildRole, ManagerRole, AdminRole} {
+		if role.String() == name {
+			return role, true
+		}
+	}
+	return 0, false
+}
+
 type Stage int8
 
 const (
@@ -48,6 +58,7 @@ type User struct {
 	EmailVerified bool   `json:"email_verified"`
 	Password      string `json:"password"`
 	Phone         string `json:"phone"`
+	Deactivated   bool   `json:"deactivated"`
 }
 
 //easyjson:json
@@ -60,11 +71,108 @@ type UserRes struct {
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
 	Phone         string `json:"phone"`
+	Deactivated   bool   `json:"deactivated"`
 }
 
 //easyjson:json
 type UserResList []UserRes
 
+type UserDirectoryFilter struct {
+	Role             *Role
+	EmailVerified    *bool
+	PassportVerified *bool
+	DoneStage        *Stage
+	CreatedFrom      uint64
+	CreatedTo        uint64
+	Search           string
+	Limit            uint64
+	Offset           uint64
+}
+
+// UserLink is parent of child or child of parent in user directory
+type UserLink struct {
+	UserID       uint64
+	FirstName    string
+	SecondName   string
+	LastName     string
+	Relationship string
+}
+
+type DirectoryUser struct {
+	User
+	CreateTime       uint64
+	PassportVerified bool
+	DoneStage        Stage
+	Parents          []UserLink
+	Children         []UserLink
+}
+
+type UserDirectory struct {
+	Total uint64
+	Users []DirectoryUser
+}
+
+//easyjson:json
+type UserLinkResp struct {
+	ID           uint64 `json:"id"`
+	FirstName    string `json:"first_name"`
+	SecondName   string `json:"second_name"`
+	LastName     string `json:"last_name"`
+	Relationship string `json:"relationship,omitempty"`
+}
+
+//easyjson:json
+type DirectoryUserResp struct {
+	ID               uint64         `json:"id"`
+	Role             string         `json:"role"`
+	FirstName        string         `json:"first_name"`
+	SecondName       string         `json:"second_name"`
+	LastName         string         `json:"last_name"`
+	Email            string         `json:"email"`
+	EmailVerified    bool           `json:"email_verified"`
+	Phone            string         `json:"phone"`
+	Deactivated      bool           `json:"deactivated"`
+	CreateTime       uint64         `json:"create_time"`
+	PassportVerified *bool          `json:"passport_verified,omitempty"`
+	DoneStage        *Stage         `json:"done_stage,omitempty"`
+	Parents          []UserLinkResp `json:"parents,omitempty"`
+	Children         []UserLinkResp `json:"children,omitempty"`
+}
+
+//easyjson:json
+type UserDirectoryResp struct {
+	Total uint64              `json:"total"`
+	Users []DirectoryUserResp `json:"users"`
+}
+
+//easyjson:json
+type ParentProfileUpdate struct {
+	FirstName  string `json:"first_name"`
+	SecondName string `json:"second_name"`
+	LastName   string `json:"last_name"`
+	Phone      string `json:"phone"`
+}
+
+//easyjson:json
+type ParentProfileUpdateRes struct {
+	User                   UserRes `json:"user"`
+	PassportReverification bool    `json:"passport_reverification"`
+}
+
+//easyjson:json
+type ManagerUpdateReq struct {
+	FirstName  string `json:"first_name"`
+	SecondName string `json:"second_name"`
+	LastName   string `json:"last_name"`
+	Phone      string `json:"phone"`
+}
+
+//easyjson:json
+type PasswordResetReq struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
 //easyjson:json
 type Parent struct {
 	ID               uint64 `json:"id"`
@@ -144,3 +252,143 @@ type ChildRes struct {
 	PlaceOfRegistration string `json:"place_of_registration"`
 	DirPath             string `json:"dir_path"`
 }
+
+// ChildProfileUpdate keeps fields which child can edit by himself,
+// empty fields are left unchanged
+//
+//easyjson:json
+type ChildProfileUpdate struct {
+	FirstName           string `json:"first_name"`
+	SecondName          string `json:"second_name"`
+	LastName            string `json:"last_name"`
+	Phone               string `json:"phone"`
+	PlaceOfResidence    string `json:"place_of_residence"`
+	PlaceOfRegistration string `json:"place_of_registration"`
+}
+
+type ChildParent struct {
+	User         User
+	Relationship string
+}
+
+//easyjson:json
+type ChildParentRes struct {
+	User         UserRes `json:"user"`
+	Relationship string  `json:"relationship"`
+}
+
+//easyjson:json
+type ChildParentResList []ChildParentRes
+
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationApproved = "approved"
+	InvitationRejected = "rejected"
+	InvitationRevoked  = "revoked"
+)
+
+// ParentInvitation links second parent or guardian to child.
+// Invitee accepts it and link is created after admissions approval
+//
+//easyjson:json
+type ParentInvitation struct {
+	ID                uint64 `json:"id"`
+	ChildID           uint64 `json:"-"`
+	ChildUserID       uint64 `json:"child_user_id"`
+	ChildFirstName    string `json:"child_first_name"`
+	ChildSecondName   string `json:"child_second_name"`
+	InviterID         uint64 `json:"-"`
+	InviterUserID     uint64 `json:"inviter_user_id"`
+	InviterFirstName  string `json:"inviter_first_name"`
+	InviterSecondName string `json:"inviter_second_name"`
+	InviteeID         uint64 `json:"-"`
+	InviteeUserID     uint64 `json:"invitee_user_id,omitempty"`
+	Email             string `json:"email"`
+	Relationship      string `json:"relationship"`
+	Status            string `json:"status"`
+	CreateTime        uint64 `json:"create_time"`
+}
+
+//easyjson:json
+type ParentInvitationList []ParentInvitation
+
+//easyjson:json
+type ParentInviteReq struct {
+	ChildID      uint64 `json:"child_id"`
+	Email        string `json:"email"`
+	Relationship string `json:"relationship"`
+}
+
+// TransferChildReq moves child from one parent account to another by users ids
+//
+//easyjson:json
+type TransferChildReq struct {
+	ChildID      uint64 `json:"child_id"`
+	FromParentID uint64 `json:"from_parent_id"`
+	ToParentID   uint64 `json:"to_parent_id"`
+	Relationship string `json:"relationship"`
+}
+
+// NotificationSettings keeps which emails about requests parent wants to get
+//
+//easyjson:json
+type NotificationSettings struct {
+	ReqApproved   bool `json:"req_approved"`
+	ReqFailed     bool `json:"req_failed"`
+	StageUnlocked bool `json:"stage_unlocked"`
+}
+
+type NotificationRecipient struct {
+	User     User
+	Settings NotificationSettings
+}
+
+type Permission string
+
+// ViewPassportsPermission shows unmasked passports in exports
+const ViewPassportsPermission Permission = "view_passports"
+
+var knownPermissions = map[Permission]bool{
+	ViewPassportsPermission: true,
+}
+
+func (p Permission) IsKnown() bool {
+	return knownPermissions[p]
+}
+
+type UserPermission struct {
+	UserID     uint64
+	Permission Permission
+	GrantedBy  uint64
+	CreateTime uint64
+}
+
+//easyjson:json
+type PermissionReq struct {
+	UserID     uint64 `json:"user_id"`
+	Permission string `json:"permission"`
+}
+
+//easyjson:json
+type UserPermissionResp struct {
+	Permission string `json:"permission"`
+	GrantedBy  uint64 `json:"granted_by,omitempty"`
+	CreateTime uint64 `json:"create_time"`
+}
+
+//easyjson:json
+type UserPermissionRespList []UserPermissionResp
+
+type ParentWithRelationship struct {
+	Parent       Parent
+	Relationship string
+}
+
+// ChildWithParents keeps child with all linked parents for exports
+type ChildWithParents struct {
+	Child      Child
+	CreateTime uint64
+	Parents    []ParentWithRelationship
+}
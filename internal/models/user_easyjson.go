@@ -118,6 +118,8 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels1(in *jlex
 			out.EmailVerified = bool(in.Bool())
 		case "phone":
 			out.Phone = string(in.String())
+		case "deactivated":
+			out.Deactivated = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -172,33 +174,1267 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels1(out *jwr
 		out.RawString(prefix)
 		out.String(string(in.Phone))
 	}
+	{
+		const prefix string = ",\"deactivated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Deactivated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserRes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserRes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserRes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels1(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *UserPermissionRespList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(UserPermissionRespList, 0, 2)
+			} else {
+				*out = UserPermissionRespList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 UserPermissionResp
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in UserPermissionRespList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserPermissionRespList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserPermissionRespList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserPermissionRespList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserPermissionRespList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *UserPermissionResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "permission":
+			out.Permission = string(in.String())
+		case "granted_by":
+			out.GrantedBy = uint64(in.Uint64())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in UserPermissionResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"permission\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Permission))
+	}
+	if in.GrantedBy != 0 {
+		const prefix string = ",\"granted_by\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.GrantedBy))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserPermissionResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserPermissionResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserPermissionResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserPermissionResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlexer.Lexer, out *UserLinkResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "last_name":
+			out.LastName = string(in.String())
+		case "relationship":
+			out.Relationship = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwriter.Writer, in UserLinkResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.LastName))
+	}
+	if in.Relationship != "" {
+		const prefix string = ",\"relationship\":"
+		out.RawString(prefix)
+		out.String(string(in.Relationship))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserLinkResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserLinkResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserLinkResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserLinkResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlexer.Lexer, out *UserDirectoryResp) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "total":
+			out.Total = uint64(in.Uint64())
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]DirectoryUserResp, 0, 0)
+					} else {
+						out.Users = []DirectoryUserResp{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 DirectoryUserResp
+					(v7).UnmarshalEasyJSON(in)
+					out.Users = append(out.Users, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(out *jwriter.Writer, in UserDirectoryResp) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Total))
+	}
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix)
+		if in.Users == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Users {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserDirectoryResp) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserDirectoryResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UserDirectoryResp) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UserDirectoryResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlexer.Lexer, out *User) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "role":
+			out.Role = Role(in.Int8())
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "last_name":
+			out.LastName = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "email_verified":
+			out.EmailVerified = bool(in.Bool())
+		case "password":
+			out.Password = string(in.String())
+		case "phone":
+			out.Phone = string(in.String())
+		case "deactivated":
+			out.Deactivated = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(out *jwriter.Writer, in User) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.Int8(int8(in.Role))
+	}
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.LastName))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"email_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.EmailVerified))
+	}
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	{
+		const prefix string = ",\"phone\":"
+		out.RawString(prefix)
+		out.String(string(in.Phone))
+	}
+	{
+		const prefix string = ",\"deactivated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Deactivated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v User) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v User) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *User) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlexer.Lexer, out *TransferChildReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "child_id":
+			out.ChildID = uint64(in.Uint64())
+		case "from_parent_id":
+			out.FromParentID = uint64(in.Uint64())
+		case "to_parent_id":
+			out.ToParentID = uint64(in.Uint64())
+		case "relationship":
+			out.Relationship = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(out *jwriter.Writer, in TransferChildReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"child_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ChildID))
+	}
+	{
+		const prefix string = ",\"from_parent_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.FromParentID))
+	}
+	{
+		const prefix string = ",\"to_parent_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ToParentID))
+	}
+	{
+		const prefix string = ",\"relationship\":"
+		out.RawString(prefix)
+		out.String(string(in.Relationship))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferChildReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferChildReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferChildReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferChildReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlexer.Lexer, out *PermissionReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
+		case "permission":
+			out.Permission = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(out *jwriter.Writer, in PermissionReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.UserID))
+	}
+	{
+		const prefix string = ",\"permission\":"
+		out.RawString(prefix)
+		out.String(string(in.Permission))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PermissionReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PermissionReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PermissionReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PermissionReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlexer.Lexer, out *PasswordResetReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		case "password":
+			out.Password = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(out *jwriter.Writer, in PasswordResetReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix)
+		out.String(string(in.Password))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PasswordResetReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PasswordResetReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PasswordResetReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PasswordResetReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(in *jlexer.Lexer, out *ParentRes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "passport":
+			out.Passport = string(in.String())
+		case "passport_verified":
+			out.PassportVerified = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(out *jwriter.Writer, in ParentRes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"passport\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Passport))
+	}
+	{
+		const prefix string = ",\"passport_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassportVerified))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentRes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentRes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentRes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels11(in *jlexer.Lexer, out *ParentProfileUpdateRes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "passport_reverification":
+			out.PassportReverification = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels11(out *jwriter.Writer, in ParentProfileUpdateRes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix[1:])
+		(in.User).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"passport_reverification\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassportReverification))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentProfileUpdateRes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentProfileUpdateRes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentProfileUpdateRes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentProfileUpdateRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels11(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels12(in *jlexer.Lexer, out *ParentProfileUpdate) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "last_name":
+			out.LastName = string(in.String())
+		case "phone":
+			out.Phone = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels12(out *jwriter.Writer, in ParentProfileUpdate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.LastName))
+	}
+	{
+		const prefix string = ",\"phone\":"
+		out.RawString(prefix)
+		out.String(string(in.Phone))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentProfileUpdate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentProfileUpdate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentProfileUpdate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentProfileUpdate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels12(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels13(in *jlexer.Lexer, out *ParentInviteReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "child_id":
+			out.ChildID = uint64(in.Uint64())
+		case "email":
+			out.Email = string(in.String())
+		case "relationship":
+			out.Relationship = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels13(out *jwriter.Writer, in ParentInviteReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"child_id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ChildID))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"relationship\":"
+		out.RawString(prefix)
+		out.String(string(in.Relationship))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentInviteReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentInviteReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentInviteReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentInviteReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels13(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels14(in *jlexer.Lexer, out *ParentInvitationList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ParentInvitationList, 0, 0)
+			} else {
+				*out = ParentInvitationList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v10 ParentInvitation
+			(v10).UnmarshalEasyJSON(in)
+			*out = append(*out, v10)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels14(out *jwriter.Writer, in ParentInvitationList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v11, v12 := range in {
+			if v11 > 0 {
+				out.RawByte(',')
+			}
+			(v12).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentInvitationList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentInvitationList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentInvitationList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentInvitationList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels14(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels15(in *jlexer.Lexer, out *ParentInvitation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = uint64(in.Uint64())
+		case "child_user_id":
+			out.ChildUserID = uint64(in.Uint64())
+		case "child_first_name":
+			out.ChildFirstName = string(in.String())
+		case "child_second_name":
+			out.ChildSecondName = string(in.String())
+		case "inviter_user_id":
+			out.InviterUserID = uint64(in.Uint64())
+		case "inviter_first_name":
+			out.InviterFirstName = string(in.String())
+		case "inviter_second_name":
+			out.InviterSecondName = string(in.String())
+		case "invitee_user_id":
+			out.InviteeUserID = uint64(in.Uint64())
+		case "email":
+			out.Email = string(in.String())
+		case "relationship":
+			out.Relationship = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels15(out *jwriter.Writer, in ParentInvitation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.ID))
+	}
+	{
+		const prefix string = ",\"child_user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.ChildUserID))
+	}
+	{
+		const prefix string = ",\"child_first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.ChildFirstName))
+	}
+	{
+		const prefix string = ",\"child_second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.ChildSecondName))
+	}
+	{
+		const prefix string = ",\"inviter_user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.InviterUserID))
+	}
+	{
+		const prefix string = ",\"inviter_first_name\":"
+		out.RawString(prefix)
+		out.String(string(in.InviterFirstName))
+	}
+	{
+		const prefix string = ",\"inviter_second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.InviterSecondName))
+	}
+	if in.InviteeUserID != 0 {
+		const prefix string = ",\"invitee_user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.InviteeUserID))
+	}
+	{
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"relationship\":"
+		out.RawString(prefix)
+		out.String(string(in.Relationship))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v UserRes) MarshalJSON() ([]byte, error) {
+func (v ParentInvitation) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels1(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserRes) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels1(w, v)
+func (v ParentInvitation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *UserRes) UnmarshalJSON(data []byte) error {
+func (v *ParentInvitation) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels1(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *UserRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels1(l, v)
+func (v *ParentInvitation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels15(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlexer.Lexer, out *User) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels16(in *jlexer.Lexer, out *Parent) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -219,6 +1455,8 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 		switch key {
 		case "id":
 			out.ID = uint64(in.Uint64())
+		case "user_id":
+			out.UserID = uint64(in.Uint64())
 		case "role":
 			out.Role = Role(in.Int8())
 		case "first_name":
@@ -235,6 +1473,12 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 			out.Password = string(in.String())
 		case "phone":
 			out.Phone = string(in.String())
+		case "passport":
+			out.Passport = string(in.String())
+		case "passport_verified":
+			out.PassportVerified = bool(in.Bool())
+		case "dir_path":
+			out.DirPath = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -245,7 +1489,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwriter.Writer, in User) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels16(out *jwriter.Writer, in Parent) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -254,6 +1498,11 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwr
 		out.RawString(prefix[1:])
 		out.Uint64(uint64(in.ID))
 	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.UserID))
+	}
 	{
 		const prefix string = ",\"role\":"
 		out.RawString(prefix)
@@ -284,7 +1533,7 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwr
 		out.RawString(prefix)
 		out.Bool(bool(in.EmailVerified))
 	}
-	{
+	if in.Password != "" {
 		const prefix string = ",\"password\":"
 		out.RawString(prefix)
 		out.String(string(in.Password))
@@ -294,33 +1543,48 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(out *jwr
 		out.RawString(prefix)
 		out.String(string(in.Phone))
 	}
+	{
+		const prefix string = ",\"passport\":"
+		out.RawString(prefix)
+		out.String(string(in.Passport))
+	}
+	{
+		const prefix string = ",\"passport_verified\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.PassportVerified))
+	}
+	{
+		const prefix string = ",\"dir_path\":"
+		out.RawString(prefix)
+		out.String(string(in.DirPath))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v User) MarshalJSON() ([]byte, error) {
+func (v Parent) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v User) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels2(w, v)
+func (v Parent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *User) UnmarshalJSON(data []byte) error {
+func (v *Parent) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels2(l, v)
+func (v *Parent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels16(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlexer.Lexer, out *ParentRes) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels17(in *jlexer.Lexer, out *NotificationSettings) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -339,10 +1603,12 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlex
 			continue
 		}
 		switch key {
-		case "passport":
-			out.Passport = string(in.String())
-		case "passport_verified":
-			out.PassportVerified = bool(in.Bool())
+		case "req_approved":
+			out.ReqApproved = bool(in.Bool())
+		case "req_failed":
+			out.ReqFailed = bool(in.Bool())
+		case "stage_unlocked":
+			out.StageUnlocked = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -353,47 +1619,139 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels3(out *jwriter.Writer, in ParentRes) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels17(out *jwriter.Writer, in NotificationSettings) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"passport\":"
+		const prefix string = ",\"req_approved\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Passport))
+		out.Bool(bool(in.ReqApproved))
 	}
 	{
-		const prefix string = ",\"passport_verified\":"
+		const prefix string = ",\"req_failed\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.PassportVerified))
+		out.Bool(bool(in.ReqFailed))
+	}
+	{
+		const prefix string = ",\"stage_unlocked\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.StageUnlocked))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v ParentRes) MarshalJSON() ([]byte, error) {
+func (v NotificationSettings) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels3(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v ParentRes) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels3(w, v)
+func (v NotificationSettings) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *ParentRes) UnmarshalJSON(data []byte) error {
+func (v *NotificationSettings) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *ParentRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels3(l, v)
+func (v *NotificationSettings) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels17(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels18(in *jlexer.Lexer, out *ManagerUpdateReq) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "last_name":
+			out.LastName = string(in.String())
+		case "phone":
+			out.Phone = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels18(out *jwriter.Writer, in ManagerUpdateReq) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.LastName))
+	}
+	{
+		const prefix string = ",\"phone\":"
+		out.RawString(prefix)
+		out.String(string(in.Phone))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ManagerUpdateReq) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ManagerUpdateReq) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels18(w, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlexer.Lexer, out *Parent) {
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ManagerUpdateReq) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ManagerUpdateReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels18(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels19(in *jlexer.Lexer, out *DirectoryUserResp) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -414,10 +1772,8 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlex
 		switch key {
 		case "id":
 			out.ID = uint64(in.Uint64())
-		case "user_id":
-			out.UserID = uint64(in.Uint64())
 		case "role":
-			out.Role = Role(in.Int8())
+			out.Role = string(in.String())
 		case "first_name":
 			out.FirstName = string(in.String())
 		case "second_name":
@@ -428,16 +1784,78 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlex
 			out.Email = string(in.String())
 		case "email_verified":
 			out.EmailVerified = bool(in.Bool())
-		case "password":
-			out.Password = string(in.String())
 		case "phone":
 			out.Phone = string(in.String())
-		case "passport":
-			out.Passport = string(in.String())
+		case "deactivated":
+			out.Deactivated = bool(in.Bool())
+		case "create_time":
+			out.CreateTime = uint64(in.Uint64())
 		case "passport_verified":
-			out.PassportVerified = bool(in.Bool())
-		case "dir_path":
-			out.DirPath = string(in.String())
+			if in.IsNull() {
+				in.Skip()
+				out.PassportVerified = nil
+			} else {
+				if out.PassportVerified == nil {
+					out.PassportVerified = new(bool)
+				}
+				*out.PassportVerified = bool(in.Bool())
+			}
+		case "done_stage":
+			if in.IsNull() {
+				in.Skip()
+				out.DoneStage = nil
+			} else {
+				if out.DoneStage == nil {
+					out.DoneStage = new(Stage)
+				}
+				*out.DoneStage = Stage(in.Int8())
+			}
+		case "parents":
+			if in.IsNull() {
+				in.Skip()
+				out.Parents = nil
+			} else {
+				in.Delim('[')
+				if out.Parents == nil {
+					if !in.IsDelim(']') {
+						out.Parents = make([]UserLinkResp, 0, 0)
+					} else {
+						out.Parents = []UserLinkResp{}
+					}
+				} else {
+					out.Parents = (out.Parents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 UserLinkResp
+					(v13).UnmarshalEasyJSON(in)
+					out.Parents = append(out.Parents, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "children":
+			if in.IsNull() {
+				in.Skip()
+				out.Children = nil
+			} else {
+				in.Delim('[')
+				if out.Children == nil {
+					if !in.IsDelim(']') {
+						out.Children = make([]UserLinkResp, 0, 0)
+					} else {
+						out.Children = []UserLinkResp{}
+					}
+				} else {
+					out.Children = (out.Children)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 UserLinkResp
+					(v14).UnmarshalEasyJSON(in)
+					out.Children = append(out.Children, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -448,7 +1866,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwriter.Writer, in Parent) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels19(out *jwriter.Writer, in DirectoryUserResp) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -457,15 +1875,10 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwr
 		out.RawString(prefix[1:])
 		out.Uint64(uint64(in.ID))
 	}
-	{
-		const prefix string = ",\"user_id\":"
-		out.RawString(prefix)
-		out.Uint64(uint64(in.UserID))
-	}
 	{
 		const prefix string = ",\"role\":"
 		out.RawString(prefix)
-		out.Int8(int8(in.Role))
+		out.String(string(in.Role))
 	}
 	{
 		const prefix string = ",\"first_name\":"
@@ -492,58 +1905,86 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(out *jwr
 		out.RawString(prefix)
 		out.Bool(bool(in.EmailVerified))
 	}
-	if in.Password != "" {
-		const prefix string = ",\"password\":"
-		out.RawString(prefix)
-		out.String(string(in.Password))
-	}
 	{
 		const prefix string = ",\"phone\":"
 		out.RawString(prefix)
 		out.String(string(in.Phone))
 	}
 	{
-		const prefix string = ",\"passport\":"
+		const prefix string = ",\"deactivated\":"
 		out.RawString(prefix)
-		out.String(string(in.Passport))
+		out.Bool(bool(in.Deactivated))
 	}
 	{
+		const prefix string = ",\"create_time\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.CreateTime))
+	}
+	if in.PassportVerified != nil {
 		const prefix string = ",\"passport_verified\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.PassportVerified))
+		out.Bool(bool(*in.PassportVerified))
 	}
-	{
-		const prefix string = ",\"dir_path\":"
+	if in.DoneStage != nil {
+		const prefix string = ",\"done_stage\":"
 		out.RawString(prefix)
-		out.String(string(in.DirPath))
+		out.Int8(int8(*in.DoneStage))
+	}
+	if len(in.Parents) != 0 {
+		const prefix string = ",\"parents\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v15, v16 := range in.Parents {
+				if v15 > 0 {
+					out.RawByte(',')
+				}
+				(v16).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Children) != 0 {
+		const prefix string = ",\"children\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.Children {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Parent) MarshalJSON() ([]byte, error) {
+func (v DirectoryUserResp) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Parent) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels4(w, v)
+func (v DirectoryUserResp) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Parent) UnmarshalJSON(data []byte) error {
+func (v *DirectoryUserResp) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Parent) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels4(l, v)
+func (v *DirectoryUserResp) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels19(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlexer.Lexer, out *Credentials) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels20(in *jlexer.Lexer, out *Credentials) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -576,7 +2017,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(out *jwriter.Writer, in Credentials) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels20(out *jwriter.Writer, in Credentials) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -596,27 +2037,27 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v Credentials) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Credentials) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels5(w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Credentials) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Credentials) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels5(l, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels20(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlexer.Lexer, out *ChildWithRegReqList) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels21(in *jlexer.Lexer, out *ChildWithRegReqList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -633,9 +2074,9 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlex
 			*out = (*out)[:0]
 		}
 		for !in.IsDelim(']') {
-			var v4 ChildWithRegReq
-			(v4).UnmarshalEasyJSON(in)
-			*out = append(*out, v4)
+			var v19 ChildWithRegReq
+			(v19).UnmarshalEasyJSON(in)
+			*out = append(*out, v19)
 			in.WantComma()
 		}
 		in.Delim(']')
@@ -644,16 +2085,16 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(out *jwriter.Writer, in ChildWithRegReqList) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels21(out *jwriter.Writer, in ChildWithRegReqList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
 		out.RawByte('[')
-		for v5, v6 := range in {
-			if v5 > 0 {
+		for v20, v21 := range in {
+			if v20 > 0 {
 				out.RawByte(',')
 			}
-			(v6).MarshalEasyJSON(out)
+			(v21).MarshalEasyJSON(out)
 		}
 		out.RawByte(']')
 	}
@@ -662,27 +2103,27 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v ChildWithRegReqList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChildWithRegReqList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels6(w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChildWithRegReqList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChildWithRegReqList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels6(l, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels21(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlexer.Lexer, out *ChildWithRegReq) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels22(in *jlexer.Lexer, out *ChildWithRegReq) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -723,7 +2164,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(out *jwriter.Writer, in ChildWithRegReq) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels22(out *jwriter.Writer, in ChildWithRegReq) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -743,27 +2184,27 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v ChildWithRegReq) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChildWithRegReq) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels7(w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChildWithRegReq) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChildWithRegReq) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels7(l, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels22(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlexer.Lexer, out *ChildRes) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels23(in *jlexer.Lexer, out *ChildRes) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -804,7 +2245,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(out *jwriter.Writer, in ChildRes) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels23(out *jwriter.Writer, in ChildRes) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -844,27 +2285,267 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v ChildRes) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels23(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChildRes) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels8(w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels23(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChildRes) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels23(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChildRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels8(l, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels23(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels24(in *jlexer.Lexer, out *ChildProfileUpdate) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "first_name":
+			out.FirstName = string(in.String())
+		case "second_name":
+			out.SecondName = string(in.String())
+		case "last_name":
+			out.LastName = string(in.String())
+		case "phone":
+			out.Phone = string(in.String())
+		case "place_of_residence":
+			out.PlaceOfResidence = string(in.String())
+		case "place_of_registration":
+			out.PlaceOfRegistration = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels24(out *jwriter.Writer, in ChildProfileUpdate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"first_name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.FirstName))
+	}
+	{
+		const prefix string = ",\"second_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SecondName))
+	}
+	{
+		const prefix string = ",\"last_name\":"
+		out.RawString(prefix)
+		out.String(string(in.LastName))
+	}
+	{
+		const prefix string = ",\"phone\":"
+		out.RawString(prefix)
+		out.String(string(in.Phone))
+	}
+	{
+		const prefix string = ",\"place_of_residence\":"
+		out.RawString(prefix)
+		out.String(string(in.PlaceOfResidence))
+	}
+	{
+		const prefix string = ",\"place_of_registration\":"
+		out.RawString(prefix)
+		out.String(string(in.PlaceOfRegistration))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChildProfileUpdate) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChildProfileUpdate) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChildProfileUpdate) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChildProfileUpdate) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels24(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels25(in *jlexer.Lexer, out *ChildParentResList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ChildParentResList, 0, 0)
+			} else {
+				*out = ChildParentResList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v22 ChildParentRes
+			(v22).UnmarshalEasyJSON(in)
+			*out = append(*out, v22)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels25(out *jwriter.Writer, in ChildParentResList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v23, v24 := range in {
+			if v23 > 0 {
+				out.RawByte(',')
+			}
+			(v24).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChildParentResList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChildParentResList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChildParentResList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChildParentResList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels25(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels26(in *jlexer.Lexer, out *ChildParentRes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "relationship":
+			out.Relationship = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels26(out *jwriter.Writer, in ChildParentRes) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix[1:])
+		(in.User).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"relationship\":"
+		out.RawString(prefix)
+		out.String(string(in.Relationship))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChildParentRes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChildParentRes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels26(w, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlexer.Lexer, out *ChildFullRes) {
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChildParentRes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChildParentRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels26(l, v)
+}
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels27(in *jlexer.Lexer, out *ChildFullRes) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -921,7 +2602,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(out *jwriter.Writer, in ChildFullRes) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels27(out *jwriter.Writer, in ChildFullRes) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1001,27 +2682,27 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v ChildFullRes) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels27(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChildFullRes) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels9(w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels27(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChildFullRes) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels27(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChildFullRes) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels9(l, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels27(l, v)
 }
-func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(in *jlexer.Lexer, out *Child) {
+func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels28(in *jlexer.Lexer, out *Child) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1082,7 +2763,7 @@ func easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(in *jle
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(out *jwriter.Writer, in Child) {
+func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels28(out *jwriter.Writer, in Child) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1172,23 +2853,23 @@ func easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v Child) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(&w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels28(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Child) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels10(w, v)
+	easyjson9e1087fdEncodeGithubComVoyakinHLokleBackendInternalModels28(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Child) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(&r, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels28(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Child) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels10(l, v)
+	easyjson9e1087fdDecodeGithubComVoyakinHLokleBackendInternalModels28(l, v)
 }
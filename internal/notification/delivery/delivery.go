@@ -0,0 +1,150 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/notification/usecase"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/middleware"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type NotificationDelivery struct {
+	notificationUseCase usecase.INotificationUsecase
+	logger              logrus.Logger
+}
+
+func SetNotificationRouting(router *mux.Router,
+	nu usecase.INotificationUsecase,
+	auth middleware.AuthMiddleware,
+	logger logrus.Logger) {
+	notificationDelivery := &NotificationDelivery{
+		notificationUseCase: nu,
+		logger:              logger,
+	}
+
+	notificationAPI := router.PathPrefix("/api/v1/notification").Subrouter()
+	notificationAPI.Use(middleware.WithJSON)
+	notificationAPI.Use(auth.WithAuth)
+
+	notificationAPI.HandleFunc("/list", notificationDelivery.GetNotifications).Methods(http.MethodGet)
+	notificationAPI.HandleFunc("/count", notificationDelivery.GetUnreadNotificationsCount).Methods(http.MethodGet)
+	notificationAPI.HandleFunc("/read", notificationDelivery.MarkNotificationRead).Methods(http.MethodPost)
+	notificationAPI.HandleFunc("/read/all", notificationDelivery.MarkAllNotificationsRead).Methods(http.MethodPost)
+}
+
+func (nd *NotificationDelivery) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		nd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var filter models.NotificationFilter
+	var err error
+	query := r.URL.Query()
+	if limit := query.Get("limit"); limit != "" {
+		filter.Limit, err = strconv.ParseUint(limit, 10, 64)
+		if err != nil {
+			nd.logger.Errorf("%s invalid limit parametr [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+	}
+	if offset := query.Get("offset"); offset != "" {
+		filter.Offset, err = strconv.ParseUint(offset, 10, 64)
+		if err != nil {
+			nd.logger.Errorf("%s invalid offset parametr [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+	}
+	if unread := query.Get("unread"); unread != "" {
+		filter.UnreadOnly, err = strconv.ParseBool(unread)
+		if err != nil {
+			nd.logger.Errorf("%s invalid unread parametr [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	notifications, status, err := nd.notificationUseCase.GetNotifications(ctx, user.ID, filter)
+	if err != nil || status != http.StatusOK {
+		nd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.NotificationsToRespList(notifications))
+}
+
+func (nd *NotificationDelivery) GetUnreadNotificationsCount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		nd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	count, status, err := nd.notificationUseCase.GetUnreadNotificationsCount(ctx, user.ID)
+	if err != nil || status != http.StatusOK {
+		nd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.NotificationCountResp{Unread: count})
+}
+
+func (nd *NotificationDelivery) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		nd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	notificationID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		nd.logger.Errorf("%s invalid notification id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := nd.notificationUseCase.MarkNotificationRead(ctx, user.ID, notificationID)
+	if err != nil || status != http.StatusOK {
+		nd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (nd *NotificationDelivery) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		nd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	status, err := nd.notificationUseCase.MarkAllNotificationsRead(ctx, user.ID)
+	if err != nil || status != http.StatusOK {
+		nd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
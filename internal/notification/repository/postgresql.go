@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+type IPostgresqlRepository interface {
+	CreateNotifications(context.Context, []uint64, models.Notification) error
+	CreateRoleNotifications(context.Context, models.Role, models.Notification) error
+	GetNotifications(context.Context, uint64, models.NotificationFilter) ([]models.Notification, error)
+	GetUnreadNotificationsCount(context.Context, uint64) (uint64, error)
+	MarkNotificationRead(context.Context, uint64, uint64) error
+	MarkAllNotificationsRead(context.Context, uint64) error
+}
+
+type postgresqlRepository struct {
+	conn   *pgx.ConnPool
+	logger logrus.Logger
+}
+
+func NewPostgresqlRepository(pool *pgx.ConnPool, logger logrus.Logger) IPostgresqlRepository {
+	return &postgresqlRepository{conn: pool, logger: logger}
+}
+
+// db returns transaction if method is called inside unit of work
+func (pr *postgresqlRepository) db(ctx context.Context) database.Querier {
+	return database.GetQuerier(ctx, pr.conn)
+}
+
+func (pr *postgresqlRepository) CreateNotifications(ctx context.Context, uids []uint64, notification models.Notification) error {
+	now := time.Now().Unix()
+	for _, uid := range uids {
+		_, err := pr.db(ctx).Exec(
+			`INSERT INTO notifications (user_id, type, title, body, req_id, create_time)
+			VALUES ($1, $2, $3, $4, $5, $6);`,
+			uid,
+			notification.Type,
+			notification.Title,
+			notification.Body,
+			notification.ReqID,
+			now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pr *postgresqlRepository) CreateRoleNotifications(ctx context.Context, role models.Role, notification models.Notification) error {
+	_, err := pr.db(ctx).Exec(
+		`INSERT INTO notifications (user_id, type, title, body, req_id, create_time)
+		SELECT id, $2, $3, $4, $5, $6
+		FROM users
+		WHERE role = $1 AND NOT deactivated;`,
+		role,
+		notification.Type,
+		notification.Title,
+		notification.Body,
+		notification.ReqID,
+		time.Now().Unix(),
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) GetNotifications(ctx context.Context, uid uint64, filter models.NotificationFilter) ([]models.Notification, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT id, user_id, type, title, body, req_id, read, create_time
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2::boolean OR NOT read)
+		ORDER BY create_time DESC, id DESC
+		LIMIT $3 OFFSET $4;`,
+		uid,
+		filter.UnreadOnly,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		return []models.Notification{}, err
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	var notification models.Notification
+	for rows.Next() {
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Body,
+			&notification.ReqID,
+			&notification.Read,
+			&notification.CreateTime,
+		)
+		if err != nil {
+			return []models.Notification{}, err
+		}
+		notifications = append(notifications, notification)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.Notification{}, err
+	}
+	return notifications, nil
+}
+
+func (pr *postgresqlRepository) GetUnreadNotificationsCount(ctx context.Context, uid uint64) (uint64, error) {
+	var count uint64
+	err := pr.db(ctx).QueryRow(
+		`SELECT COUNT(*)
+		FROM notifications
+		WHERE user_id = $1 AND NOT read;`,
+		uid,
+	).Scan(
+		&count,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (pr *postgresqlRepository) MarkNotificationRead(ctx context.Context, uid uint64, id uint64) error {
+	var updatedID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE notifications
+		SET read = true
+		WHERE id = $1 AND user_id = $2
+		RETURNING id;`,
+		id,
+		uid,
+	).Scan(
+		&updatedID,
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) MarkAllNotificationsRead(ctx context.Context, uid uint64) error {
+	_, err := pr.db(ctx).Exec(
+		`UPDATE notifications
+		SET read = true
+		WHERE user_id = $1 AND NOT read;`,
+		uid,
+	)
+	return err
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/notification/repository"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultNotificationsLimit = 20
+	MaxNotificationsLimit     = 100
+)
+
+type INotificationUsecase interface {
+	Notify(context.Context, []uint64, models.Notification) error
+	NotifyRole(context.Context, models.Role, models.Notification) error
+	GetNotifications(context.Context, uint64, models.NotificationFilter) ([]models.Notification, int, error)
+	GetUnreadNotificationsCount(context.Context, uint64) (uint64, int, error)
+	MarkNotificationRead(context.Context, uint64, uint64) (int, error)
+	MarkAllNotificationsRead(context.Context, uint64) (int, error)
+}
+
+type notificationUsecase struct {
+	psql   repository.IPostgresqlRepository
+	logger logrus.Logger
+}
+
+func NewNotificationUsecase(pr repository.IPostgresqlRepository, logger logrus.Logger) INotificationUsecase {
+	return &notificationUsecase{
+		psql:   pr,
+		logger: logger,
+	}
+}
+
+func (nu *notificationUsecase) Notify(ctx context.Context, uids []uint64, notification models.Notification) error {
+	if len(uids) == 0 {
+		return nil
+	}
+	err := nu.psql.CreateNotifications(ctx, uids, notification)
+	if err != nil {
+		return fmt.Errorf("NotificationUsecase.Notify: failed to create notifications with err: %s", err)
+	}
+	return nil
+}
+
+func (nu *notificationUsecase) NotifyRole(ctx context.Context, role models.Role, notification models.Notification) error {
+	err := nu.psql.CreateRoleNotifications(ctx, role, notification)
+	if err != nil {
+		return fmt.Errorf("NotificationUsecase.NotifyRole: failed to create notifications for role %s with err: %s", role.String(), err)
+	}
+	return nil
+}
+
+func (nu *notificationUsecase) GetNotifications(ctx context.Context, uid uint64, filter models.NotificationFilter) ([]models.Notification, int, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultNotificationsLimit
+	}
+	if filter.Limit > MaxNotificationsLimit {
+		return []models.Notification{}, http.StatusBadRequest, fmt.Errorf("NotificationUsecase.GetNotifications: limit %d is too big", filter.Limit)
+	}
+	notifications, err := nu.psql.GetNotifications(ctx, uid, filter)
+	if err != nil {
+		return []models.Notification{}, http.StatusInternalServerError, fmt.Errorf("NotificationUsecase.GetNotifications: failed to get notifications with err: %s", err)
+	}
+	return notifications, http.StatusOK, nil
+}
+
+func (nu *notificationUsecase) GetUnreadNotificationsCount(ctx context.Context, uid uint64) (uint64, int, error) {
+	count, err := nu.psql.GetUnreadNotificationsCount(ctx, uid)
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("NotificationUsecase.GetUnreadNotificationsCount: failed to count notifications with err: %s", err)
+	}
+	return count, http.StatusOK, nil
+}
+
+func (nu *notificationUsecase) MarkNotificationRead(ctx context.Context, uid uint64, id uint64) (int, error) {
+	err := nu.psql.MarkNotificationRead(ctx, uid, id)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("NotificationUsecase.MarkNotificationRead: notification not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("NotificationUsecase.MarkNotificationRead: failed to mark notification with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+func (nu *notificationUsecase) MarkAllNotificationsRead(ctx context.Context, uid uint64) (int, error) {
+	err := nu.psql.MarkAllNotificationsRead(ctx, uid)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("NotificationUsecase.MarkAllNotificationsRead: failed to mark notifications with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
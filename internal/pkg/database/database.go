@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+// Querier is implemented by both *pgx.ConnPool and *pgx.Tx
+type Querier interface {
+	Exec(sql string, arguments ...interface{}) (pgx.CommandTag, error)
+	Query(sql string, args ...interface{}) (*pgx.Rows, error)
+	QueryRow(sql string, args ...interface{}) *pgx.Row
+}
+
+// transaction is implemented by *pgx.Tx
+type transaction interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+type ctxKey int
+
+const ctxUnitOfWork ctxKey = iota
+
+type unitOfWorkState struct {
+	tx          transaction
+	afterCommit []func(context.Context)
+}
+
+func NewConnPool(cfg config.PostgresConfig, logger logrus.Logger) *pgx.ConnPool {
+	connStr := fmt.Sprintf("user=%s dbname=%s password=%s host=%s port=%s sslmode=disable",
+		cfg.User,
+		cfg.DBName,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port)
+
+	pgxConnectionConfig, err := pgx.ParseConnectionString(connStr)
+	if err != nil {
+		logger.Fatalf("Invalid config string: %s", err)
+	}
+
+	pool, err := pgx.NewConnPool(pgx.ConnPoolConfig{
+		ConnConfig:     pgxConnectionConfig,
+		MaxConnections: 100,
+		AfterConnect:   nil,
+		AcquireTimeout: 0,
+	})
+	if err != nil {
+		logger.Fatalf("Error %s occurred during connection to database", err)
+	}
+	return pool
+}
+
+// GetQuerier returns transaction of current unit of work or pool if there is no one
+func GetQuerier(ctx context.Context, pool *pgx.ConnPool) Querier {
+	state, ok := ctx.Value(ctxUnitOfWork).(*unitOfWorkState)
+	if ok {
+		return state.tx
+	}
+	return pool
+}
+
+// AfterCommit defers fn until current unit of work is committed.
+// fn is dropped on rollback and is called at once outside of unit of work
+func AfterCommit(ctx context.Context, fn func(context.Context)) {
+	state, ok := ctx.Value(ctxUnitOfWork).(*unitOfWorkState)
+	if ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn(ctx)
+}
+
+type IUnitOfWork interface {
+	Do(context.Context, func(context.Context) error) error
+}
+
+type unitOfWork struct {
+	begin  func(context.Context) (transaction, error)
+	logger logrus.Logger
+}
+
+func NewUnitOfWork(pool *pgx.ConnPool, logger logrus.Logger) IUnitOfWork {
+	return &unitOfWork{
+		begin: func(ctx context.Context) (transaction, error) {
+			tx, err := pool.BeginEx(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			return tx, nil
+		},
+		logger: logger,
+	}
+}
+
+// Do runs fn in single transaction shared by all repositories which get ctx passed to fn.
+// Nested calls join outer unit of work
+func (uow *unitOfWork) Do(ctx context.Context, fn func(context.Context) error) (err error) {
+	if _, ok := ctx.Value(ctxUnitOfWork).(*unitOfWorkState); ok {
+		return fn(ctx)
+	}
+
+	tx, err := uow.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("UnitOfWork.Do: failed to begin transaction with err: %s", err)
+	}
+	state := &unitOfWorkState{tx: tx}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, ctxUnitOfWork, state))
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			uow.logger.Errorf("UnitOfWork.Do: failed to rollback transaction with err: %s", rbErr)
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("UnitOfWork.Do: failed to commit transaction with err: %s", err)
+	}
+
+	for _, hook := range state.afterCommit {
+		hook(ctx)
+	}
+	return nil
+}
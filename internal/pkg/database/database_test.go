@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeTx struct {
+	commitErr  error
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Exec(sql string, arguments ...interface{}) (pgx.CommandTag, error) {
+	return "", nil
+}
+
+func (tx *fakeTx) Query(sql string, args ...interface{}) (*pgx.Rows, error) {
+	return nil, nil
+}
+
+func (tx *fakeTx) QueryRow(sql string, args ...interface{}) *pgx.Row {
+	return nil
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.committed = true
+	return tx.commitErr
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+func newTestUnitOfWork(tx *fakeTx, beginErr error, begins *int) *unitOfWork {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return &unitOfWork{
+		begin: func(context.Context) (transaction, error) {
+			*begins++
+			if beginErr != nil {
+				return nil, beginErr
+			}
+			return tx, nil
+		},
+		logger: *logger,
+	}
+}
+
+func TestGetQuerier(t *testing.T) {
+	var pool *pgx.ConnPool
+	if q := GetQuerier(context.Background(), pool); q != Querier(pool) {
+		t.Fatalf("expected pool outside of unit of work, got %T", q)
+	}
+
+	tx := &fakeTx{}
+	begins := 0
+	uow := newTestUnitOfWork(tx, nil, &begins)
+	err := uow.Do(context.Background(), func(ctx context.Context) error {
+		if q := GetQuerier(ctx, pool); q != Querier(tx) {
+			t.Errorf("expected transaction inside unit of work, got %T", q)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestAfterCommitOutsideUnitOfWork(t *testing.T) {
+	called := false
+	AfterCommit(context.Background(), func(context.Context) {
+		called = true
+	})
+	if !called {
+		t.Fatal("hook must be called at once outside of unit of work")
+	}
+}
+
+func TestUnitOfWorkDo(t *testing.T) {
+	fnErr := errors.New("fn failed")
+	tests := []struct {
+		name         string
+		beginErr     error
+		commitErr    error
+		fn           func(uow IUnitOfWork, calls *[]string) func(context.Context) error
+		wantErr      bool
+		wantBegins   int
+		wantCommit   bool
+		wantRollback bool
+		wantCalls    []string
+	}{
+		{
+			name: "hooks run in order after commit",
+			fn: func(uow IUnitOfWork, calls *[]string) func(context.Context) error {
+				return func(ctx context.Context) error {
+					AfterCommit(ctx, func(context.Context) { *calls = append(*calls, "first") })
+					AfterCommit(ctx, func(context.Context) { *calls = append(*calls, "second") })
+					*calls = append(*calls, "fn")
+					return nil
+				}
+			},
+			wantBegins: 1,
+			wantCommit: true,
+			wantCalls:  []string{"fn", "first", "second"},
+		},
+		{
+			name: "hooks are dropped on rollback",
+			fn: func(uow IUnitOfWork, calls *[]string) func(context.Context) error {
+				return func(ctx context.Context) error {
+					AfterCommit(ctx, func(context.Context) { *calls = append(*calls, "hook") })
+					return fnErr
+				}
+			},
+			wantErr:      true,
+			wantBegins:   1,
+			wantRollback: true,
+		},
+		{
+			name:      "hooks are dropped when commit fails",
+			commitErr: errors.New("commit failed"),
+			fn: func(uow IUnitOfWork, calls *[]string) func(context.Context) error {
+				return func(ctx context.Context) error {
+					AfterCommit(ctx, func(context.Context) { *calls = append(*calls, "hook") })
+					return nil
+				}
+			},
+			wantErr:    true,
+			wantBegins: 1,
+			wantCommit: true,
+		},
+		{
+			name:     "fn isn't called when begin fails",
+			beginErr: errors.New("begin failed"),
+			fn: func(uow IUnitOfWork, calls *[]string) func(context.Context) error {
+				return func(ctx context.Context) error {
+					*calls = append(*calls, "fn")
+					return nil
+				}
+			},
+			wantErr:    true,
+			wantBegins: 1,
+		},
+		{
+			name: "nested unit of work joins outer one",
+			fn: func(uow IUnitOfWork, calls *[]string) func(context.Context) error {
+				return func(ctx context.Context) error {
+					err := uow.Do(ctx, func(ctx context.Context) error {
+						AfterCommit(ctx, func(context.Context) { *calls = append(*calls, "inner hook") })
+						return nil
+					})
+					if err != nil {
+						return err
+					}
+					// inner hook must wait for outer commit
+					*calls = append(*calls, "outer fn")
+					return nil
+				}
+			},
+			wantBegins: 1,
+			wantCommit: true,
+			wantCalls:  []string{"outer fn", "inner hook"},
+		},
+		{
+			name: "nested error rolls back outer unit of work",
+			fn: func(uow IUnitOfWork, calls *[]string) func(context.Context) error {
+				return func(ctx context.Context) error {
+					AfterCommit(ctx, func(context.Context) { *calls = append(*calls, "outer hook") })
+					return uow.Do(ctx, func(ctx context.Context) error {
+						return fnErr
+					})
+				}
+			},
+			wantErr:      true,
+			wantBegins:   1,
+			wantRollback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &fakeTx{commitErr: tt.commitErr}
+			begins := 0
+			uow := newTestUnitOfWork(tx, tt.beginErr, &begins)
+
+			var calls []string
+			err := uow.Do(context.Background(), tt.fn(uow, &calls))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if begins != tt.wantBegins {
+				t.Errorf("expected %d transactions, got %d", tt.wantBegins, begins)
+			}
+			if tx.committed != tt.wantCommit {
+				t.Errorf("expected committed=%t, got %t", tt.wantCommit, tx.committed)
+			}
+			if tx.rolledBack != tt.wantRollback {
+				t.Errorf("expected rolled back=%t, got %t", tt.wantRollback, tx.rolledBack)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("expected calls %v, got %v", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestUnitOfWorkDoRollsBackOnPanic(t *testing.T) {
+	tx := &fakeTx{}
+	begins := 0
+	uow := newTestUnitOfWork(tx, nil, &begins)
+
+	defer func() {
+		if p := recover(); p == nil {
+			t.Fatal("panic must be propagated")
+		}
+		if !tx.rolledBack || tx.committed {
+			t.Errorf("expected rollback without commit, got committed=%t rolled back=%t", tx.committed, tx.rolledBack)
+		}
+	}()
+	_ = uow.Do(context.Background(), func(context.Context) error {
+		panic("fn panicked")
+	})
+}
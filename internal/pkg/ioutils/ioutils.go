@@ -37,11 +37,11 @@ func SendError(w http.ResponseWriter, respCode int, errorMsg string) {
 
 func SendDefaultError(w http.ResponseWriter, respCode int) {
 	Send(w, respCode, ModelError{
-		Message: resolveErrorToString(respCode),
+		Message: ResolveErrorToString(respCode),
 	})
 }
 
-func resolveErrorToString(respCode int) string {
+func ResolveErrorToString(respCode int) string {
 	switch respCode {
 	case http.StatusBadRequest:
 		return "bad request"
@@ -53,6 +53,8 @@ func resolveErrorToString(respCode int) string {
 		return "not found"
 	case http.StatusConflict:
 		return "conflict"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable entity"
 	case http.StatusInternalServerError:
 		return "internal"
 	default:
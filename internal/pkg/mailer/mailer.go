@@ -2,6 +2,7 @@ package mailer
 
 import (
 	"fmt"
+	"html"
 
 	"github.com/VoyakinH/lokle_backend/config"
 	"github.com/sirupsen/logrus"
@@ -47,3 +48,72 @@ func SendCompleteChildRegistrationEmail(to_email string, first_name string, seco
 	}
 	return nil
 }
+
+func sendMessage(msg *gomail.Message) error {
+	msg.SetHeader("From", config.Mailer.Email)
+	n := gomail.NewDialer("mail.s-kit.moscow", 25, config.Mailer.Email, config.Mailer.Password)
+
+	if err := n.DialAndSend(msg); err != nil {
+		logrus.Errorf("Mailer.sendMessage: failed send email via kit smpt server with err: %s", err)
+		msg.SetHeader("From", config.Mailer.AdditionalEmail)
+		n = gomail.NewDialer("smtp.mail.ru", 465, config.Mailer.AdditionalEmail, config.Mailer.AdditionalPassword)
+		if err := n.DialAndSend(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func SendRegReqApprovedEmail(to_email string, first_name string, second_name string, req_type string) error {
+	msg := gomail.NewMessage()
+	msg.SetHeader("To", to_email)
+	msg.SetHeader("Subject", "Заявка одобрена Столичный-КИТ")
+	msg.SetBody("text/html", fmt.Sprintf("Приветствуем, %s %s! <br/> Ваша заявка «%s» одобрена. <br/> Подробности доступны в личном кабинете: https://kit.lokle.ru <br/> Если Вы получили это письмо по ошибке, просто игнорируйте его. <br/>",
+		html.EscapeString(first_name), html.EscapeString(second_name), html.EscapeString(req_type)))
+	return sendMessage(msg)
+}
+
+func SendRegReqFailedEmail(to_email string, first_name string, second_name string, req_type string, message string) error {
+	msg := gomail.NewMessage()
+	msg.SetHeader("To", to_email)
+	msg.SetHeader("Subject", "Заявка отклонена Столичный-КИТ")
+	msg.SetBody("text/html", fmt.Sprintf("Приветствуем, %s %s! <br/> Ваша заявка «%s» отклонена. <br/> Комментарий менеджера: %s <br/> Исправить заявку можно в личном кабинете: https://kit.lokle.ru <br/> Если Вы получили это письмо по ошибке, просто игнорируйте его. <br/>",
+		html.EscapeString(first_name), html.EscapeString(second_name), html.EscapeString(req_type), html.EscapeString(message)))
+	return sendMessage(msg)
+}
+
+func SendStageUnlockedEmail(to_email string, first_name string, second_name string, child_name string, stage string) error {
+	msg := gomail.NewMessage()
+	msg.SetHeader("To", to_email)
+	msg.SetHeader("Subject", "Доступен следующий этап регистрации Столичный-КИТ")
+	msg.SetBody("text/html", fmt.Sprintf("Приветствуем, %s %s! <br/> Для ребенка %s доступен следующий этап: «%s». <br/> Продолжить регистрацию можно в личном кабинете: https://kit.lokle.ru <br/> Если Вы получили это письмо по ошибке, просто игнорируйте его. <br/>",
+		html.EscapeString(first_name), html.EscapeString(second_name), html.EscapeString(child_name), html.EscapeString(stage)))
+	return sendMessage(msg)
+}
+
+func SendParentInvitationEmail(to_email string, inviter_name string, child_name string) error {
+	msg := gomail.NewMessage()
+	msg.SetHeader("To", to_email)
+	msg.SetHeader("Subject", "Приглашение Столичный-КИТ")
+	msg.SetBody("text/html", fmt.Sprintf("Приветствуем! <br/> %s приглашает Вас стать законным представителем ребенка %s. <br/> Чтобы принять приглашение, зарегистрируйтесь или войдите в личный кабинет с этой почтой: https://kit.lokle.ru/login <br/> Если Вы получили это письмо по ошибке, просто игнорируйте его. <br/>",
+		html.EscapeString(inviter_name), html.EscapeString(child_name)))
+	return sendMessage(msg)
+}
+
+func SendPasswordResetEmail(to_email string, first_name string, second_name string, token string) error {
+	msg := gomail.NewMessage()
+	msg.SetHeader("To", to_email)
+	msg.SetHeader("Subject", "Сброс пароля Столичный-КИТ")
+	msg.SetBody("text/html", fmt.Sprintf("Приветствуем, %s %s! <br/> Для установки нового пароля пройдите, пожалуйста, по ссылке: <br/> https://kit.lokle.ru/login?password_reset_token=%s <br/> Если Вы получили это письмо по ошибке, просто игнорируйте его. <br/> Ссылка активна в течение 24 часов.",
+		html.EscapeString(first_name), html.EscapeString(second_name), token))
+	return sendMessage(msg)
+}
+
+func SendImportedParentEmail(to_email string, first_name string, second_name string, child_name string, password string) error {
+	msg := gomail.NewMessage()
+	msg.SetHeader("To", to_email)
+	msg.SetHeader("Subject", "Регистрация Столичный-КИТ")
+	msg.SetBody("text/html", fmt.Sprintf("Приветствуем, %s %s! <br/> Для Вас создан личный кабинет, в котором уже добавлен ребенок %s. <br/> Ваши данные для входа: <br/>  Логин: %s <br/> Пароль: %s <br/> Войти и заполнить недостающие данные можно по ссылке: https://kit.lokle.ru/login <br/> Если Вы получили это письмо по ошибке, просто игнорируйте его. <br/>",
+		html.EscapeString(first_name), html.EscapeString(second_name), html.EscapeString(child_name), html.EscapeString(to_email), password))
+	return sendMessage(msg)
+}
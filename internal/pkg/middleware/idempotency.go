@@ -0,0 +1,283 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/idempotency/repository"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLen      = 255
+	// requests and responses are buffered to fingerprint and replay them
+	maxIdempotentBodySize = 1 << 20 // 1MB
+	// multipart requests are spooled to temp file, limit is the same as for file upload
+	maxIdempotentMultipartSize = 120 << 20 // 120MB
+)
+
+type IdempotencyMiddleware struct {
+	repo    repository.IRedisIdempotencyRepository
+	ttl     time.Duration
+	lockTTL time.Duration
+	logger  logrus.Logger
+}
+
+func NewIdempotencyMiddleware(repo repository.IRedisIdempotencyRepository, cfg config.IdempotencyConfig, logger logrus.Logger) IdempotencyMiddleware {
+	return IdempotencyMiddleware{
+		repo:    repo,
+		ttl:     cfg.TTL,
+		lockTTL: cfg.LockTTL,
+		logger:  logger,
+	}
+}
+
+// responseRecorder writes response to client and keeps its copy for replays
+type responseRecorder struct {
+	http.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	rr.status = status
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.status == 0 {
+		rr.status = http.StatusOK
+	}
+	// too large response isn't kept, so it can't be replayed
+	if rr.body.Len()+len(b) <= maxIdempotentBodySize {
+		rr.body.Write(b)
+	} else {
+		rr.truncated = true
+	}
+	return rr.ResponseWriter.Write(b)
+}
+
+// WithIdempotency replays stored response for POST requests retried with the same Idempotency-Key.
+// Multipart requests are spooled to temp file instead of memory because of uploaded files.
+// Must be used after auth middleware because keys are scoped by user
+func (im IdempotencyMiddleware) WithIdempotency(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idemKey := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || idemKey == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if len(idemKey) > maxIdempotencyKeyLen {
+			im.logger.Errorf("%s idempotency key is too long [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+
+		ctx := r.Context()
+		user := ctx_utils.GetUser(ctx)
+		if user == nil {
+			im.logger.Errorf("%s failed get ctx user for idempotency key with [status=%d]", r.URL, http.StatusForbidden)
+			ioutils.SendDefaultError(w, http.StatusForbidden)
+			return
+		}
+
+		var fingerprint string
+		if boundary, ok := multipartBoundary(r); ok {
+			body, status, err := spoolBody(r.Body)
+			if err != nil {
+				im.logger.Errorf("%s failed to read multipart body with [status=%d] [error=%s]", r.URL, status, err)
+				ioutils.SendDefaultError(w, status)
+				return
+			}
+			defer func() {
+				body.Close()
+				os.Remove(body.Name())
+			}()
+
+			fingerprint, err = multipartFingerprint(r, body, boundary)
+			if err != nil {
+				im.logger.Errorf("%s failed to read multipart body with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+				ioutils.SendDefaultError(w, http.StatusBadRequest)
+				return
+			}
+			if _, err := body.Seek(0, io.SeekStart); err != nil {
+				im.logger.Errorf("%s failed to rewind multipart body with [status=%d] [error=%s]", r.URL, http.StatusInternalServerError, err)
+				ioutils.SendDefaultError(w, http.StatusInternalServerError)
+				return
+			}
+			r.Body = body
+		} else {
+			body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+			if err != nil {
+				im.logger.Errorf("%s failed to read body with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+				ioutils.SendDefaultError(w, http.StatusBadRequest)
+				return
+			}
+			if len(body) > maxIdempotentBodySize {
+				im.logger.Errorf("%s body is too large for idempotency key [status=%d]", r.URL, http.StatusRequestEntityTooLarge)
+				ioutils.SendError(w, http.StatusRequestEntityTooLarge, "request body is too large to be sent with idempotency key")
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			fingerprint = requestFingerprint(r, body)
+		}
+
+		key := fmt.Sprintf("idempotency:%d:%s:%s", user.ID, r.URL.Path, idemKey)
+
+		reserved, err := im.repo.Reserve(ctx, key, models.IdempotencyRecord{Fingerprint: fingerprint}, im.lockTTL)
+		if err != nil {
+			// redis is unavailable, so we serve request as usual
+			im.logger.Errorf("%s failed to reserve idempotency key with err: %s", r.URL, err)
+			h.ServeHTTP(w, r)
+			return
+		}
+		if !reserved {
+			im.replay(ctx, w, r, key, fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w}
+		h.ServeHTTP(recorder, r)
+
+		// server errors and too large responses are not stored so request can be retried with the same key
+		if recorder.status == 0 || recorder.status >= http.StatusInternalServerError || recorder.truncated {
+			err = im.repo.Delete(context.Background(), key)
+			if err != nil {
+				im.logger.Errorf("%s failed to release idempotency key with err: %s", r.URL, err)
+			}
+			return
+		}
+
+		err = im.repo.Save(context.Background(), key, models.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			StatusCode:  recorder.status,
+			ContentType: w.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}, im.ttl)
+		if err != nil {
+			im.logger.Errorf("%s failed to save idempotent response with err: %s", r.URL, err)
+		}
+	})
+}
+
+func (im IdempotencyMiddleware) replay(ctx context.Context, w http.ResponseWriter, r *http.Request, key string, fingerprint string) {
+	record, err := im.repo.Get(ctx, key)
+	if err == redis.Nil {
+		im.logger.Errorf("%s idempotency key has just expired [status=%d]", r.URL, http.StatusConflict)
+		ioutils.SendError(w, http.StatusConflict, "idempotency key has expired, retry request")
+		return
+	} else if err != nil {
+		im.logger.Errorf("%s failed to get idempotency key with [status=%d] [error=%s]", r.URL, http.StatusInternalServerError, err)
+		ioutils.SendDefaultError(w, http.StatusInternalServerError)
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		im.logger.Errorf("%s idempotency key reused with different payload [status=%d]", r.URL, http.StatusUnprocessableEntity)
+		ioutils.SendError(w, http.StatusUnprocessableEntity, "idempotency key has been already used with different payload")
+		return
+	}
+	if !record.Completed {
+		im.logger.Errorf("%s request with the same idempotency key is in progress [status=%d]", r.URL, http.StatusConflict)
+		ioutils.SendError(w, http.StatusConflict, "request with this idempotency key is in progress")
+		return
+	}
+
+	if record.ContentType != "" {
+		w.Header().Set("Content-Type", record.ContentType)
+	}
+	w.Header().Set(IdempotencyReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	_, _ = w.Write(record.Body)
+}
+
+func multipartBoundary(r *http.Request) (string, bool) {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return "", false
+	}
+	return params["boundary"], true
+}
+
+// spoolBody copies body to temp file which must be closed and removed by caller
+func spoolBody(body io.Reader) (*os.File, int, error) {
+	file, err := ioutil.TempFile("", "idempotency-*")
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	n, err := io.Copy(file, io.LimitReader(body, maxIdempotentMultipartSize+1))
+	if err == nil && n > maxIdempotentMultipartSize {
+		err = fmt.Errorf("body is larger than %d bytes", maxIdempotentMultipartSize)
+	}
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		if n > maxIdempotentMultipartSize {
+			return nil, http.StatusRequestEntityTooLarge, err
+		}
+		return nil, http.StatusBadRequest, err
+	}
+	return file, http.StatusOK, nil
+}
+
+func newRequestHash(r *http.Request) hash.Hash {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	return hash
+}
+
+// only hash of request is stored, so payload doesn't stay in redis
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := newRequestHash(r)
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// multipartFingerprint hashes parts instead of raw body because client
+// can generate new boundary on retry. Parts are read by stream, so files aren't kept in memory
+func multipartFingerprint(r *http.Request, body io.Reader, boundary string) (string, error) {
+	hash := newRequestHash(r)
+	reader := multipart.NewReader(body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%q %q %q\n", part.FormName(), part.FileName(), part.Header.Get("Content-Type"))
+		n, err := io.Copy(hash, part)
+		part.Close()
+		if err != nil {
+			return "", err
+		}
+		// size separates content of part from the next part
+		fmt.Fprintf(hash, "\n%d\n", n)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,263 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeIdempotencyRepository struct {
+	records map[string]models.IdempotencyRecord
+}
+
+func (fr *fakeIdempotencyRepository) Reserve(ctx context.Context, key string, record models.IdempotencyRecord, ttl time.Duration) (bool, error) {
+	if _, ok := fr.records[key]; ok {
+		return false, nil
+	}
+	fr.records[key] = record
+	return true, nil
+}
+
+func (fr *fakeIdempotencyRepository) Get(ctx context.Context, key string) (models.IdempotencyRecord, error) {
+	record, ok := fr.records[key]
+	if !ok {
+		return models.IdempotencyRecord{}, redis.Nil
+	}
+	return record, nil
+}
+
+func (fr *fakeIdempotencyRepository) Save(ctx context.Context, key string, record models.IdempotencyRecord, ttl time.Duration) error {
+	fr.records[key] = record
+	return nil
+}
+
+func (fr *fakeIdempotencyRepository) Delete(ctx context.Context, key string) error {
+	delete(fr.records, key)
+	return nil
+}
+
+type idempotentCall struct {
+	userID      uint64
+	key         string
+	contentType string
+	body        string
+}
+
+func newIdempotentRequest(call idempotentCall) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/req/child/stage", strings.NewReader(call.body))
+	if call.key != "" {
+		r.Header.Set(IdempotencyKeyHeader, call.key)
+	}
+	if call.contentType != "" {
+		r.Header.Set("Content-Type", call.contentType)
+	}
+	if call.userID != 0 {
+		r = r.WithContext(context.WithValue(r.Context(), ctx_utils.CtxUser, &models.User{ID: call.userID}))
+	}
+	return r
+}
+
+// uploadBody builds file upload form with given boundary
+func uploadBody(boundary string, content string) string {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	_ = writer.SetBoundary(boundary)
+	part, _ := writer.CreateFormFile("passport", "passport.pdf")
+	_, _ = part.Write([]byte(content))
+	_ = writer.Close()
+	return body.String()
+}
+
+func TestWithIdempotency(t *testing.T) {
+	tests := []struct {
+		name         string
+		calls        []idempotentCall
+		handlerCode  int
+		handlerBody  string
+		prepare      func(repo *fakeIdempotencyRepository)
+		wantCodes    []int
+		wantHandled  int
+		wantReplayed []bool
+	}{
+		{
+			name:         "request without key isn't stored",
+			calls:        []idempotentCall{{userID: 1, body: "{}"}, {userID: 1, body: "{}"}},
+			wantCodes:    []int{http.StatusOK, http.StatusOK},
+			wantHandled:  2,
+			wantReplayed: []bool{false, false},
+		},
+		{
+			name:         "retry with the same key and body is replayed",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: `{"a":1}`}, {userID: 1, key: "k", body: `{"a":1}`}},
+			wantCodes:    []int{http.StatusOK, http.StatusOK},
+			wantHandled:  1,
+			wantReplayed: []bool{false, true},
+		},
+		{
+			name:         "client errors are replayed",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: "{}"}, {userID: 1, key: "k", body: "{}"}},
+			handlerCode:  http.StatusConflict,
+			wantCodes:    []int{http.StatusConflict, http.StatusConflict},
+			wantHandled:  1,
+			wantReplayed: []bool{false, true},
+		},
+		{
+			name:         "key reused with different body",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: `{"a":1}`}, {userID: 1, key: "k", body: `{"a":2}`}},
+			wantCodes:    []int{http.StatusOK, http.StatusUnprocessableEntity},
+			wantHandled:  1,
+			wantReplayed: []bool{false, false},
+		},
+		{
+			name:         "keys are scoped by user",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: "{}"}, {userID: 2, key: "k", body: "{}"}},
+			wantCodes:    []int{http.StatusOK, http.StatusOK},
+			wantHandled:  2,
+			wantReplayed: []bool{false, false},
+		},
+		{
+			name:         "server errors aren't stored",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: "{}"}, {userID: 1, key: "k", body: "{}"}},
+			handlerCode:  http.StatusInternalServerError,
+			wantCodes:    []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			wantHandled:  2,
+			wantReplayed: []bool{false, false},
+		},
+		{
+			name:  "request in progress",
+			calls: []idempotentCall{{userID: 1, key: "k", body: "{}"}},
+			prepare: func(repo *fakeIdempotencyRepository) {
+				r := newIdempotentRequest(idempotentCall{body: "{}"})
+				repo.records["idempotency:1:/api/v1/req/child/stage:k"] = models.IdempotencyRecord{
+					Fingerprint: requestFingerprint(r, []byte("{}")),
+				}
+			},
+			wantCodes:    []int{http.StatusConflict},
+			wantHandled:  0,
+			wantReplayed: []bool{false},
+		},
+		{
+			name: "upload retried with new boundary is replayed",
+			calls: []idempotentCall{
+				{userID: 1, key: "k", contentType: "multipart/form-data; boundary=first", body: uploadBody("first", "scan")},
+				{userID: 1, key: "k", contentType: "multipart/form-data; boundary=second", body: uploadBody("second", "scan")},
+			},
+			wantCodes:    []int{http.StatusOK, http.StatusOK},
+			wantHandled:  1,
+			wantReplayed: []bool{false, true},
+		},
+		{
+			name: "upload of another file with the same key",
+			calls: []idempotentCall{
+				{userID: 1, key: "k", contentType: "multipart/form-data; boundary=first", body: uploadBody("first", "scan")},
+				{userID: 1, key: "k", contentType: "multipart/form-data; boundary=first", body: uploadBody("first", "other scan")},
+			},
+			wantCodes:    []int{http.StatusOK, http.StatusUnprocessableEntity},
+			wantHandled:  1,
+			wantReplayed: []bool{false, false},
+		},
+		{
+			name:         "broken multipart body",
+			calls:        []idempotentCall{{userID: 1, key: "k", contentType: "multipart/form-data; boundary=first", body: "--first\r\nbroken"}},
+			wantCodes:    []int{http.StatusBadRequest},
+			wantHandled:  0,
+			wantReplayed: []bool{false},
+		},
+		{
+			name:         "too large body",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: strings.Repeat("a", maxIdempotentBodySize+1)}},
+			wantCodes:    []int{http.StatusRequestEntityTooLarge},
+			wantHandled:  0,
+			wantReplayed: []bool{false},
+		},
+		{
+			name:         "too large response isn't stored",
+			calls:        []idempotentCall{{userID: 1, key: "k", body: "{}"}, {userID: 1, key: "k", body: "{}"}},
+			handlerBody:  strings.Repeat("a", maxIdempotentBodySize+1),
+			wantCodes:    []int{http.StatusOK, http.StatusOK},
+			wantHandled:  2,
+			wantReplayed: []bool{false, false},
+		},
+		{
+			name:         "too long key",
+			calls:        []idempotentCall{{userID: 1, key: strings.Repeat("k", maxIdempotencyKeyLen+1), body: "{}"}},
+			wantCodes:    []int{http.StatusBadRequest},
+			wantHandled:  0,
+			wantReplayed: []bool{false},
+		},
+		{
+			name:         "key without user",
+			calls:        []idempotentCall{{key: "k", body: "{}"}},
+			wantCodes:    []int{http.StatusForbidden},
+			wantHandled:  0,
+			wantReplayed: []bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeIdempotencyRepository{records: map[string]models.IdempotencyRecord{}}
+			if tt.prepare != nil {
+				tt.prepare(repo)
+			}
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			im := NewIdempotencyMiddleware(repo, config.IdempotencyConfig{TTL: time.Hour, LockTTL: time.Minute}, *logger)
+
+			handled := 0
+			handler := im.WithIdempotency(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handled++
+				// handler must get whole body after middleware has read it
+				if _, err := ioutil.ReadAll(r.Body); err != nil {
+					t.Errorf("failed to read body in handler: %s", err)
+				}
+				code := tt.handlerCode
+				if code == 0 {
+					code = http.StatusOK
+				}
+				body := tt.handlerBody
+				if body == "" {
+					body = `{"handled":true}`
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(body))
+			}))
+
+			var firstBody []byte
+			for i, call := range tt.calls {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, newIdempotentRequest(call))
+
+				if rec.Code != tt.wantCodes[i] {
+					t.Errorf("call %d: expected status %d, got %d", i, tt.wantCodes[i], rec.Code)
+				}
+				replayed := rec.Header().Get(IdempotencyReplayedHeader) == "true"
+				if replayed != tt.wantReplayed[i] {
+					t.Errorf("call %d: expected replayed=%t, got %t", i, tt.wantReplayed[i], replayed)
+				}
+				if i == 0 {
+					firstBody = rec.Body.Bytes()
+				} else if replayed && !bytes.Equal(rec.Body.Bytes(), firstBody) {
+					t.Errorf("call %d: replayed body %q differs from original %q", i, rec.Body.String(), firstBody)
+				}
+			}
+			if handled != tt.wantHandled {
+				t.Errorf("expected handler to be called %d times, got %d", tt.wantHandled, handled)
+			}
+		})
+	}
+}
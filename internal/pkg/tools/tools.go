@@ -1,6 +1,39 @@
 package tools
 
-import "github.com/VoyakinH/lokle_backend/internal/models"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+)
+
+// phone limits are taken from users table
+const (
+	maxPhoneLen = 16
+	minPhoneLen = 10
+)
+
+// ValidatePhone checks that phone has only digits with optional leading plus
+func ValidatePhone(phone string) bool {
+	if len(phone) < minPhoneLen || len(phone) > maxPhoneLen {
+		return false
+	}
+	for i, r := range phone {
+		if !unicode.IsDigit(r) && !(r == '+' && i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// FullName joins name parts skipping empty ones
+func FullName(lastName string, firstName string, secondName string) string {
+	return strings.Join(strings.Fields(lastName+" "+firstName+" "+secondName), " ")
+}
 
 func UserToUserRes(user models.User) models.UserRes {
 	return models.UserRes{
@@ -12,6 +45,7 @@ func UserToUserRes(user models.User) models.UserRes {
 		Email:         user.Email,
 		EmailVerified: user.EmailVerified,
 		Phone:         user.Phone,
+		Deactivated:   user.Deactivated,
 	}
 }
 
@@ -23,6 +57,54 @@ func UsersToUserResList(users []models.User) models.UserResList {
 	return resp
 }
 
+func userLinksToResp(links []models.UserLink) []models.UserLinkResp {
+	var resp []models.UserLinkResp
+	for _, link := range links {
+		resp = append(resp, models.UserLinkResp{
+			ID:           link.UserID,
+			FirstName:    link.FirstName,
+			SecondName:   link.SecondName,
+			LastName:     link.LastName,
+			Relationship: link.Relationship,
+		})
+	}
+	return resp
+}
+
+func UserDirectoryToResp(directory models.UserDirectory) models.UserDirectoryResp {
+	resp := models.UserDirectoryResp{
+		Total: directory.Total,
+		Users: []models.DirectoryUserResp{},
+	}
+	for _, user := range directory.Users {
+		userResp := models.DirectoryUserResp{
+			ID:            user.ID,
+			Role:          user.Role.String(),
+			FirstName:     user.FirstName,
+			SecondName:    user.SecondName,
+			LastName:      user.LastName,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerified,
+			Phone:         user.Phone,
+			Deactivated:   user.Deactivated,
+			CreateTime:    user.CreateTime,
+			Parents:       userLinksToResp(user.Parents),
+			Children:      userLinksToResp(user.Children),
+		}
+		// role specific fields are shown only for their roles
+		switch user.Role {
+		case models.ParentRole:
+			passportVerified := user.PassportVerified
+			userResp.PassportVerified = &passportVerified
+		case models.ChildRole:
+			doneStage := user.DoneStage
+			userResp.DoneStage = &doneStage
+		}
+		resp.Users = append(resp.Users, userResp)
+	}
+	return resp
+}
+
 func ParentToParentRes(parent models.Parent) models.ParentRes {
 	return models.ParentRes{
 		Passport:         parent.Passport,
@@ -60,6 +142,17 @@ func ChildToChildRes(child models.Child) models.ChildRes {
 	}
 }
 
+func ChildParentsToResList(parents []models.ChildParent) models.ChildParentResList {
+	resp := models.ChildParentResList{}
+	for _, parent := range parents {
+		resp = append(resp, models.ChildParentRes{
+			User:         UserToUserRes(parent.User),
+			Relationship: parent.Relationship,
+		})
+	}
+	return resp
+}
+
 func ChildToUser(child models.Child) models.User {
 	return models.User{
 		ID:            child.ID,
@@ -76,12 +169,14 @@ func ChildToUser(child models.Child) models.User {
 
 func FullRegReqToSimpleResp(req models.RegReqFull) models.RegReqResp {
 	return models.RegReqResp{
-		ID:         req.ID,
-		UserID:     req.UserID,
-		Type:       req.Type.String(),
-		Status:     req.Status,
-		CreateTime: req.CreateTime,
-		Message:    req.Message,
+		ID:             req.ID,
+		UserID:         req.UserID,
+		Type:           req.Type.String(),
+		Status:         req.Status,
+		CreateTime:     req.CreateTime,
+		Message:        req.Message,
+		Issues:         req.Issues,
+		UnreadMessages: req.UnreadMessages,
 	}
 }
 
@@ -89,12 +184,14 @@ func FullRegReqToSimpleRespList(reqs []models.RegReqFull) models.RegReqRespList
 	var respList models.RegReqRespList
 	for _, req := range reqs {
 		respList = append(respList, models.RegReqResp{
-			ID:         req.ID,
-			UserID:     req.UserID,
-			Type:       req.Type.String(),
-			Status:     req.Status,
-			CreateTime: req.CreateTime,
-			Message:    req.Message,
+			ID:             req.ID,
+			UserID:         req.UserID,
+			Type:           req.Type.String(),
+			Status:         req.Status,
+			CreateTime:     req.CreateTime,
+			Message:        req.Message,
+			Issues:         req.Issues,
+			UnreadMessages: req.UnreadMessages,
 		})
 	}
 	return respList
@@ -112,15 +209,239 @@ func RegReqsWithUserToRespList(reqs []models.RegReqWithUser) models.RegReqWithUs
 			tempManager = nil
 		}
 		respList = append(respList, models.RegReqWithUserResp{
-			ID:          req.ID,
-			User:        UserToUserRes(req.User),
-			Manager:     tempManager,
-			Type:        req.Type.String(),
-			Status:      req.Status,
-			CreateTime:  req.CreateTime,
-			TimeInQueue: req.TimeInQueue,
-			Message:     req.Message,
+			ID:                 req.ID,
+			User:               UserToUserRes(req.User),
+			Manager:            tempManager,
+			Type:               req.Type.String(),
+			Status:             req.Status,
+			CreateTime:         req.CreateTime,
+			TimeInQueue:        req.TimeInQueue,
+			Message:            req.Message,
+			EscalationReason:   req.EscalationReason,
+			UnreadMessages:     req.UnreadMessages,
+			PossibleDuplicates: req.PossibleDuplicates,
+		})
+	}
+	return respList
+}
+
+func RegReqHistoryToRespList(history []models.RegReqHistory) models.RegReqHistoryRespList {
+	resp := models.RegReqHistoryRespList{}
+	for _, record := range history {
+		resp = append(resp, models.RegReqHistoryResp{
+			ReqID:         record.ReqID,
+			Type:          record.Type.String(),
+			Action:        record.Action,
+			Message:       record.Message,
+			ReqCreateTime: record.ReqCreateTime,
+			CreateTime:    record.CreateTime,
+		})
+	}
+	return resp
+}
+
+func RegReqStatsToResp(stats models.RegReqStats) models.RegReqStatsResp {
+	resp := models.RegReqStatsResp{
+		From:                 stats.From,
+		To:                   stats.To,
+		Pending:              []models.PendingRegReqStatResp{},
+		MedianProcessingTime: stats.MedianProcessingTime,
+		P90ProcessingTime:    stats.P90ProcessingTime,
+		Decisions:            []models.ManagerDecisionsStatResp{},
+		MultiFixShare:        stats.MultiFixShare,
+	}
+	for _, pending := range stats.Pending {
+		resp.Pending = append(resp.Pending, models.PendingRegReqStatResp{
+			Type:  pending.Type.String(),
+			Count: pending.Count,
+		})
+	}
+	for _, decisions := range stats.Decisions {
+		resp.Decisions = append(resp.Decisions, models.ManagerDecisionsStatResp{
+			Manager:  UserToUserRes(decisions.Manager),
+			Day:      decisions.Day,
+			Approved: decisions.Approved,
+			Failed:   decisions.Failed,
+		})
+	}
+	return resp
+}
+
+func BatchResultsToResp(results []models.BatchItemResult) models.BatchResultResp {
+	resp := models.BatchResultResp{
+		Items: []models.BatchItemResultResp{},
+	}
+	for _, result := range results {
+		item := models.BatchItemResultResp{
+			ReqID:  result.ReqID,
+			Status: result.Status,
+		}
+		if result.Err != nil || result.Status != http.StatusOK {
+			item.Error = result.Message
+			if item.Error == "" {
+				item.Error = ioutils.ResolveErrorToString(result.Status)
+			}
+			resp.Failed += 1
+		} else {
+			resp.Succeeded += 1
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
+
+func StudentImportReportToResp(report models.StudentImportReport) models.StudentImportReportResp {
+	resp := models.StudentImportReportResp{
+		DryRun: report.DryRun,
+		Items:  []models.StudentImportResultResp{},
+	}
+	for _, result := range report.Results {
+		resp.Total += 1
+		if len(result.Errors) != 0 {
+			resp.Invalid += 1
+		} else {
+			resp.Valid += 1
+		}
+		resp.Items = append(resp.Items, models.StudentImportResultResp{
+			Row:          result.Row,
+			ChildName:    result.ChildName,
+			ParentEmail:  result.ParentEmail,
+			ChildAction:  result.ChildAction,
+			ParentAction: result.ParentAction,
+			LinkAction:   result.LinkAction,
+			ChildID:      result.ChildID,
+			ParentID:     result.ParentID,
+			Errors:       result.Errors,
 		})
 	}
+	return resp
+}
+
+func RegReqMessagesToRespList(msgs []models.RegReqMessage) models.RegReqMessageRespList {
+	respList := models.RegReqMessageRespList{}
+	for _, msg := range msgs {
+		respList = append(respList, RegReqMessageToResp(msg))
+	}
 	return respList
 }
+
+func RegReqMessageToResp(msg models.RegReqMessage) models.RegReqMessageResp {
+	return models.RegReqMessageResp{
+		ID:          msg.ID,
+		ReqID:       msg.ReqID,
+		Author:      UserToUserRes(msg.Author),
+		Body:        msg.Body,
+		Internal:    msg.Internal,
+		Attachments: msg.Attachments,
+		CreateTime:  msg.CreateTime,
+		Receipts:    msg.Receipts,
+	}
+}
+
+func NotificationsToRespList(notifications []models.Notification) models.NotificationRespList {
+	respList := models.NotificationRespList{}
+	for _, notification := range notifications {
+		respList = append(respList, models.NotificationResp{
+			ID:         notification.ID,
+			Type:       string(notification.Type),
+			Title:      notification.Title,
+			Body:       notification.Body,
+			ReqID:      notification.ReqID,
+			Read:       notification.Read,
+			CreateTime: notification.CreateTime,
+		})
+	}
+	return respList
+}
+
+func RegReqEventToResp(event models.RegReqEvent) models.RegReqEventResp {
+	return models.RegReqEventResp{
+		Type:       string(event.Type),
+		ReqID:      event.ReqID,
+		UserID:     event.UserID,
+		ManagerID:  event.ManagerID,
+		ReqType:    event.ReqType.String(),
+		CreateTime: event.CreateTime,
+	}
+}
+
+func RegReqChangesToRespList(changes []models.RegReqChange) models.RegReqChangeRespList {
+	resp := models.RegReqChangeRespList{}
+	for _, change := range changes {
+		resp = append(resp, models.RegReqChangeResp{
+			Field:  change.Field,
+			Before: change.OldValue,
+			After:  change.NewValue,
+		})
+	}
+	return resp
+}
+
+func AdmissionTemplateToResp(template models.AdmissionTemplate) models.AdmissionTemplateResp {
+	return models.AdmissionTemplateResp{
+		ID:         template.ID,
+		Title:      template.Title,
+		Body:       template.Body,
+		Active:     template.Active,
+		AuthorID:   template.AuthorID,
+		CreateTime: template.CreateTime,
+		UpdateTime: template.UpdateTime,
+	}
+}
+
+func AdmissionTemplatesToRespList(templates []models.AdmissionTemplate) models.AdmissionTemplateRespList {
+	resp := models.AdmissionTemplateRespList{}
+	for _, template := range templates {
+		resp = append(resp, AdmissionTemplateToResp(template))
+	}
+	return resp
+}
+
+func UserPermissionsToRespList(permissions []models.UserPermission) models.UserPermissionRespList {
+	resp := models.UserPermissionRespList{}
+	for _, permission := range permissions {
+		resp = append(resp, models.UserPermissionResp{
+			Permission: string(permission.Permission),
+			GrantedBy:  permission.GrantedBy,
+			CreateTime: permission.CreateTime,
+		})
+	}
+	return resp
+}
+
+// dates are shown in school time zone
+var moscowTime = time.FixedZone("MSK", 3*60*60)
+
+// FormatDate returns date of unix time, zero time is empty date
+func FormatDate(unixTime uint64) string {
+	if unixTime == 0 {
+		return ""
+	}
+	return time.Unix(int64(unixTime), 0).In(moscowTime).Format("02.01.2006")
+}
+
+// MaskPassport keeps only last digits of decrypted passport
+func MaskPassport(passport string) string {
+	runes := []rune(passport)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
+
+var dateLayouts = []string{"02.01.2006", "2006-01-02"}
+
+// ParseDate returns unix time of date start, date is in FormatDate or ISO format
+func ParseDate(date string) (uint64, error) {
+	for _, layout := range dateLayouts {
+		parsed, err := time.ParseInLocation(layout, date, moscowTime)
+		if err != nil {
+			continue
+		}
+		if parsed.Unix() <= 0 {
+			return 0, fmt.Errorf("date %s is too early", date)
+		}
+		return uint64(parsed.Unix()), nil
+	}
+	return 0, fmt.Errorf("invalid date %s", date)
+}
@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+)
+
+type Action string
+
+const (
+	VerifyPassportAction   Action = "verify_passport"
+	SetStageAction         Action = "set_stage"
+	IssueCredentialsAction Action = "issue_credentials"
+)
+
+// Stage is registration request type defined by workflow config
+type Stage struct {
+	Type              models.RegReqType
+	Title             string
+	Owner             models.Role
+	RequiredFields    []string
+	RequiredDocuments []string
+	Action            Action
+	Requires          models.Stage
+	Grants            models.Stage
+	Next              models.RegReqType
+	DeleteDocuments   []string
+}
+
+// Builtin stages have own endpoints with request specific data,
+// other stages are submitted with documents only
+func (s Stage) Builtin() bool {
+	return s.Type <= models.ChildThirdStage
+}
+
+var childFields = map[string]func(models.Child) bool{
+	"first_name":            func(c models.Child) bool { return c.FirstName != "" },
+	"second_name":           func(c models.Child) bool { return c.SecondName != "" },
+	"last_name":             func(c models.Child) bool { return c.LastName != "" },
+	"email":                 func(c models.Child) bool { return c.Email != "" },
+	"phone":                 func(c models.Child) bool { return c.Phone != "" },
+	"birth_date":            func(c models.Child) bool { return c.BirthDate != 0 },
+	"passport":              func(c models.Child) bool { return c.Passport != "" },
+	"place_of_residence":    func(c models.Child) bool { return c.PlaceOfResidence != "" },
+	"place_of_registration": func(c models.Child) bool { return c.PlaceOfRegistration != "" },
+}
+
+var parentFields = map[string]func(models.Parent) bool{
+	"first_name":  func(p models.Parent) bool { return p.FirstName != "" },
+	"second_name": func(p models.Parent) bool { return p.SecondName != "" },
+	"last_name":   func(p models.Parent) bool { return p.LastName != "" },
+	"email":       func(p models.Parent) bool { return p.Email != "" },
+	"phone":       func(p models.Parent) bool { return p.Phone != "" },
+	"passport":    func(p models.Parent) bool { return p.Passport != "" },
+}
+
+var stages = map[models.RegReqType]Stage{}
+
+// stages in config order
+var stageList []Stage
+
+// Load must be called once on startup after config is set
+func Load(cfg []config.WorkflowStageConfig) error {
+	loaded := make(map[models.RegReqType]Stage, len(cfg))
+	loadedList := make([]Stage, 0, len(cfg))
+	for _, stageCfg := range cfg {
+		stage, err := parseStage(stageCfg)
+		if err != nil {
+			return err
+		}
+		if _, ok := loaded[stage.Type]; ok {
+			return fmt.Errorf("workflow.Load: request type %d is declared twice", stage.Type)
+		}
+		loaded[stage.Type] = stage
+		loadedList = append(loadedList, stage)
+	}
+	for _, stage := range loadedList {
+		if stage.Next == 0 {
+			continue
+		}
+		if _, ok := loaded[stage.Next]; !ok {
+			return fmt.Errorf("workflow.Load: next type %d of request type %d isn't declared", stage.Next, stage.Type)
+		}
+	}
+	for _, builtin := range []models.RegReqType{
+		models.ParentPassportVerification,
+		models.ChildFirstStageForStudent,
+		models.ChildFirstStage,
+		models.ChildSecondStage,
+		models.ChildThirdStage,
+	} {
+		if _, ok := loaded[builtin]; !ok {
+			return fmt.Errorf("workflow.Load: built-in request type %d isn't declared", builtin)
+		}
+	}
+
+	for _, stage := range loadedList {
+		models.SetRegReqTypeTitle(stage.Type, stage.Title)
+	}
+	stages = loaded
+	stageList = loadedList
+	return nil
+}
+
+func parseStage(cfg config.WorkflowStageConfig) (Stage, error) {
+	stage := Stage{
+		Type:              models.RegReqType(cfg.Type),
+		Title:             cfg.Title,
+		RequiredFields:    cfg.RequiredFields,
+		RequiredDocuments: cfg.RequiredDocuments,
+		Action:            Action(cfg.Action),
+		Requires:          models.Stage(cfg.RequiresStage),
+		Grants:            models.Stage(cfg.GrantsStage),
+		Next:              models.RegReqType(cfg.NextType),
+		DeleteDocuments:   cfg.DeleteDocuments,
+	}
+	if stage.Type <= 0 || stage.Title == "" {
+		return Stage{}, fmt.Errorf("workflow.parseStage: request type %d must have positive type and title", cfg.Type)
+	}
+	if stage.Type == models.ChildDataChange {
+		return Stage{}, fmt.Errorf("workflow.parseStage: request type %d is reserved for child data changes", cfg.Type)
+	}
+
+	switch cfg.Owner {
+	case "parent":
+		stage.Owner = models.ParentRole
+		for _, field := range stage.RequiredFields {
+			if _, ok := parentFields[field]; !ok {
+				return Stage{}, fmt.Errorf("workflow.parseStage: unknown parent field %s in request type %d", field, cfg.Type)
+			}
+		}
+	case "child":
+		stage.Owner = models.ChildRole
+		for _, field := range stage.RequiredFields {
+			if _, ok := childFields[field]; !ok {
+				return Stage{}, fmt.Errorf("workflow.parseStage: unknown child field %s in request type %d", field, cfg.Type)
+			}
+		}
+	default:
+		return Stage{}, fmt.Errorf("workflow.parseStage: unknown owner %s in request type %d", cfg.Owner, cfg.Type)
+	}
+
+	switch stage.Action {
+	case VerifyPassportAction:
+		if stage.Owner != models.ParentRole {
+			return Stage{}, fmt.Errorf("workflow.parseStage: action %s is available only for parent in request type %d", stage.Action, cfg.Type)
+		}
+	case SetStageAction, IssueCredentialsAction:
+		if stage.Owner != models.ChildRole || stage.Grants <= stage.Requires {
+			return Stage{}, fmt.Errorf("workflow.parseStage: action %s needs child owner and granted stage after required one in request type %d", stage.Action, cfg.Type)
+		}
+	default:
+		return Stage{}, fmt.Errorf("workflow.parseStage: unknown action %s in request type %d", cfg.Action, cfg.Type)
+	}
+	return stage, nil
+}
+
+func Get(reqType models.RegReqType) (Stage, bool) {
+	stage, ok := stages[reqType]
+	return stage, ok
+}
+
+func List() []Stage {
+	return stageList
+}
+
+// MissingChildFields returns required fields which child hasn't filled in
+func MissingChildFields(stage Stage, child models.Child) []string {
+	missing := []string{}
+	for _, field := range stage.RequiredFields {
+		if filled, ok := childFields[field]; ok && !filled(child) {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// MissingParentFields returns required fields which parent hasn't filled in
+func MissingParentFields(stage Stage, parent models.Parent) []string {
+	missing := []string{}
+	for _, field := range stage.RequiredFields {
+		if filled, ok := parentFields[field]; ok && !filled(parent) {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
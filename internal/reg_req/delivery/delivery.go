@@ -1,6 +1,7 @@
 package delivery
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -23,6 +24,7 @@ func SetRegReqRouting(router *mux.Router,
 	rru usecase.IRegReqUsecase,
 	auth middleware.AuthMiddleware,
 	roleMw middleware.RoleMiddleware,
+	idem middleware.IdempotencyMiddleware,
 	logger logrus.Logger) {
 	regReqDelivery := &RegReqDelivery{
 		regReqUseCase: rru,
@@ -32,15 +34,26 @@ func SetRegReqRouting(router *mux.Router,
 	regReqParentAPI := router.PathPrefix("/api/v1/reg/request/parent").Subrouter()
 	regReqParentAPI.Use(middleware.WithJSON)
 	regReqParentAPI.Use(auth.WithAuth)
+	regReqParentAPI.Use(idem.WithIdempotency)
 	regReqParentAPI.Use(roleMw.CheckParent)
 
 	regReqParentAPI.HandleFunc("/passport", regReqDelivery.CreateVerifyParentPassportReq).Methods(http.MethodPost)
 	regReqParentAPI.HandleFunc("/list", regReqDelivery.GetParentRegRequests).Methods(http.MethodGet)
 	regReqParentAPI.HandleFunc("/passport/fix", regReqDelivery.FixVerifyParentPassportReq).Methods(http.MethodPost)
+	regReqParentAPI.HandleFunc("/reasons", regReqDelivery.GetRejectionReasons).Methods(http.MethodGet)
+	regReqParentAPI.HandleFunc("/workflow", regReqDelivery.GetWorkflowStages).Methods(http.MethodGet)
+	regReqParentAPI.HandleFunc("/checklist", regReqDelivery.GetParentChecklist).Methods(http.MethodGet)
+	regReqParentAPI.HandleFunc("/cancel", regReqDelivery.CancelRegReq).Methods(http.MethodPost)
+	regReqParentAPI.HandleFunc("/child/withdraw", regReqDelivery.WithdrawChild).Methods(http.MethodPost)
+	regReqParentAPI.HandleFunc("/child/change", regReqDelivery.CreateChildDataChangeReq).Methods(http.MethodPost)
+	regReqParentAPI.HandleFunc("/changes", regReqDelivery.GetParentRegReqChanges).Methods(http.MethodGet)
+	regReqParentAPI.HandleFunc("/messages", regReqDelivery.GetParentRegReqMessages).Methods(http.MethodGet)
+	regReqParentAPI.HandleFunc("/message", regReqDelivery.CreateParentRegReqMessage).Methods(http.MethodPost)
 
 	regReqChildAPI := router.PathPrefix("/api/v1/reg/request/child/stage").Subrouter()
 	regReqChildAPI.Use(middleware.WithJSON)
 	regReqChildAPI.Use(auth.WithAuth)
+	regReqChildAPI.Use(idem.WithIdempotency)
 
 	regReqChildAPI.Handle("/first", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.FirstSignupChild))).Methods(http.MethodPost)
 	regReqChildAPI.Handle("/first/fix", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.FixFirstSignupChild))).Methods(http.MethodPost)
@@ -48,15 +61,69 @@ func SetRegReqRouting(router *mux.Router,
 	regReqChildAPI.Handle("/second/fix", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.FixSecondSignupChild))).Methods(http.MethodPost)
 	regReqChildAPI.Handle("/third", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.ThirdSignupChild))).Methods(http.MethodPost)
 	regReqChildAPI.Handle("/third/fix", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.FixThirdSignupChild))).Methods(http.MethodPost)
+	regReqChildAPI.Handle("/custom", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.SubmitChildStage))).Methods(http.MethodPost)
+	regReqChildAPI.Handle("/custom/fix", roleMw.CheckParent(http.HandlerFunc(regReqDelivery.FixChildStage))).Methods(http.MethodPost)
+
+	regReqChildSelfAPI := router.PathPrefix("/api/v1/reg/request/child/self").Subrouter()
+	regReqChildSelfAPI.Use(middleware.WithJSON)
+	regReqChildSelfAPI.Use(auth.WithAuth)
+	regReqChildSelfAPI.Use(roleMw.CheckChild)
+
+	regReqChildSelfAPI.HandleFunc("/list", regReqDelivery.GetChildRegRequests).Methods(http.MethodGet)
+	regReqChildSelfAPI.HandleFunc("/history", regReqDelivery.GetChildRegReqHistory).Methods(http.MethodGet)
+	regReqChildSelfAPI.HandleFunc("/checklist", regReqDelivery.GetChildChecklist).Methods(http.MethodGet)
 
 	regReqCompleteAPI := router.PathPrefix("/api/v1/reg/request/manager").Subrouter()
 	regReqCompleteAPI.Use(middleware.WithJSON)
 	regReqCompleteAPI.Use(auth.WithAuth)
+	regReqCompleteAPI.Use(idem.WithIdempotency)
 	regReqCompleteAPI.Use(roleMw.CheckManager)
 
 	regReqCompleteAPI.HandleFunc("/complete", regReqDelivery.CompleteRegReq).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/claim", regReqDelivery.ClaimRegReq).Methods(http.MethodPost)
 	regReqCompleteAPI.HandleFunc("/failed", regReqDelivery.FailedRegReq).Methods(http.MethodPost)
 	regReqCompleteAPI.HandleFunc("/list", regReqDelivery.GetRegReqs).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/stats", regReqDelivery.GetManagerRegReqStats).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/complete/batch", regReqDelivery.BatchCompleteRegReq).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/failed/batch", regReqDelivery.BatchFailedRegReq).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/reasons", regReqDelivery.GetRejectionReasons).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/messages", regReqDelivery.GetManagerRegReqMessages).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/changes", regReqDelivery.GetRegReqChanges).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/message", regReqDelivery.CreateManagerRegReqMessage).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/escalate", regReqDelivery.EscalateRegReq).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/reassign", regReqDelivery.ReassignRegReq).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/duplicates", regReqDelivery.GetChildDuplicates).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/duplicates/dismiss", regReqDelivery.DismissChildDuplicate).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/duplicates/merge", regReqDelivery.MergeChildDuplicate).Methods(http.MethodPost)
+	regReqCompleteAPI.HandleFunc("/export/requests", regReqDelivery.ExportManagerRegReqs).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/export/children", regReqDelivery.ExportChildren).Methods(http.MethodGet)
+	regReqCompleteAPI.HandleFunc("/export/stats", regReqDelivery.ExportManagerRegReqStats).Methods(http.MethodGet)
+
+	regReqAdminAPI := router.PathPrefix("/api/v1/reg/request/admin").Subrouter()
+	regReqAdminAPI.Use(middleware.WithJSON)
+	regReqAdminAPI.Use(auth.WithAuth)
+	regReqAdminAPI.Use(idem.WithIdempotency)
+	regReqAdminAPI.Use(roleMw.CheckAdmin)
+
+	regReqAdminAPI.HandleFunc("/stats", regReqDelivery.GetAdminRegReqStats).Methods(http.MethodGet)
+	regReqAdminAPI.HandleFunc("/escalated", regReqDelivery.GetEscalatedRegReqs).Methods(http.MethodGet)
+	regReqAdminAPI.HandleFunc("/resolve", regReqDelivery.ResolveEscalation).Methods(http.MethodPost)
+	regReqAdminAPI.HandleFunc("/child/transfer", regReqDelivery.TransferChild).Methods(http.MethodPost)
+	regReqAdminAPI.HandleFunc("/export/requests", regReqDelivery.ExportAdminRegReqs).Methods(http.MethodGet)
+	regReqAdminAPI.HandleFunc("/export/children", regReqDelivery.ExportChildren).Methods(http.MethodGet)
+	regReqAdminAPI.HandleFunc("/export/stats", regReqDelivery.ExportAdminRegReqStats).Methods(http.MethodGet)
+	regReqAdminAPI.HandleFunc("/import/students/check", regReqDelivery.CheckImportStudents).Methods(http.MethodPost)
+	regReqAdminAPI.HandleFunc("/import/students", regReqDelivery.ImportStudents).Methods(http.MethodPost)
+}
+
+// workflow errors are sent with message explaining what is missing
+func sendStageError(w http.ResponseWriter, status int, err error) {
+	var stageErr *usecase.StageError
+	if errors.As(err, &stageErr) {
+		ioutils.SendError(w, stageErr.Status, stageErr.Message)
+		return
+	}
+	ioutils.SendDefaultError(w, status)
 }
 
 func (rrd *RegReqDelivery) CreateVerifyParentPassportReq(w http.ResponseWriter, r *http.Request) {
@@ -79,7 +146,7 @@ func (rrd *RegReqDelivery) CreateVerifyParentPassportReq(w http.ResponseWriter,
 	status, err := rrd.regReqUseCase.CreateVerifyParentPassportReq(ctx, *parent, req)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -106,7 +173,7 @@ func (rrd *RegReqDelivery) FixVerifyParentPassportReq(w http.ResponseWriter, r *
 	status, err := rrd.regReqUseCase.FixVerifyParentPassportReq(ctx, *parent, req)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -152,7 +219,7 @@ func (rrd *RegReqDelivery) FirstSignupChild(w http.ResponseWriter, r *http.Reque
 	createdChild, status, err := rrd.regReqUseCase.CreateChild(ctx, childReq, parent.ID)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -179,7 +246,7 @@ func (rrd *RegReqDelivery) FixFirstSignupChild(w http.ResponseWriter, r *http.Re
 	status, err := rrd.regReqUseCase.FixChild(ctx, childReq)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -206,7 +273,7 @@ func (rrd *RegReqDelivery) SecondSignupChild(w http.ResponseWriter, r *http.Requ
 	createdReq, status, err := rrd.regReqUseCase.SecondRegistrationChildStage(ctx, childReq, *parent)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -233,7 +300,7 @@ func (rrd *RegReqDelivery) FixSecondSignupChild(w http.ResponseWriter, r *http.R
 	status, err := rrd.regReqUseCase.FixSecondRegistrationChildStage(ctx, childReq, *parent)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -260,7 +327,7 @@ func (rrd *RegReqDelivery) ThirdSignupChild(w http.ResponseWriter, r *http.Reque
 	createdReq, status, err := rrd.regReqUseCase.ThirdRegistrationChildStage(ctx, childReq, *parent)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -287,7 +354,113 @@ func (rrd *RegReqDelivery) FixThirdSignupChild(w http.ResponseWriter, r *http.Re
 	status, err := rrd.regReqUseCase.FixThirdRegistrationChildStage(ctx, childReq, *parent)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
-		ioutils.SendDefaultError(w, status)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) SubmitChildStage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var stageReq models.ChildStageReq
+	err := ioutils.ReadJSON(r, &stageReq)
+	if err != nil || stageReq.ChildID == 0 {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	createdReq, status, err := rrd.regReqUseCase.SubmitChildStage(ctx, stageReq, *parent)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.Send(w, status, tools.FullRegReqToSimpleResp(createdReq))
+}
+
+func (rrd *RegReqDelivery) FixChildStage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var fixReq models.FixChildStageReq
+	err := ioutils.ReadJSON(r, &fixReq)
+	if err != nil || fixReq.ReqID == 0 {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.FixChildStage(ctx, fixReq, *parent)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) CancelRegReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqID, err := strconv.ParseUint(r.URL.Query().Get("req"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid req id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.CancelRegReq(ctx, *parent, reqID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) WithdrawChild(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	childUID, err := strconv.ParseUint(r.URL.Query().Get("child"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid child id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.WithdrawChild(ctx, *parent, childUID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
 		return
 	}
 
@@ -296,6 +469,13 @@ func (rrd *RegReqDelivery) FixThirdSignupChild(w http.ResponseWriter, r *http.Re
 
 func (rrd *RegReqDelivery) CompleteRegReq(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
 	reqIDString := r.URL.Query().Get("req")
 	if reqIDString == "" {
 		rrd.logger.Errorf("%s empty query [status=%d]", r.URL, http.StatusBadRequest)
@@ -309,7 +489,33 @@ func (rrd *RegReqDelivery) CompleteRegReq(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	status, err := rrd.regReqUseCase.CompleteRegReq(ctx, reqID)
+	status, err := rrd.regReqUseCase.CompleteRegReq(ctx, manager.ID, reqID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) ClaimRegReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqID, err := strconv.ParseUint(r.URL.Query().Get("req"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid req id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.ClaimRegReq(ctx, manager.ID, reqID)
 	if err != nil || status != http.StatusOK {
 		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
 		ioutils.SendDefaultError(w, status)
@@ -364,3 +570,611 @@ func (rrd *RegReqDelivery) FailedRegReq(w http.ResponseWriter, r *http.Request)
 
 	ioutils.SendWithoutBody(w, status)
 }
+
+func (rrd *RegReqDelivery) EscalateRegReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var escalateReq models.EscalateReq
+	err := ioutils.ReadJSON(r, &escalateReq)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.EscalateRegReq(ctx, user.ID, escalateReq)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) ReassignRegReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var reassignReq models.ReassignReq
+	err := ioutils.ReadJSON(r, &reassignReq)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.ReassignRegReq(ctx, user.ID, reassignReq)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) GetChildDuplicates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	duplicates, status, err := rrd.regReqUseCase.GetChildDuplicates(ctx)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.ChildDuplicateList(duplicates))
+}
+
+func (rrd *RegReqDelivery) DismissChildDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	duplicateID, err := strconv.ParseUint(r.URL.Query().Get("duplicate"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid duplicate id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.DismissChildDuplicate(ctx, manager.ID, duplicateID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) MergeChildDuplicate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var mergeReq models.MergeChildrenReq
+	err := ioutils.ReadJSON(r, &mergeReq)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.MergeChildDuplicate(ctx, manager.ID, mergeReq)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) TransferChild(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var transferReq models.TransferChildReq
+	err := ioutils.ReadJSON(r, &transferReq)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.TransferChild(ctx, admin.ID, transferReq)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (rrd *RegReqDelivery) GetEscalatedRegReqs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reqList, status, err := rrd.regReqUseCase.GetEscalatedRegReqs(ctx)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqsWithUserToRespList(reqList))
+}
+
+func (rrd *RegReqDelivery) ResolveEscalation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var resolveReq models.ResolveEscalationReq
+	err := ioutils.ReadJSON(r, &resolveReq)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := rrd.regReqUseCase.ResolveEscalation(ctx, user.ID, resolveReq)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+// parseStatsFilter reads optional unix time range from "from" and "to" query params
+func parseStatsFilter(r *http.Request) (models.RegReqStatsFilter, error) {
+	var filter models.RegReqStatsFilter
+	var err error
+	query := r.URL.Query()
+	if from := query.Get("from"); from != "" {
+		filter.From, err = strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return models.RegReqStatsFilter{}, err
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		filter.To, err = strconv.ParseUint(to, 10, 64)
+		if err != nil {
+			return models.RegReqStatsFilter{}, err
+		}
+	}
+	return filter, nil
+}
+
+func (rrd *RegReqDelivery) GetManagerRegReqStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid date range parameters [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	// manager can see only own decisions
+	filter.ManagerID = manager.ID
+
+	stats, status, err := rrd.regReqUseCase.GetRegReqStats(ctx, filter)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqStatsToResp(stats))
+}
+
+func (rrd *RegReqDelivery) GetAdminRegReqStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid date range parameters [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	if managerIDString := r.URL.Query().Get("manager"); managerIDString != "" {
+		filter.ManagerID, err = strconv.ParseUint(managerIDString, 10, 64)
+		if err != nil {
+			rrd.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	stats, status, err := rrd.regReqUseCase.GetRegReqStats(ctx, filter)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqStatsToResp(stats))
+}
+
+func (rrd *RegReqDelivery) BatchCompleteRegReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var batch models.BatchCompleteReq
+	err := ioutils.ReadJSON(r, &batch)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	results, status, err := rrd.regReqUseCase.BatchCompleteRegReq(ctx, manager.ID, batch)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.BatchResultsToResp(results))
+}
+
+func (rrd *RegReqDelivery) BatchFailedRegReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var batch models.BatchFailedReq
+	err := ioutils.ReadJSON(r, &batch)
+	if err != nil {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	results, status, err := rrd.regReqUseCase.BatchFailedRegReq(ctx, manager.ID, batch)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.BatchResultsToResp(results))
+}
+
+func (rrd *RegReqDelivery) GetRejectionReasons(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reasons, status, err := rrd.regReqUseCase.GetRejectionReasons(ctx)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, reasons)
+}
+
+func (rrd *RegReqDelivery) GetWorkflowStages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stages, status, err := rrd.regReqUseCase.GetWorkflowStages(ctx)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, stages)
+}
+
+func (rrd *RegReqDelivery) GetParentChecklist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	checklists, status, err := rrd.regReqUseCase.GetParentChecklist(ctx, *parent)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, checklists)
+}
+
+func (rrd *RegReqDelivery) GetChildRegRequests(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	child := ctx_utils.GetChild(ctx)
+	if child == nil {
+		rrd.logger.Errorf("%s failed get ctx child with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqList, status, err := rrd.regReqUseCase.GetRegRequestsList(ctx, child.UserID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.FullRegReqToSimpleRespList(reqList))
+}
+
+func (rrd *RegReqDelivery) GetChildRegReqHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	child := ctx_utils.GetChild(ctx)
+	if child == nil {
+		rrd.logger.Errorf("%s failed get ctx child with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	history, status, err := rrd.regReqUseCase.GetRegReqHistory(ctx, child.UserID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqHistoryToRespList(history))
+}
+
+func (rrd *RegReqDelivery) GetChildChecklist(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	child := ctx_utils.GetChild(ctx)
+	if child == nil {
+		rrd.logger.Errorf("%s failed get ctx child with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	checklist, status, err := rrd.regReqUseCase.GetChildChecklist(ctx, *child)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, checklist)
+}
+
+func (rrd *RegReqDelivery) GetParentRegReqMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqID, err := strconv.ParseUint(r.URL.Query().Get("req"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid req id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	msgs, status, err := rrd.regReqUseCase.GetParentRegReqMessages(ctx, *parent, reqID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqMessagesToRespList(msgs))
+}
+
+func (rrd *RegReqDelivery) CreateParentRegReqMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var req models.RegReqMessageReq
+	err := ioutils.ReadJSON(r, &req)
+	if err != nil || req.ReqID == 0 {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	msg, status, err := rrd.regReqUseCase.CreateParentRegReqMessage(ctx, *parent, req)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqMessageToResp(msg))
+}
+
+func (rrd *RegReqDelivery) GetManagerRegReqMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqID, err := strconv.ParseUint(r.URL.Query().Get("req"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid req id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	msgs, status, err := rrd.regReqUseCase.GetManagerRegReqMessages(ctx, manager.ID, reqID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqMessagesToRespList(msgs))
+}
+
+func (rrd *RegReqDelivery) CreateManagerRegReqMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var req models.RegReqMessageReq
+	err := ioutils.ReadJSON(r, &req)
+	if err != nil || req.ReqID == 0 {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	msg, status, err := rrd.regReqUseCase.CreateManagerRegReqMessage(ctx, manager.ID, req)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqMessageToResp(msg))
+}
+
+func (rrd *RegReqDelivery) CreateChildDataChangeReq(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var changeReq models.ChildDataChangeReq
+	err := ioutils.ReadJSON(r, &changeReq)
+	if err != nil || changeReq.ChildID == 0 {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	createdReq, status, err := rrd.regReqUseCase.CreateChildDataChangeReq(ctx, *parent, changeReq)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		sendStageError(w, status, err)
+		return
+	}
+
+	ioutils.Send(w, status, tools.FullRegReqToSimpleResp(createdReq))
+}
+
+func (rrd *RegReqDelivery) GetRegReqChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqID, err := strconv.ParseUint(r.URL.Query().Get("req"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid req id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	changes, status, err := rrd.regReqUseCase.GetRegReqChanges(ctx, reqID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqChangesToRespList(changes))
+}
+
+func (rrd *RegReqDelivery) GetParentRegReqChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		rrd.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	reqID, err := strconv.ParseUint(r.URL.Query().Get("req"), 10, 64)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid req id parametr [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	changes, status, err := rrd.regReqUseCase.GetParentRegReqChanges(ctx, *parent, reqID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.RegReqChangesToRespList(changes))
+}
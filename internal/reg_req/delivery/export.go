@@ -0,0 +1,134 @@
+package delivery
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+	"github.com/VoyakinH/lokle_backend/internal/reg_req/usecase"
+	"github.com/xuri/excelize/v2"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// sendXLSX streams workbook to response, status can't be changed after first written byte
+func (rrd *RegReqDelivery) sendXLSX(w http.ResponseWriter, r *http.Request, f *excelize.File, name string) {
+	defer f.Close()
+
+	w.Header().Set("Content-Type", xlsxContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.xlsx"`, name, time.Now().Format("2006-01-02")))
+	w.WriteHeader(http.StatusOK)
+	err := f.Write(w)
+	if err != nil {
+		rrd.logger.Errorf("%s failed to write xlsx with [error=%s]", r.URL, err)
+	}
+}
+
+func (rrd *RegReqDelivery) ExportManagerRegReqs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f, status, err := rrd.regReqUseCase.ExportRegReqs(ctx, usecase.PendingReqStatus)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	rrd.sendXLSX(w, r, f, "requests")
+}
+
+func (rrd *RegReqDelivery) ExportAdminRegReqs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reqStatus := r.URL.Query().Get("status")
+	if reqStatus == "" {
+		reqStatus = usecase.PendingReqStatus
+	}
+
+	f, status, err := rrd.regReqUseCase.ExportRegReqs(ctx, reqStatus)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	rrd.sendXLSX(w, r, f, "requests_"+reqStatus)
+}
+
+func (rrd *RegReqDelivery) ExportChildren(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	f, status, err := rrd.regReqUseCase.ExportChildren(ctx, user.ID)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	rrd.sendXLSX(w, r, f, "children")
+}
+
+func (rrd *RegReqDelivery) ExportManagerRegReqStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid date range parameters [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	// manager can see only own decisions
+	filter.ManagerID = manager.ID
+
+	f, status, err := rrd.regReqUseCase.ExportRegReqStats(ctx, filter)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	rrd.sendXLSX(w, r, f, "stats")
+}
+
+func (rrd *RegReqDelivery) ExportAdminRegReqStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		rrd.logger.Errorf("%s invalid date range parameters [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	if managerIDString := r.URL.Query().Get("manager"); managerIDString != "" {
+		filter.ManagerID, err = strconv.ParseUint(managerIDString, 10, 64)
+		if err != nil {
+			rrd.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	f, status, err := rrd.regReqUseCase.ExportRegReqStats(ctx, filter)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	rrd.sendXLSX(w, r, f, "stats")
+}
@@ -0,0 +1,61 @@
+package delivery
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+)
+
+const maxImportFileSize = 5 << 20 // 5MB
+
+// CheckImportStudents returns report of import without saving anything
+func (rrd *RegReqDelivery) CheckImportStudents(w http.ResponseWriter, r *http.Request) {
+	rrd.importStudents(w, r, true)
+}
+
+func (rrd *RegReqDelivery) ImportStudents(w http.ResponseWriter, r *http.Request) {
+	rrd.importStudents(w, r, false)
+}
+
+// students list is sent as multipart form with xlsx or csv file in field file
+func (rrd *RegReqDelivery) importStudents(w http.ResponseWriter, r *http.Request, dryRun bool) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		rrd.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		rrd.logger.Errorf("%s file is too big [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		rrd.logger.Errorf("%s file not found in form [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		rrd.logger.Errorf("%s failed to read file [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	report, status, err := rrd.regReqUseCase.ImportStudents(ctx, admin.ID, data, dryRun)
+	if err != nil || status != http.StatusOK {
+		rrd.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.StudentImportReportToResp(report))
+}
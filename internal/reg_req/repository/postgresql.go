@@ -6,20 +6,40 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/VoyakinH/lokle_backend/config"
 	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
 	"github.com/jackc/pgx"
 	"github.com/sirupsen/logrus"
 )
 
 type IPostgresqlRepository interface {
 	CreateRegReq(context.Context, uint64, models.RegReqType) (models.RegReqFull, error)
+	CreateFailedRegReq(context.Context, uint64, models.RegReqType, string) (models.RegReqFull, error)
 	FixRegReq(context.Context, uint64) error
 	GetRegRequestList(context.Context, uint64) ([]models.RegReqFull, error)
 	GetRegRequestListAll(context.Context) ([]models.RegReqWithUser, error)
+	GetEscalatedRegRequestList(context.Context) ([]models.RegReqWithUser, error)
+	DetectChildDuplicates(context.Context, uint64) error
+	FindChildrenByName(context.Context, string, uint64) ([]uint64, error)
+	GetChildDuplicates(context.Context, string) ([]models.ChildDuplicate, error)
+	GetChildDuplicateByID(context.Context, uint64) (models.ChildDuplicate, error)
+	DismissChildDuplicate(context.Context, uint64, uint64) error
 	GetRegRequestByID(context.Context, uint64) (models.RegReqFull, error)
+	LockRegReq(context.Context, uint64, string) error
 	DeleteRegReq(context.Context, uint64) (models.RegReqFull, error)
 	FailedRegReq(context.Context, uint64, models.FailedReq) error
+	ClaimRegReq(context.Context, uint64, uint64) error
+	EscalateRegReq(context.Context, models.EscalateReq) error
+	AssignRegReq(context.Context, uint64, uint64, string) error
+	AddRegReqHistory(context.Context, models.RegReqHistory) error
+	GetRegReqHistoryByUser(context.Context, uint64) ([]models.RegReqHistory, error)
+	GetArchivedRegReq(context.Context, uint64) (models.RegReqFull, error)
+	GetRegReqStats(context.Context, models.RegReqStatsFilter) (models.RegReqStats, error)
+	CreateRegReqMessage(context.Context, uint64, models.RegReqMessageReq) (models.RegReqMessage, error)
+	GetRegReqMessages(context.Context, uint64, bool) ([]models.RegReqMessage, error)
+	MarkRegReqMessagesRead(context.Context, uint64, uint64, bool) error
+	CreateRegReqChanges(context.Context, uint64, []models.RegReqChange) error
+	GetRegReqChanges(context.Context, uint64) ([]models.RegReqChange, error)
 }
 
 type postgresqlRepository struct {
@@ -27,36 +47,19 @@ type postgresqlRepository struct {
 	logger logrus.Logger
 }
 
-func NewPostgresqlRepository(cfg config.PostgresConfig, logger logrus.Logger) IPostgresqlRepository {
-	connStr := fmt.Sprintf("user=%s dbname=%s password=%s host=%s port=%s sslmode=disable",
-		cfg.User,
-		cfg.DBName,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port)
-
-	pgxConnectionConfig, err := pgx.ParseConnectionString(connStr)
-	if err != nil {
-		logger.Fatalf("Invalid config string: %s", err)
-	}
-
-	pool, err := pgx.NewConnPool(pgx.ConnPoolConfig{
-		ConnConfig:     pgxConnectionConfig,
-		MaxConnections: 100,
-		AfterConnect:   nil,
-		AcquireTimeout: 0,
-	})
-	if err != nil {
-		logger.Fatalf("Error %s occurred during connection to database", err)
-	}
-
+func NewPostgresqlRepository(pool *pgx.ConnPool, logger logrus.Logger) IPostgresqlRepository {
 	return &postgresqlRepository{conn: pool, logger: logger}
 }
 
+// db returns transaction if method is called inside unit of work
+func (pr *postgresqlRepository) db(ctx context.Context) database.Querier {
+	return database.GetQuerier(ctx, pr.conn)
+}
+
 func (pr *postgresqlRepository) CreateRegReq(ctx context.Context, uid uint64, reqType models.RegReqType) (models.RegReqFull, error) {
 	var req models.RegReqFull
 	now := time.Now().Unix()
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`INSERT INTO registration_requests (user_id, type, create_time)
 		VALUES ($1, $2, $3)
 		RETURNING id, user_id, type, status, create_time;`,
@@ -76,9 +79,65 @@ func (pr *postgresqlRepository) CreateRegReq(ctx context.Context, uid uint64, re
 	return req, nil
 }
 
+// CreateFailedRegReq creates request which waits for fix without manager's review
+func (pr *postgresqlRepository) CreateFailedRegReq(ctx context.Context, uid uint64, reqType models.RegReqType, message string) (models.RegReqFull, error) {
+	var req models.RegReqFull
+	now := time.Now().Unix()
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO registration_requests (user_id, type, status, create_time, message)
+		VALUES ($1, $2, 'failed', $3, $4)
+		RETURNING id, user_id, type, status, create_time, message;`,
+		uid,
+		reqType,
+		now,
+		message,
+	).Scan(
+		&req.ID,
+		&req.UserID,
+		&req.Type,
+		&req.Status,
+		&req.CreateTime,
+		&req.Message,
+	)
+	if err != nil {
+		return models.RegReqFull{}, err
+	}
+	return req, nil
+}
+
 func (pr *postgresqlRepository) GetRegRequestList(ctx context.Context, uid uint64) ([]models.RegReqFull, error) {
-	rows, err := pr.conn.Query(
-		"SELECT id, user_id, type, status, create_time, message FROM registration_requests WHERE user_id = $1;",
+	rows, err := pr.db(ctx).Query(
+		`SELECT
+			rr.id,
+			rr.user_id,
+			rr.type,
+			rr.status,
+			rr.create_time,
+			rr.message,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'field', i.field,
+					'document', i.document,
+					'reason_code', i.reason_code,
+					'comment', i.comment
+				) ORDER BY i.id)
+				FROM registration_request_issues AS i
+				WHERE i.req_id = rr.id
+			), '[]')::text,
+			(
+				SELECT COUNT(*)
+				FROM registration_request_messages AS m
+				WHERE m.req_id = rr.id
+					AND NOT m.internal
+					AND m.author_id IS DISTINCT FROM $1
+					AND NOT EXISTS (
+						SELECT 1
+						FROM registration_request_message_reads AS mr
+						WHERE mr.message_id = m.id AND mr.user_id = $1
+					)
+			)
+		FROM registration_requests AS rr
+		WHERE rr.user_id = $1;`,
 		uid,
 	)
 	if err != nil {
@@ -88,6 +147,7 @@ func (pr *postgresqlRepository) GetRegRequestList(ctx context.Context, uid uint6
 
 	var respList []models.RegReqFull
 	var resp models.RegReqFull
+	var issues string
 	for rows.Next() {
 		err := rows.Scan(
 			&resp.ID,
@@ -96,10 +156,17 @@ func (pr *postgresqlRepository) GetRegRequestList(ctx context.Context, uid uint6
 			&resp.Status,
 			&resp.CreateTime,
 			&resp.Message,
+			&issues,
+			&resp.UnreadMessages,
 		)
 		if err != nil {
 			return []models.RegReqFull{}, err
 		}
+		resp.Issues = nil
+		err = resp.Issues.UnmarshalJSON([]byte(issues))
+		if err != nil {
+			return []models.RegReqFull{}, err
+		}
 		respList = append(respList, resp)
 	}
 	if err := rows.Err(); err != nil {
@@ -146,7 +213,15 @@ func (mn *managerNull) convertToManager() *models.User {
 }
 
 func (pr *postgresqlRepository) GetRegRequestListAll(ctx context.Context) ([]models.RegReqWithUser, error) {
-	rows, err := pr.conn.Query(
+	return pr.getRegRequestListByStatus(ctx, "pending")
+}
+
+func (pr *postgresqlRepository) GetEscalatedRegRequestList(ctx context.Context) ([]models.RegReqWithUser, error) {
+	return pr.getRegRequestListByStatus(ctx, "escalated")
+}
+
+func (pr *postgresqlRepository) getRegRequestListByStatus(ctx context.Context, status string) ([]models.RegReqWithUser, error) {
+	rows, err := pr.db(ctx).Query(
 		`SELECT
 			rr.id,
 			us.id,
@@ -164,12 +239,33 @@ func (pr *postgresqlRepository) GetRegRequestListAll(ctx context.Context) ([]mod
 			rr.type,
 			rr.status,
 			rr.create_time,
-			rr.message
+			rr.message,
+			rr.escalation_reason,
+			(
+				SELECT COUNT(*)
+				FROM registration_request_messages AS m
+				WHERE m.req_id = rr.id
+					AND m.author_role NOT IN ($1, $2)
+					AND NOT EXISTS (
+						SELECT 1
+						FROM registration_request_message_reads AS mr
+						JOIN users AS ru ON (ru.id = mr.user_id)
+						WHERE mr.message_id = m.id AND ru.role IN ($1, $2)
+					)
+			),
+			(
+				SELECT COUNT(*)
+				FROM child_duplicates AS cd
+				WHERE cd.status = 'open' AND rr.user_id IN (cd.child_id, cd.duplicate_id)
+			)
 		FROM registration_requests AS rr
 		JOIN users AS us ON (us.id = rr.user_id)
 		LEFT JOIN users AS usm ON (usm.id = rr.manager_id)
-		WHERE rr.status = 'pending'
+		WHERE rr.status = $3
 		ORDER BY create_time;`,
+		models.ManagerRole,
+		models.AdminRole,
+		status,
 	)
 	if err != nil {
 		return []models.RegReqWithUser{}, err
@@ -198,6 +294,9 @@ func (pr *postgresqlRepository) GetRegRequestListAll(ctx context.Context) ([]mod
 			&resp.Status,
 			&resp.CreateTime,
 			&resp.Message,
+			&resp.EscalationReason,
+			&resp.UnreadMessages,
+			&resp.PossibleDuplicates,
 		)
 		if err != nil {
 			return []models.RegReqWithUser{}, err
@@ -213,28 +312,66 @@ func (pr *postgresqlRepository) GetRegRequestListAll(ctx context.Context) ([]mod
 
 func (pr *postgresqlRepository) GetRegRequestByID(ctx context.Context, reqID uint64) (models.RegReqFull, error) {
 	var req models.RegReqFull
-	err := pr.conn.QueryRow(
-		`SELECT id, user_id, type, status, create_time, message
-		FROM registration_requests
-		WHERE id = $1;`,
+	var issues string
+	err := pr.db(ctx).QueryRow(
+		`SELECT
+			rr.id,
+			rr.user_id,
+			COALESCE(rr.manager_id, 0),
+			rr.type,
+			rr.status,
+			rr.create_time,
+			rr.message,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'field', i.field,
+					'document', i.document,
+					'reason_code', i.reason_code,
+					'comment', i.comment
+				) ORDER BY i.id)
+				FROM registration_request_issues AS i
+				WHERE i.req_id = rr.id
+			), '[]')::text
+		FROM registration_requests AS rr
+		WHERE rr.id = $1;`,
 		reqID,
 	).Scan(
 		&req.ID,
 		&req.UserID,
+		&req.ManagerID,
 		&req.Type,
 		&req.Status,
 		&req.CreateTime,
 		&req.Message,
+		&issues,
 	)
 	if err != nil {
 		return models.RegReqFull{}, err
 	}
+	err = req.Issues.UnmarshalJSON([]byte(issues))
+	if err != nil {
+		return models.RegReqFull{}, err
+	}
 	return req, nil
 }
 
+// LockRegReq locks request until the end of current unit of work.
+// Returns pgx.ErrNoRows if request has been deleted or its status has been changed
+func (pr *postgresqlRepository) LockRegReq(ctx context.Context, reqID uint64, status string) error {
+	var lockedID uint64
+	err := pr.db(ctx).QueryRow(
+		`SELECT id FROM registration_requests WHERE id = $1 AND status = $2 FOR UPDATE;`,
+		reqID,
+		status,
+	).Scan(
+		&lockedID,
+	)
+	return err
+}
+
 func (pr *postgresqlRepository) DeleteRegReq(ctx context.Context, reqID uint64) (models.RegReqFull, error) {
 	var deletedReq models.RegReqFull
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`DELETE FROM registration_requests WHERE id = $1
 		RETURNING id, user_id, type, status, create_time, message;`,
 		reqID,
@@ -259,10 +396,10 @@ func (pr *postgresqlRepository) DeleteRegReq(ctx context.Context, reqID uint64)
 
 func (pr *postgresqlRepository) FailedRegReq(ctx context.Context, managerID uint64, failedReq models.FailedReq) error {
 	var updateReqID uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE registration_requests
 		SET (manager_id, status, message) = ($2, 'failed', $3)
-		WHERE id = $1
+		WHERE id = $1 AND status <> 'failed'
 		RETURNING id;`,
 		failedReq.ReqId,
 		managerID,
@@ -274,16 +411,31 @@ func (pr *postgresqlRepository) FailedRegReq(ctx context.Context, managerID uint
 	if err != nil {
 		return err
 	}
+
+	for _, issue := range failedReq.Issues {
+		_, err = pr.db(ctx).Exec(
+			`INSERT INTO registration_request_issues (req_id, field, document, reason_code, comment)
+			VALUES ($1, $2, $3, $4, $5);`,
+			failedReq.ReqId,
+			issue.Field,
+			issue.Document,
+			issue.ReasonCode,
+			issue.Comment,
+		)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (pr *postgresqlRepository) FixRegReq(ctx context.Context, reqID uint64) error {
 	var updatedPassport uint64
 	now := time.Now().Unix()
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE registration_requests
 		SET (status, create_time) = ('pending', $2)
-		WHERE id = $1
+		WHERE id = $1 AND status = 'failed'
 		RETURNING id;`,
 		reqID,
 		now,
@@ -291,8 +443,652 @@ func (pr *postgresqlRepository) FixRegReq(ctx context.Context, reqID uint64) err
 		&updatedPassport,
 	)
 
+	if err != nil {
+		return err
+	}
+
+	// issues are actual only until parent fixes request
+	_, err = pr.db(ctx).Exec(
+		`DELETE FROM registration_request_issues WHERE req_id = $1;`,
+		reqID,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *postgresqlRepository) AddRegReqHistory(ctx context.Context, record models.RegReqHistory) error {
+	var id uint64
+	now := time.Now().Unix()
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO registration_requests_history (req_id, user_id, manager_id, type, action, message, req_create_time, create_time)
+		VALUES ($1, $2, NULLIF($3::bigint, 0), $4, $5, $6, $7, $8)
+		RETURNING id;`,
+		record.ReqID,
+		record.UserID,
+		record.ManagerID,
+		record.Type,
+		record.Action,
+		record.Message,
+		record.ReqCreateTime,
+		now,
+	).Scan(
+		&id,
+	)
+
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// GetArchivedRegReq restores request deleted after completion from its last history record
+func (pr *postgresqlRepository) GetArchivedRegReq(ctx context.Context, reqID uint64) (models.RegReqFull, error) {
+	var req models.RegReqFull
+	err := pr.db(ctx).QueryRow(
+		`SELECT req_id, user_id, COALESCE(manager_id, 0), type, req_create_time
+		FROM registration_requests_history
+		WHERE req_id = $1
+		ORDER BY create_time DESC, id DESC
+		LIMIT 1;`,
+		reqID,
+	).Scan(
+		&req.ID,
+		&req.UserID,
+		&req.ManagerID,
+		&req.Type,
+		&req.CreateTime,
+	)
+	if err != nil {
+		return models.RegReqFull{}, err
+	}
+	return req, nil
+}
+
+func (pr *postgresqlRepository) GetRegReqHistoryByUser(ctx context.Context, uid uint64) ([]models.RegReqHistory, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT
+			req_id,
+			user_id,
+			COALESCE(manager_id, 0),
+			type,
+			action,
+			COALESCE(message, ''),
+			req_create_time,
+			create_time
+		FROM registration_requests_history
+		WHERE user_id = $1
+		ORDER BY create_time, id;`,
+		uid,
+	)
+	if err != nil {
+		return []models.RegReqHistory{}, err
+	}
+	defer rows.Close()
+
+	history := []models.RegReqHistory{}
+	var record models.RegReqHistory
+	for rows.Next() {
+		err := rows.Scan(
+			&record.ReqID,
+			&record.UserID,
+			&record.ManagerID,
+			&record.Type,
+			&record.Action,
+			&record.Message,
+			&record.ReqCreateTime,
+			&record.CreateTime,
+		)
+		if err != nil {
+			return []models.RegReqHistory{}, err
+		}
+		history = append(history, record)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.RegReqHistory{}, err
+	}
+	return history, nil
+}
+
+func (pr *postgresqlRepository) GetRegReqStats(ctx context.Context, filter models.RegReqStatsFilter) (models.RegReqStats, error) {
+	stats := models.RegReqStats{
+		From: filter.From,
+		To:   filter.To,
+	}
+
+	// pending queue is a snapshot, so it doesn't depend on date range
+	rows, err := pr.db(ctx).Query(
+		`SELECT type, COUNT(*)
+		FROM registration_requests
+		WHERE status = 'pending'
+		GROUP BY type
+		ORDER BY type;`,
+	)
+	if err != nil {
+		return models.RegReqStats{}, err
+	}
+	var pending models.PendingRegReqStat
+	for rows.Next() {
+		err := rows.Scan(
+			&pending.Type,
+			&pending.Count,
+		)
+		if err != nil {
+			rows.Close()
+			return models.RegReqStats{}, err
+		}
+		stats.Pending = append(stats.Pending, pending)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return models.RegReqStats{}, err
+	}
+
+	// request create time is reset on fix, so processing time is counted from first creation in history
+	err = pr.db(ctx).QueryRow(
+		`SELECT
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY h.create_time - COALESCE(c.create_time, h.req_create_time)), 0),
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY h.create_time - COALESCE(c.create_time, h.req_create_time)), 0)
+		FROM registration_requests_history AS h
+		LEFT JOIN (
+			SELECT req_id, MIN(create_time) AS create_time
+			FROM registration_requests_history
+			WHERE action = 'created'
+			GROUP BY req_id
+		) AS c ON (c.req_id = h.req_id)
+		WHERE h.action IN ('completed', 'failed')
+			AND h.create_time BETWEEN $1 AND $2
+			AND ($3::bigint = 0 OR h.manager_id = $3);`,
+		filter.From,
+		filter.To,
+		filter.ManagerID,
+	).Scan(
+		&stats.MedianProcessingTime,
+		&stats.P90ProcessingTime,
+	)
+	if err != nil {
+		return models.RegReqStats{}, err
+	}
+
+	rows, err = pr.db(ctx).Query(
+		`SELECT
+			us.id,
+			us.first_name,
+			us.second_name,
+			us.last_name,
+			us.role,
+			us.email,
+			us.phone,
+			to_char(to_timestamp(h.create_time), 'YYYY-MM-DD') AS day,
+			COUNT(*) FILTER (WHERE h.action = 'completed'),
+			COUNT(*) FILTER (WHERE h.action = 'failed')
+		FROM registration_requests_history AS h
+		JOIN users AS us ON (us.id = h.manager_id)
+		WHERE h.action IN ('completed', 'failed')
+			AND h.create_time BETWEEN $1 AND $2
+			AND ($3::bigint = 0 OR h.manager_id = $3)
+		GROUP BY us.id, day
+		ORDER BY day, us.id;`,
+		filter.From,
+		filter.To,
+		filter.ManagerID,
+	)
+	if err != nil {
+		return models.RegReqStats{}, err
+	}
+	var decisions models.ManagerDecisionsStat
+	for rows.Next() {
+		err := rows.Scan(
+			&decisions.Manager.ID,
+			&decisions.Manager.FirstName,
+			&decisions.Manager.SecondName,
+			&decisions.Manager.LastName,
+			&decisions.Manager.Role,
+			&decisions.Manager.Email,
+			&decisions.Manager.Phone,
+			&decisions.Day,
+			&decisions.Approved,
+			&decisions.Failed,
+		)
+		if err != nil {
+			rows.Close()
+			return models.RegReqStats{}, err
+		}
+		stats.Decisions = append(stats.Decisions, decisions)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return models.RegReqStats{}, err
+	}
+
+	// fixes are made by user, so for manager only requests handled by the manager are counted
+	err = pr.db(ctx).QueryRow(
+		`SELECT COALESCE(AVG(CASE WHEN fixes > 1 THEN 1 ELSE 0 END), 0)::float8
+		FROM (
+			SELECT req_id, COUNT(*) FILTER (WHERE action = 'fixed') AS fixes
+			FROM registration_requests_history
+			WHERE create_time BETWEEN $1 AND $2
+			GROUP BY req_id
+			HAVING $3::bigint = 0 OR bool_or(manager_id = $3)
+		) AS r;`,
+		filter.From,
+		filter.To,
+		filter.ManagerID,
+	).Scan(
+		&stats.MultiFixShare,
+	)
+	if err != nil {
+		return models.RegReqStats{}, err
+	}
+
+	return stats, nil
+}
+
+func (pr *postgresqlRepository) CreateRegReqMessage(ctx context.Context, authorID uint64, msgReq models.RegReqMessageReq) (models.RegReqMessage, error) {
+	msg := models.RegReqMessage{
+		ReqID:       msgReq.ReqID,
+		Body:        msgReq.Body,
+		Internal:    msgReq.Internal,
+		Attachments: msgReq.Attachments,
+		CreateTime:  uint64(time.Now().Unix()),
+		Receipts:    models.MessageReceiptList{},
+	}
+	if msg.Attachments == nil {
+		msg.Attachments = []string{}
+	}
+	err := pr.db(ctx).QueryRow(
+		`WITH m AS (
+			INSERT INTO registration_request_messages (req_id, author_id, author_role, body, internal, attachments, create_time)
+			SELECT $1, us.id, us.role, $3, $4, $5, $6
+			FROM users AS us
+			WHERE us.id = $2
+			RETURNING id, author_id
+		)
+		SELECT m.id, us.id, us.first_name, us.second_name, us.last_name, us.role, us.email, us.phone
+		FROM m
+		JOIN users AS us ON (us.id = m.author_id);`,
+		msg.ReqID,
+		authorID,
+		msg.Body,
+		msg.Internal,
+		msg.Attachments,
+		msg.CreateTime,
+	).Scan(
+		&msg.ID,
+		&msg.Author.ID,
+		&msg.Author.FirstName,
+		&msg.Author.SecondName,
+		&msg.Author.LastName,
+		&msg.Author.Role,
+		&msg.Author.Email,
+		&msg.Author.Phone,
+	)
+	if err != nil {
+		return models.RegReqMessage{}, err
+	}
+	return msg, nil
+}
+
+func (pr *postgresqlRepository) GetRegReqMessages(ctx context.Context, reqID uint64, withInternal bool) ([]models.RegReqMessage, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT
+			m.id,
+			m.req_id,
+			COALESCE(us.id, 0),
+			COALESCE(us.first_name, ''),
+			COALESCE(us.second_name, ''),
+			COALESCE(us.last_name, ''),
+			m.author_role,
+			COALESCE(us.email, ''),
+			COALESCE(us.phone, ''),
+			m.body,
+			m.internal,
+			m.attachments,
+			m.create_time,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'user_id', mr.user_id,
+					'read_time', mr.read_time
+				) ORDER BY mr.read_time)
+				FROM registration_request_message_reads AS mr
+				WHERE mr.message_id = m.id
+			), '[]')::text
+		FROM registration_request_messages AS m
+		LEFT JOIN users AS us ON (us.id = m.author_id)
+		WHERE m.req_id = $1 AND ($2::boolean OR NOT m.internal)
+		ORDER BY m.create_time, m.id;`,
+		reqID,
+		withInternal,
+	)
+	if err != nil {
+		return []models.RegReqMessage{}, err
+	}
+	defer rows.Close()
+
+	msgList := []models.RegReqMessage{}
+	var msg models.RegReqMessage
+	var receipts string
+	for rows.Next() {
+		err := rows.Scan(
+			&msg.ID,
+			&msg.ReqID,
+			&msg.Author.ID,
+			&msg.Author.FirstName,
+			&msg.Author.SecondName,
+			&msg.Author.LastName,
+			&msg.Author.Role,
+			&msg.Author.Email,
+			&msg.Author.Phone,
+			&msg.Body,
+			&msg.Internal,
+			&msg.Attachments,
+			&msg.CreateTime,
+			&receipts,
+		)
+		if err != nil {
+			return []models.RegReqMessage{}, err
+		}
+		msg.Receipts = nil
+		err = msg.Receipts.UnmarshalJSON([]byte(receipts))
+		if err != nil {
+			return []models.RegReqMessage{}, err
+		}
+		msgList = append(msgList, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.RegReqMessage{}, err
+	}
+	return msgList, nil
+}
+
+func (pr *postgresqlRepository) MarkRegReqMessagesRead(ctx context.Context, reqID uint64, uid uint64, withInternal bool) error {
+	_, err := pr.db(ctx).Exec(
+		`INSERT INTO registration_request_message_reads (message_id, user_id, read_time)
+		SELECT m.id, $2, $3
+		FROM registration_request_messages AS m
+		WHERE m.req_id = $1 AND m.author_id IS DISTINCT FROM $2 AND ($4::boolean OR NOT m.internal)
+		ON CONFLICT DO NOTHING;`,
+		reqID,
+		uid,
+		time.Now().Unix(),
+		withInternal,
+	)
+	return err
+}
+
+// pending request can be claimed only if nobody else has claimed it
+func (pr *postgresqlRepository) ClaimRegReq(ctx context.Context, managerID uint64, reqID uint64) error {
+	var claimedReqID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE registration_requests
+		SET manager_id = $2
+		WHERE id = $1 AND status = 'pending' AND (manager_id IS NULL OR manager_id = $2)
+		RETURNING id;`,
+		reqID,
+		managerID,
+	).Scan(
+		&claimedReqID,
+	)
+	return err
+}
+
+// EscalateRegReq moves pending request to admins queue
+func (pr *postgresqlRepository) EscalateRegReq(ctx context.Context, escalateReq models.EscalateReq) error {
+	var escalatedReqID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE registration_requests
+		SET (status, escalation_reason) = ('escalated', $2)
+		WHERE id = $1 AND status = 'pending'
+		RETURNING id;`,
+		escalateReq.ReqID,
+		escalateReq.Reason,
+	).Scan(
+		&escalatedReqID,
+	)
+	return err
+}
+
+// AssignRegReq puts request in pending status to manager or to common queue if managerID is 0,
+// request is changed only if it is in fromStatus
+func (pr *postgresqlRepository) AssignRegReq(ctx context.Context, reqID uint64, managerID uint64, fromStatus string) error {
+	var assignedReqID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE registration_requests
+		SET (manager_id, status, escalation_reason) = (NULLIF($2::bigint, 0), 'pending', '')
+		WHERE id = $1 AND status = $3
+		RETURNING id;`,
+		reqID,
+		managerID,
+		fromStatus,
+	).Scan(
+		&assignedReqID,
+	)
+	return err
+}
+
+// names are compared in lower case with "ё" replaced and spaces collapsed
+const childFullNameExpr = `regexp_replace(translate(lower(concat_ws(' ', %[1]s.last_name, %[1]s.first_name, %[1]s.second_name)), 'ё', 'е'), '\s+', ' ', 'g')`
+
+// minimal pg_trgm similarity of full names for possible duplicate
+const duplicateNameSimilarity = 0.6
+
+// DetectChildDuplicates saves children with similar name and birth date or with the same passport
+// as possible duplicates of child with uid
+func (pr *postgresqlRepository) DetectChildDuplicates(ctx context.Context, uid uint64) error {
+	nameSimilarity := fmt.Sprintf("similarity(%s, %s)",
+		fmt.Sprintf(childFullNameExpr, "nu"),
+		fmt.Sprintf(childFullNameExpr, "du"))
+	// passports are encrypted deterministically, so equal ones have equal ciphertexts
+	passportMatch := `(nc.passport <> '' AND dc.passport = nc.passport)`
+	_, err := pr.db(ctx).Exec(
+		`INSERT INTO child_duplicates (child_id, duplicate_id, similarity, passport_match, create_time)
+		SELECT
+			LEAST(nc.user_id, dc.user_id),
+			GREATEST(nc.user_id, dc.user_id),
+			`+nameSimilarity+`,
+			`+passportMatch+`,
+			$2
+		FROM children AS nc
+		JOIN users AS nu ON (nu.id = nc.user_id)
+		JOIN children AS dc ON (dc.user_id <> nc.user_id AND (
+			(nc.birth_date <> 0 AND dc.birth_date BETWEEN nc.birth_date - 86399 AND nc.birth_date + 86399)
+			OR `+passportMatch+`))
+		JOIN users AS du ON (du.id = dc.user_id)
+		WHERE nc.user_id = $1
+			AND (`+passportMatch+` OR `+nameSimilarity+` >= $3)
+		ON CONFLICT (child_id, duplicate_id) DO UPDATE
+		SET passport_match = child_duplicates.passport_match OR EXCLUDED.passport_match;`,
+		uid,
+		time.Now().Unix(),
+		duplicateNameSimilarity,
+	)
+	return err
+}
+
+// FindChildrenByName returns user ids of children with the same normalized full name
+// born on the same day, fullName must be normalized like childFullNameExpr
+func (pr *postgresqlRepository) FindChildrenByName(ctx context.Context, fullName string, birthDate uint64) ([]uint64, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT c.user_id
+		FROM children AS c
+		JOIN users AS u ON (u.id = c.user_id)
+		WHERE btrim(`+fmt.Sprintf(childFullNameExpr, "u")+`) = $1
+			AND c.birth_date BETWEEN $2::bigint - 86399 AND $2::bigint + 86399
+		ORDER BY c.user_id;`,
+		fullName,
+		birthDate,
+	)
+	if err != nil {
+		return []uint64{}, err
+	}
+	defer rows.Close()
+
+	uids := []uint64{}
+	for rows.Next() {
+		var uid uint64
+		err = rows.Scan(&uid)
+		if err != nil {
+			return []uint64{}, err
+		}
+		uids = append(uids, uid)
+	}
+	if err := rows.Err(); err != nil {
+		return []uint64{}, err
+	}
+	return uids, nil
+}
+
+const childDuplicateColumns = `
+			cd.id,
+			cu.id,
+			cu.first_name,
+			cu.second_name,
+			COALESCE(cu.last_name, ''),
+			cu.email,
+			c.birth_date,
+			c.done_stage,
+			du.id,
+			du.first_name,
+			du.second_name,
+			COALESCE(du.last_name, ''),
+			du.email,
+			d.birth_date,
+			d.done_stage,
+			cd.similarity,
+			cd.passport_match,
+			cd.status,
+			cd.create_time
+		FROM child_duplicates AS cd
+		JOIN users AS cu ON (cu.id = cd.child_id)
+		JOIN children AS c ON (c.user_id = cd.child_id)
+		JOIN users AS du ON (du.id = cd.duplicate_id)
+		JOIN children AS d ON (d.user_id = cd.duplicate_id)`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanChildDuplicate(row rowScanner) (models.ChildDuplicate, error) {
+	var duplicate models.ChildDuplicate
+	err := row.Scan(
+		&duplicate.ID,
+		&duplicate.Child.UserID,
+		&duplicate.Child.FirstName,
+		&duplicate.Child.SecondName,
+		&duplicate.Child.LastName,
+		&duplicate.Child.Email,
+		&duplicate.Child.BirthDate,
+		&duplicate.Child.DoneStage,
+		&duplicate.Duplicate.UserID,
+		&duplicate.Duplicate.FirstName,
+		&duplicate.Duplicate.SecondName,
+		&duplicate.Duplicate.LastName,
+		&duplicate.Duplicate.Email,
+		&duplicate.Duplicate.BirthDate,
+		&duplicate.Duplicate.DoneStage,
+		&duplicate.Similarity,
+		&duplicate.PassportMatch,
+		&duplicate.Status,
+		&duplicate.CreateTime,
+	)
+	return duplicate, err
+}
+
+func (pr *postgresqlRepository) GetChildDuplicates(ctx context.Context, status string) ([]models.ChildDuplicate, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT`+childDuplicateColumns+`
+		WHERE cd.status = $1
+		ORDER BY cd.passport_match DESC, cd.similarity DESC, cd.id;`,
+		status,
+	)
+	if err != nil {
+		return []models.ChildDuplicate{}, err
+	}
+	defer rows.Close()
+
+	duplicates := []models.ChildDuplicate{}
+	for rows.Next() {
+		duplicate, err := scanChildDuplicate(rows)
+		if err != nil {
+			return []models.ChildDuplicate{}, err
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.ChildDuplicate{}, err
+	}
+	return duplicates, nil
+}
+
+func (pr *postgresqlRepository) GetChildDuplicateByID(ctx context.Context, id uint64) (models.ChildDuplicate, error) {
+	return scanChildDuplicate(pr.db(ctx).QueryRow(
+		`SELECT`+childDuplicateColumns+`
+		WHERE cd.id = $1;`,
+		id,
+	))
+}
+
+func (pr *postgresqlRepository) DismissChildDuplicate(ctx context.Context, id uint64, managerID uint64) error {
+	var dismissedID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE child_duplicates
+		SET (status, manager_id) = ('dismissed', $2)
+		WHERE id = $1 AND status = 'open'
+		RETURNING id;`,
+		id,
+		managerID,
+	).Scan(
+		&dismissedID,
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) CreateRegReqChanges(ctx context.Context, reqID uint64, changes []models.RegReqChange) error {
+	for _, change := range changes {
+		_, err := pr.db(ctx).Exec(
+			`INSERT INTO registration_request_changes (req_id, field, old_value, new_value)
+			VALUES ($1, $2, $3, $4);`,
+			reqID,
+			change.Field,
+			change.OldValue,
+			change.NewValue,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pr *postgresqlRepository) GetRegReqChanges(ctx context.Context, reqID uint64) ([]models.RegReqChange, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT field, old_value, new_value
+		FROM registration_request_changes
+		WHERE req_id = $1
+		ORDER BY id;`,
+		reqID,
+	)
+	if err != nil {
+		return []models.RegReqChange{}, err
+	}
+	defer rows.Close()
+
+	changes := []models.RegReqChange{}
+	for rows.Next() {
+		var change models.RegReqChange
+		err := rows.Scan(
+			&change.Field,
+			&change.OldValue,
+			&change.NewValue,
+		)
+		if err != nil {
+			return []models.RegReqChange{}, err
+		}
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.RegReqChange{}, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/crypt"
+	"github.com/jackc/pgx"
+)
+
+// limit is taken from children table
+const maxChildDataLen = 128
+
+// child fields which can be changed by data change request, they are saved with UpdateChild
+var childChangeFields = map[string]func(*models.Child) *string{
+	"passport":              func(c *models.Child) *string { return &c.Passport },
+	"place_of_residence":    func(c *models.Child) *string { return &c.PlaceOfResidence },
+	"place_of_registration": func(c *models.Child) *string { return &c.PlaceOfRegistration },
+}
+
+// CreateChildDataChangeReq proposes new values of child data which was verified during registration
+func (rru *regReqUsecase) CreateChildDataChangeReq(ctx context.Context, parent models.Parent, changeReq models.ChildDataChangeReq) (models.RegReqFull, int, error) {
+	child, err := rru.userPsql.GetChildByUID(ctx, changeReq.ChildID)
+	if err == pgx.ErrNoRows {
+		return models.RegReqFull{}, http.StatusNotFound, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: child not found")
+	} else if err != nil {
+		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to get child data with err: %s", err)
+	}
+	isParent, err := rru.userPsql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to check parent-child pair with err: %s", err)
+	}
+	if !isParent {
+		return models.RegReqFull{}, http.StatusForbidden, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: current child isn't child of current parent")
+	}
+	// before that data is changed with registration stages
+	if child.DoneStage < models.ThirdStage {
+		return models.RegReqFull{}, http.StatusConflict, newStageError(http.StatusConflict,
+			"child data can be changed by request only after stage %d is approved", models.ThirdStage)
+	}
+
+	reqs, err := rru.psql.GetRegRequestList(ctx, child.UserID)
+	if err != nil {
+		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to get child's requests with err: %s", err)
+	}
+	for _, existsReq := range reqs {
+		if existsReq.Type == models.ChildDataChange && existsReq.Status != FailedReqStatus {
+			return models.RegReqFull{}, http.StatusConflict, newStageError(http.StatusConflict,
+				"child already has data change request in progress")
+		}
+	}
+
+	changes := []models.RegReqChange{}
+	for field, value := range changeReq.Changes {
+		current, ok := childChangeFields[field]
+		if !ok {
+			return models.RegReqFull{}, http.StatusBadRequest, newStageError(http.StatusBadRequest,
+				"field %s can't be changed by request", field)
+		}
+		value = strings.TrimSpace(value)
+		if value == "" || utf8.RuneCountInString(value) > maxChildDataLen {
+			return models.RegReqFull{}, http.StatusBadRequest, newStageError(http.StatusBadRequest,
+				"invalid value of field %s", field)
+		}
+
+		oldValue := *current(&child)
+		if field == "passport" {
+			storedPassport := ""
+			if oldValue != "" {
+				storedPassport, err = crypt.Decrypt(oldValue)
+				if err != nil {
+					return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to decrypt child passport with err: %s", err)
+				}
+			}
+			if storedPassport == value {
+				continue
+			}
+			value, err = crypt.Encrypt(value)
+			if err != nil {
+				return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to encrypt child passport with err: %s", err)
+			}
+		} else if oldValue == value {
+			continue
+		}
+		changes = append(changes, models.RegReqChange{
+			Field:    field,
+			OldValue: oldValue,
+			NewValue: value,
+		})
+	}
+	if len(changes) == 0 {
+		return models.RegReqFull{}, http.StatusBadRequest, newStageError(http.StatusBadRequest,
+			"request doesn't change child data")
+	}
+
+	// comment and evidence documents are the first message of request
+	msgReq := models.RegReqMessageReq{
+		Body:        strings.TrimSpace(changeReq.Comment),
+		Attachments: changeReq.Documents,
+	}
+	withMessage := msgReq.Body != "" || len(msgReq.Attachments) != 0
+	if withMessage {
+		if err := validateMessage(msgReq); err != nil {
+			return models.RegReqFull{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: %s", err)
+		}
+		if len(msgReq.Attachments) > maxMessageAttachments {
+			return models.RegReqFull{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: too many documents %d", len(msgReq.Attachments))
+		}
+		status, err := rru.checkUploadedFiles(ctx, models.User{ID: child.UserID, Role: models.ChildRole}, msgReq.Attachments)
+		if err != nil {
+			return models.RegReqFull{}, status, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: %s", err)
+		}
+	}
+
+	var createdReq models.RegReqFull
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		var err error
+		createdReq, err = rru.psql.CreateRegReq(ctx, child.UserID, models.ChildDataChange)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to create request with err: %s", err)
+		}
+		err = rru.psql.CreateRegReqChanges(ctx, createdReq.ID, changes)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to save changes with err: %s", err)
+		}
+		if withMessage {
+			msgReq.ReqID = createdReq.ID
+			_, err = rru.psql.CreateRegReqMessage(ctx, parent.UserID, msgReq)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to save comment with err: %s", err)
+			}
+		}
+
+		err = rru.addHistory(ctx, createdReq, 0, CreatedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChildDataChangeReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return models.RegReqFull{}, status, err
+	}
+
+	rru.notifyManagersRegReqCreated(ctx, createdReq)
+	rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, createdReq, 0)
+
+	return createdReq, http.StatusOK, nil
+}
+
+// getRegReqChanges returns changes with decrypted passport values.
+// Passport verification request has changes when parent's name is changed
+func (rru *regReqUsecase) getRegReqChanges(ctx context.Context, req models.RegReqFull) ([]models.RegReqChange, int, error) {
+	if req.Type != models.ChildDataChange && req.Type != models.ParentPassportVerification {
+		return []models.RegReqChange{}, http.StatusBadRequest, fmt.Errorf("request isn't data change request")
+	}
+	changes, err := rru.psql.GetRegReqChanges(ctx, req.ID)
+	if err != nil {
+		return []models.RegReqChange{}, http.StatusInternalServerError, fmt.Errorf("failed to get changes with err: %s", err)
+	}
+	for i := range changes {
+		if changes[i].Field != "passport" {
+			continue
+		}
+		for _, value := range []*string{&changes[i].OldValue, &changes[i].NewValue} {
+			if *value == "" {
+				continue
+			}
+			*value, err = crypt.Decrypt(*value)
+			if err != nil {
+				return []models.RegReqChange{}, http.StatusInternalServerError, fmt.Errorf("failed to decrypt passport with err: %s", err)
+			}
+		}
+	}
+	return changes, http.StatusOK, nil
+}
+
+// GetRegReqChanges shows managers values before and after data change
+func (rru *regReqUsecase) GetRegReqChanges(ctx context.Context, reqID uint64) ([]models.RegReqChange, int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	if err == pgx.ErrNoRows {
+		return []models.RegReqChange{}, http.StatusNotFound, fmt.Errorf("RegReqUsecase.GetRegReqChanges: request not found")
+	} else if err != nil {
+		return []models.RegReqChange{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetRegReqChanges: failed to get request with err: %s", err)
+	}
+	changes, status, err := rru.getRegReqChanges(ctx, req)
+	if err != nil {
+		return []models.RegReqChange{}, status, fmt.Errorf("RegReqUsecase.GetRegReqChanges: %s", err)
+	}
+	return changes, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetParentRegReqChanges(ctx context.Context, parent models.Parent, reqID uint64) ([]models.RegReqChange, int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	if err == pgx.ErrNoRows {
+		return []models.RegReqChange{}, http.StatusNotFound, fmt.Errorf("RegReqUsecase.GetParentRegReqChanges: request not found")
+	} else if err != nil {
+		return []models.RegReqChange{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetParentRegReqChanges: failed to get request with err: %s", err)
+	}
+	status, err := rru.checkParentReqAccess(ctx, parent, req)
+	if err != nil {
+		return []models.RegReqChange{}, status, fmt.Errorf("RegReqUsecase.GetParentRegReqChanges: %s", err)
+	}
+	changes, status, err := rru.getRegReqChanges(ctx, req)
+	if err != nil {
+		return []models.RegReqChange{}, status, fmt.Errorf("RegReqUsecase.GetParentRegReqChanges: %s", err)
+	}
+	return changes, http.StatusOK, nil
+}
+
+// changesAuditDetails lists changed values, passport is kept encrypted as it is stored
+func changesAuditDetails(changes []models.RegReqChange) string {
+	details := make([]string, 0, len(changes))
+	for _, change := range changes {
+		details = append(details, fmt.Sprintf("%s: %q -> %q", change.Field, change.OldValue, change.NewValue))
+	}
+	return strings.Join(details, "; ")
+}
+
+// applyChildDataChange saves approved values, it fails if child data
+// has been changed in other way since request creation
+func (rru *regReqUsecase) applyChildDataChange(ctx context.Context, managerID uint64, req models.RegReqFull) (int, error) {
+	child, err := rru.userPsql.GetChildByUID(ctx, req.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyChildDataChange: failed to get child data with err: %s", err)
+	}
+	changes, err := rru.psql.GetRegReqChanges(ctx, req.ID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyChildDataChange: failed to get changes with err: %s", err)
+	}
+
+	for _, change := range changes {
+		current, ok := childChangeFields[change.Field]
+		if !ok {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyChildDataChange: unknown changed field %s", change.Field)
+		}
+		value := current(&child)
+		if *value != change.OldValue {
+			return http.StatusConflict, newStageError(http.StatusConflict,
+				"field %s has been changed since request creation, request must be failed", change.Field)
+		}
+		*value = change.NewValue
+	}
+
+	err = rru.userPsql.UpdateChild(ctx, child)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyChildDataChange: failed to update child data with err: %s", err)
+	}
+
+	err = rru.psql.DetectChildDuplicates(ctx, child.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyChildDataChange: failed to detect duplicates with err: %s", err)
+	}
+
+	// changes are deleted with approved request
+	err = rru.auditPsql.AddRecord(ctx, models.AuditRecord{
+		ActorID:    managerID,
+		Action:     models.ChildDataChangedAudit,
+		EntityType: models.ChildRole.String(),
+		EntityID:   child.UserID,
+		Details:    changesAuditDetails(changes),
+	})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyChildDataChange: failed to add audit record with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
+	"github.com/jackc/pgx"
+)
+
+// GetChildDuplicates returns open possible duplicates, they are detected when child data is saved
+func (rru *regReqUsecase) GetChildDuplicates(ctx context.Context) ([]models.ChildDuplicate, int, error) {
+	duplicates, err := rru.psql.GetChildDuplicates(ctx, models.OpenDuplicateStatus)
+	if err != nil {
+		return []models.ChildDuplicate{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetChildDuplicates: failed to get duplicates with err: %s", err)
+	}
+	return duplicates, http.StatusOK, nil
+}
+
+// DismissChildDuplicate marks pair as different children, so it isn't shown anymore
+func (rru *regReqUsecase) DismissChildDuplicate(ctx context.Context, managerID uint64, duplicateID uint64) (int, error) {
+	err := rru.psql.DismissChildDuplicate(ctx, duplicateID, managerID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.DismissChildDuplicate: open duplicate not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.DismissChildDuplicate: failed to dismiss duplicate with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// MergeChildDuplicate moves parents and missing data of merged child to kept one
+// and deletes merged child with its requests
+func (rru *regReqUsecase) MergeChildDuplicate(ctx context.Context, managerID uint64, mergeReq models.MergeChildrenReq) (int, error) {
+	duplicate, err := rru.psql.GetChildDuplicateByID(ctx, mergeReq.DuplicateID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: duplicate not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to get duplicate with err: %s", err)
+	}
+	if duplicate.Status != models.OpenDuplicateStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: duplicate has been already dismissed")
+	}
+
+	var mergedUID uint64
+	switch mergeReq.KeepChildID {
+	case duplicate.Child.UserID:
+		mergedUID = duplicate.Duplicate.UserID
+	case duplicate.Duplicate.UserID:
+		mergedUID = duplicate.Child.UserID
+	default:
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: kept child isn't in duplicate pair")
+	}
+
+	keptChild, err := rru.userPsql.GetChildByUID(ctx, mergeReq.KeepChildID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to get kept child with err: %s", err)
+	}
+	mergedChild, err := rru.userPsql.GetChildByUID(ctx, mergedUID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to get merged child with err: %s", err)
+	}
+	// merged child can already have credentials after approved stages
+	if mergedChild.DoneStage > keptChild.DoneStage {
+		return http.StatusConflict, newStageError(http.StatusConflict,
+			"child with further registration stage must be kept")
+	}
+	mergedReqs, err := rru.psql.GetRegRequestList(ctx, mergedUID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to get merged child's requests with err: %s", err)
+	}
+
+	// passport is moved encrypted as it is stored
+	if keptChild.Passport == "" {
+		keptChild.Passport = mergedChild.Passport
+	}
+	if keptChild.PlaceOfResidence == "" {
+		keptChild.PlaceOfResidence = mergedChild.PlaceOfResidence
+	}
+	if keptChild.PlaceOfRegistration == "" {
+		keptChild.PlaceOfRegistration = mergedChild.PlaceOfRegistration
+	}
+	moveDir := keptChild.DirPath == "" && mergedChild.DirPath != ""
+
+	var recipients []models.NotificationRecipient
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		// recipients are resolved by parent-child links which are deleted with merged child
+		var err error
+		recipients, err = rru.userPsql.GetNotificationRecipients(ctx, mergedUID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to get notification recipients with err: %s", err)
+		}
+
+		err = rru.userPsql.CopyParentChildLinks(ctx, mergedChild.ID, keptChild.ID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to copy parent-child links with err: %s", err)
+		}
+		err = rru.userPsql.UpdateChild(ctx, keptChild)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to update kept child with err: %s", err)
+		}
+		if moveDir {
+			_, err = rru.userPsql.UpdateChildDirPath(ctx, keptChild.UserID, mergedChild.DirPath)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to move documents with err: %s", err)
+			}
+		}
+
+		for _, req := range mergedReqs {
+			err = rru.addHistory(ctx, req, managerID, MergedReqAction, fmt.Sprintf("into child %d", keptChild.UserID))
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to add request history with err: %s", err)
+			}
+		}
+		err = rru.auditPsql.AddRecord(ctx, models.AuditRecord{
+			ActorID:    managerID,
+			Action:     models.ChildMergedAudit,
+			EntityType: models.ChildRole.String(),
+			EntityID:   keptChild.UserID,
+			Details:    fmt.Sprintf("child %d merged", mergedUID),
+		})
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to add audit record with err: %s", err)
+		}
+
+		// links, requests and duplicate pairs of merged child are removed by cascade
+		_, err = rru.userPsql.DeleteUser(ctx, mergedUID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to delete merged child with err: %s", err)
+		}
+		// kept child can get passport of merged one
+		err = rru.psql.DetectChildDuplicates(ctx, keptChild.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.MergeChildDuplicate: failed to detect duplicates with err: %s", err)
+		}
+
+		// documents of merged child are moved to kept child's dir
+		if !moveDir && mergedChild.DirPath != "" {
+			database.AfterCommit(ctx, func(ctx context.Context) {
+				err := rru.fm.MoveDir(mergedChild.DirPath, keptChild.DirPath)
+				if err != nil {
+					rru.logger.Errorf("RegReqUsecase.MergeChildDuplicate: failed to move documents of child %d with err: %s", mergedUID, err)
+				}
+			})
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	for _, req := range mergedReqs {
+		rru.publishRegReqEventTo(ctx, models.RegReqCancelledEvent, req, managerID, recipients)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+)
+
+// publishRegReqEvent pushes request change to all stream subscribers.
+// request owner and his parents receive event, managers receive all events
+func (rru *regReqUsecase) publishRegReqEvent(ctx context.Context, eventType models.RegReqEventType, req models.RegReqFull, managerID uint64) {
+	recipients, err := rru.userPsql.GetNotificationRecipients(ctx, req.UserID)
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.publishRegReqEvent: failed to get recipients for request %d with err: %s", req.ID, err)
+		return
+	}
+	rru.publishRegReqEventTo(ctx, eventType, req, managerID, recipients)
+}
+
+// publishRegReqEventTo is used when recipients have to be resolved before request owner is deleted
+func (rru *regReqUsecase) publishRegReqEventTo(ctx context.Context, eventType models.RegReqEventType, req models.RegReqFull, managerID uint64, recipients []models.NotificationRecipient) {
+	uids := []uint64{req.UserID}
+	for _, recipient := range recipients {
+		if recipient.User.ID != req.UserID {
+			uids = append(uids, recipient.User.ID)
+		}
+	}
+
+	err := rru.eu.PublishRegReqEvent(ctx, models.RegReqEvent{
+		Type:       eventType,
+		ReqID:      req.ID,
+		UserID:     req.UserID,
+		ManagerID:  managerID,
+		ReqType:    req.Type,
+		CreateTime: uint64(time.Now().Unix()),
+		Recipients: uids,
+	})
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.publishRegReqEvent: request %d: %s", req.ID, err)
+	}
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/crypt"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/xuri/excelize/v2"
+)
+
+// rows are streamed to sheet, so header must be written first
+func writeSheet(f *excelize.File, sheet string, header []interface{}, rows [][]interface{}) error {
+	// new file has empty default sheet which is used for first sheet
+	if index, _ := f.GetSheetIndex("Sheet1"); index != -1 {
+		err := f.SetSheetName("Sheet1", sheet)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err := f.NewSheet(sheet)
+		if err != nil {
+			return err
+		}
+	}
+
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	err = sw.SetColWidth(1, len(header), 20)
+	if err != nil {
+		return err
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	err = sw.SetRow("A1", header, excelize.RowOpts{StyleID: headerStyle})
+	if err != nil {
+		return err
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		err = sw.SetRow(cell, row)
+		if err != nil {
+			return err
+		}
+	}
+	return sw.Flush()
+}
+
+// ExportRegReqs exports the same queues which managers and admins see in lists
+func (rru *regReqUsecase) ExportRegReqs(ctx context.Context, status string) (*excelize.File, int, error) {
+	var reqs []models.RegReqWithUser
+	var err error
+	switch status {
+	case PendingReqStatus:
+		reqs, _, err = rru.GetRegRequestsListAll(ctx)
+	case EscalatedReqStatus:
+		reqs, _, err = rru.GetEscalatedRegReqs(ctx)
+	default:
+		return nil, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ExportRegReqs: unknown status %s", status)
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportRegReqs: %s", err)
+	}
+
+	header := []interface{}{"ID", "Тип", "Статус", "Заявитель", "Роль", "Почта", "Телефон", "Менеджер",
+		"Дата создания", "Дней в очереди", "Непрочитанных сообщений", "Возможных дубликатов", "Причина эскалации", "Комментарий"}
+	rows := make([][]interface{}, 0, len(reqs))
+	for _, req := range reqs {
+		manager := ""
+		if req.Manager != nil {
+			manager = tools.FullName(req.Manager.LastName, req.Manager.FirstName, req.Manager.SecondName)
+		}
+		rows = append(rows, []interface{}{
+			req.ID,
+			req.Type.String(),
+			req.Status,
+			tools.FullName(req.User.LastName, req.User.FirstName, req.User.SecondName),
+			req.User.Role.String(),
+			req.User.Email,
+			req.User.Phone,
+			manager,
+			tools.FormatDate(req.CreateTime),
+			req.TimeInQueue,
+			req.UnreadMessages,
+			req.PossibleDuplicates,
+			req.EscalationReason,
+			req.Message,
+		})
+	}
+
+	f := excelize.NewFile()
+	err = writeSheet(f, "Заявки", header, rows)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportRegReqs: failed to write sheet with err: %s", err)
+	}
+	return f, http.StatusOK, nil
+}
+
+// ExportChildren writes row for every child and parent pair,
+// passports are masked unless user has permission to view them
+func (rru *regReqUsecase) ExportChildren(ctx context.Context, uid uint64) (*excelize.File, int, error) {
+	showPassports, err := rru.userPsql.HasPermission(ctx, uid, models.ViewPassportsPermission)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportChildren: failed to check permission with err: %s", err)
+	}
+	children, err := rru.userPsql.GetChildrenWithParents(ctx)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportChildren: failed to get children with err: %s", err)
+	}
+
+	passport := func(encrypted string) (string, error) {
+		if encrypted == "" {
+			return "", nil
+		}
+		decrypted, err := crypt.Decrypt(encrypted)
+		if err != nil {
+			return "", err
+		}
+		if !showPassports {
+			return tools.MaskPassport(decrypted), nil
+		}
+		return decrypted, nil
+	}
+
+	header := []interface{}{"ID", "ФИО ребенка", "Дата рождения", "Этап", "Почта", "Телефон", "Паспорт",
+		"Адрес проживания", "Адрес регистрации", "Дата регистрации",
+		"ФИО родителя", "Кем приходится", "Почта родителя", "Телефон родителя", "Паспорт родителя"}
+	rows := make([][]interface{}, 0, len(children))
+	for _, child := range children {
+		childPassport, err := passport(child.Child.Passport)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportChildren: failed to decrypt child passport with err: %s", err)
+		}
+		childRow := []interface{}{
+			child.Child.UserID,
+			tools.FullName(child.Child.LastName, child.Child.FirstName, child.Child.SecondName),
+			tools.FormatDate(child.Child.BirthDate),
+			child.Child.DoneStage,
+			child.Child.Email,
+			child.Child.Phone,
+			childPassport,
+			child.Child.PlaceOfResidence,
+			child.Child.PlaceOfRegistration,
+			tools.FormatDate(child.CreateTime),
+		}
+		if len(child.Parents) == 0 {
+			rows = append(rows, childRow)
+			continue
+		}
+		for _, parent := range child.Parents {
+			parentPassport, err := passport(parent.Parent.Passport)
+			if err != nil {
+				return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportChildren: failed to decrypt parent passport with err: %s", err)
+			}
+			row := append([]interface{}{}, childRow...)
+			rows = append(rows, append(row,
+				tools.FullName(parent.Parent.LastName, parent.Parent.FirstName, parent.Parent.SecondName),
+				parent.Relationship,
+				parent.Parent.Email,
+				parent.Parent.Phone,
+				parentPassport,
+			))
+		}
+	}
+
+	f := excelize.NewFile()
+	err = writeSheet(f, "Дети", header, rows)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportChildren: failed to write sheet with err: %s", err)
+	}
+	return f, http.StatusOK, nil
+}
+
+// ExportRegReqStats writes the same stats as stats endpoint to separate sheets
+func (rru *regReqUsecase) ExportRegReqStats(ctx context.Context, filter models.RegReqStatsFilter) (*excelize.File, int, error) {
+	stats, status, err := rru.GetRegReqStats(ctx, filter)
+	if err != nil {
+		return nil, status, fmt.Errorf("RegReqUsecase.ExportRegReqStats: %s", err)
+	}
+
+	f := excelize.NewFile()
+	err = writeSheet(f, "Сводка", []interface{}{"Показатель", "Значение"}, [][]interface{}{
+		{"Начало периода", tools.FormatDate(stats.From)},
+		{"Конец периода", tools.FormatDate(stats.To)},
+		{"Медиана обработки, ч", stats.MedianProcessingTime / 3600},
+		{"90-й перцентиль обработки, ч", stats.P90ProcessingTime / 3600},
+		{"Доля заявок с несколькими исправлениями", stats.MultiFixShare},
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportRegReqStats: failed to write summary with err: %s", err)
+	}
+
+	pending := make([][]interface{}, 0, len(stats.Pending))
+	for _, stat := range stats.Pending {
+		pending = append(pending, []interface{}{stat.Type.String(), stat.Count})
+	}
+	err = writeSheet(f, "Очередь", []interface{}{"Тип", "Ожидают"}, pending)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportRegReqStats: failed to write pending with err: %s", err)
+	}
+
+	decisions := make([][]interface{}, 0, len(stats.Decisions))
+	for _, stat := range stats.Decisions {
+		decisions = append(decisions, []interface{}{
+			stat.Manager.ID,
+			tools.FullName(stat.Manager.LastName, stat.Manager.FirstName, stat.Manager.SecondName),
+			stat.Day,
+			stat.Approved,
+			stat.Failed,
+		})
+	}
+	err = writeSheet(f, "Решения", []interface{}{"ID менеджера", "Менеджер", "День", "Одобрено", "Отклонено"}, decisions)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ExportRegReqStats: failed to write decisions with err: %s", err)
+	}
+	return f, http.StatusOK, nil
+}
@@ -0,0 +1,438 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/hasher"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/mailer"
+	pswdgenerator "github.com/VoyakinH/lokle_backend/internal/pkg/psw_generator"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/jackc/pgx"
+	"github.com/xuri/excelize/v2"
+)
+
+const maxImportRows = 1000
+
+const (
+	maxImportNameLen  = 32
+	maxImportPhoneLen = 16
+)
+
+const importedStudentMessage = "Ученик добавлен из списка школы, заполните, пожалуйста, недостающие данные"
+
+// columns are found by header name or russian title in any order
+var importColumns = []struct {
+	name     string
+	title    string
+	required bool
+}{
+	{"last_name", "Фамилия", true},
+	{"first_name", "Имя", true},
+	{"second_name", "Отчество", false},
+	{"birth_date", "Дата рождения", true},
+	{"email", "Почта", false},
+	{"phone", "Телефон", false},
+	{"parent_email", "Почта родителя", true},
+	{"parent_phone", "Телефон родителя", false},
+	{"parent_last_name", "Фамилия родителя", false},
+	{"parent_first_name", "Имя родителя", false},
+	{"parent_second_name", "Отчество родителя", false},
+	{"relationship", "Кем приходится", false},
+}
+
+// importPlan keeps records found for row, zero ids are created on apply
+type importPlan struct {
+	row       models.StudentImportRow
+	parentUID uint64
+	childUID  uint64
+	linked    bool
+}
+
+// readImportFile returns all rows of xlsx file first sheet or csv file
+func readImportFile(data []byte) ([][]string, error) {
+	// xlsx is zip archive
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fmt.Errorf("file has no sheets")
+		}
+		// raw values keep dates as serial numbers whatever cell format is
+		return f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	}
+
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	// excel with russian locale saves csv with semicolons
+	header := data
+	if end := bytes.IndexByte(data, '\n'); end != -1 {
+		header = data[:end]
+	}
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		reader.Comma = ';'
+	}
+	return reader.ReadAll()
+}
+
+func parseImportHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(importColumns))
+	for i, cell := range header {
+		cell = strings.TrimSpace(cell)
+		for _, column := range importColumns {
+			if strings.EqualFold(cell, column.name) || strings.EqualFold(cell, column.title) {
+				columns[column.name] = i
+			}
+		}
+	}
+	for _, column := range importColumns {
+		if _, ok := columns[column.name]; column.required && !ok {
+			return nil, fmt.Errorf("required column %s not found", column.name)
+		}
+	}
+	return columns, nil
+}
+
+// parseImportDate accepts excel serial date as well as text date
+func parseImportDate(value string) (uint64, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		date, err := excelize.ExcelDateToTime(serial, false)
+		if err != nil {
+			return 0, err
+		}
+		value = date.Format("02.01.2006")
+	}
+	return tools.ParseDate(value)
+}
+
+// normalizeImportName makes full name comparable with names stored in db
+func normalizeImportName(lastName string, firstName string, secondName string) string {
+	name := strings.ReplaceAll(strings.ToLower(lastName+" "+firstName+" "+secondName), "ё", "е")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+func parseImportRow(columns map[string]int, record []string) (models.StudentImportRow, []string) {
+	value := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := models.StudentImportRow{
+		Child: models.Child{
+			Role:       models.ChildRole,
+			FirstName:  value("first_name"),
+			SecondName: value("second_name"),
+			LastName:   value("last_name"),
+			Email:      value("email"),
+			Phone:      value("phone"),
+		},
+		Parent: models.User{
+			Role:       models.ParentRole,
+			FirstName:  value("parent_first_name"),
+			SecondName: value("parent_second_name"),
+			LastName:   value("parent_last_name"),
+			Email:      value("parent_email"),
+			Phone:      value("parent_phone"),
+		},
+		Relationship: value("relationship"),
+	}
+
+	errs := []string{}
+	if row.Child.LastName == "" || row.Child.FirstName == "" {
+		errs = append(errs, "child last name and first name are required")
+	}
+	for _, name := range []string{row.Child.FirstName, row.Child.SecondName, row.Child.LastName,
+		row.Parent.FirstName, row.Parent.SecondName, row.Parent.LastName} {
+		if utf8.RuneCountInString(name) > maxImportNameLen {
+			errs = append(errs, fmt.Sprintf("name %s is too long", name))
+		}
+	}
+	if len(row.Child.Phone) > maxImportPhoneLen || len(row.Parent.Phone) > maxImportPhoneLen {
+		errs = append(errs, "phone is too long")
+	}
+	if utf8.RuneCountInString(row.Relationship) > maxRelationshipLen {
+		errs = append(errs, "relationship is too long")
+	}
+
+	birthDate := value("birth_date")
+	if birthDate == "" {
+		errs = append(errs, "birth date is required")
+	} else {
+		var err error
+		row.Child.BirthDate, err = parseImportDate(birthDate)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid birth date %s", birthDate))
+		} else if row.Child.BirthDate > uint64(time.Now().Unix()) {
+			errs = append(errs, "birth date is in the future")
+		}
+	}
+
+	if !strings.Contains(row.Parent.Email, "@") {
+		errs = append(errs, "invalid parent email")
+	}
+	if row.Child.Email != "" && !strings.Contains(row.Child.Email, "@") {
+		errs = append(errs, "invalid child email")
+	}
+	if row.Child.Email != "" && strings.EqualFold(row.Child.Email, row.Parent.Email) {
+		errs = append(errs, "child and parent can't have the same email")
+	}
+	return row, errs
+}
+
+// ImportStudents creates or matches children of students list with their parents.
+// Dry run only reports what would be done, otherwise every valid row is saved separately
+func (rru *regReqUsecase) ImportStudents(ctx context.Context, adminID uint64, data []byte, dryRun bool) (models.StudentImportReport, int, error) {
+	records, err := readImportFile(data)
+	if err != nil {
+		return models.StudentImportReport{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ImportStudents: failed to read file with err: %s", err)
+	}
+	if len(records) < 2 || len(records)-1 > maxImportRows {
+		return models.StudentImportReport{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ImportStudents: invalid rows count %d", len(records)-1)
+	}
+	columns, err := parseImportHeader(records[0])
+	if err != nil {
+		return models.StudentImportReport{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ImportStudents: %s", err)
+	}
+
+	report := models.StudentImportReport{
+		DryRun:  dryRun,
+		Results: []models.StudentImportResult{},
+	}
+	// in dry run nothing is saved, so records of previous rows
+	// are considered as created with planned maps
+	seenRows := map[string]int{}
+	plannedParents := map[string]bool{}
+	plannedChildren := map[string]bool{}
+	for i, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		row, errs := parseImportRow(columns, record)
+		row.Row = i + 2
+		result := models.StudentImportResult{
+			Row:         row.Row,
+			ChildName:   tools.FullName(row.Child.LastName, row.Child.FirstName, row.Child.SecondName),
+			ParentEmail: row.Parent.Email,
+			Errors:      errs,
+		}
+
+		childKey := fmt.Sprintf("%s|%s", normalizeImportName(row.Child.LastName, row.Child.FirstName, row.Child.SecondName),
+			tools.FormatDate(row.Child.BirthDate))
+		parentKey := strings.ToLower(row.Parent.Email)
+		if len(result.Errors) == 0 {
+			if seenRow, ok := seenRows[childKey+"|"+parentKey]; ok {
+				result.Errors = append(result.Errors, fmt.Sprintf("duplicate of row %d", seenRow))
+			}
+			seenRows[childKey+"|"+parentKey] = row.Row
+		}
+
+		if len(result.Errors) == 0 {
+			plan, errs, err := rru.planImportRow(ctx, row, dryRun && plannedChildren[childKey])
+			if err != nil {
+				return models.StudentImportReport{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ImportStudents: row %d: %s", row.Row, err)
+			}
+			result.Errors = append(result.Errors, errs...)
+			if len(result.Errors) == 0 {
+				result.ParentAction = importAction(plan.parentUID == 0 && !(dryRun && plannedParents[parentKey]))
+				result.ChildAction = importAction(plan.childUID == 0 && !(dryRun && plannedChildren[childKey]))
+				if plan.linked {
+					result.LinkAction = models.ImportMatchAction
+				} else {
+					result.LinkAction = models.ImportCreateAction
+				}
+				plannedParents[parentKey] = true
+				plannedChildren[childKey] = true
+				result.ParentID = plan.parentUID
+				result.ChildID = plan.childUID
+			}
+			if len(result.Errors) == 0 && !dryRun {
+				result.ParentID, result.ChildID, err = rru.applyImportRow(ctx, adminID, plan)
+				if err != nil {
+					rru.logger.Errorf("RegReqUsecase.ImportStudents: failed to save row %d with err: %s", row.Row, err)
+					result.Errors = append(result.Errors, "failed to save row")
+				}
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, http.StatusOK, nil
+}
+
+// importAction returns action for record which is created or matched
+func importAction(create bool) string {
+	if create {
+		return models.ImportCreateAction
+	}
+	return models.ImportMatchAction
+}
+
+// planImportRow finds parent and child of row, row errors are returned separately from db errors.
+// childPlanned is set in dry run if child is created by previous row
+func (rru *regReqUsecase) planImportRow(ctx context.Context, row models.StudentImportRow, childPlanned bool) (importPlan, []string, error) {
+	plan := importPlan{row: row}
+
+	parentUser, err := rru.userPsql.GetUserByEmail(ctx, row.Parent.Email)
+	if err == nil {
+		if parentUser.Role != models.ParentRole {
+			return importPlan{}, []string{fmt.Sprintf("parent email belongs to user with role %s", parentUser.Role.String())}, nil
+		}
+		plan.parentUID = parentUser.ID
+	} else if err != pgx.ErrNoRows {
+		return importPlan{}, nil, fmt.Errorf("failed to check parent email with err: %s", err)
+	}
+
+	childUIDs, err := rru.psql.FindChildrenByName(ctx,
+		normalizeImportName(row.Child.LastName, row.Child.FirstName, row.Child.SecondName), row.Child.BirthDate)
+	if err != nil {
+		return importPlan{}, nil, fmt.Errorf("failed to find child with err: %s", err)
+	}
+	if len(childUIDs) > 1 {
+		return importPlan{}, []string{"several children match the row, merge duplicates first"}, nil
+	}
+	if len(childUIDs) == 1 {
+		plan.childUID = childUIDs[0]
+	} else if !childPlanned {
+		if row.Child.Email == "" {
+			return importPlan{}, []string{"child email is required to create new child"}, nil
+		}
+		_, err = rru.userPsql.GetUserByEmail(ctx, row.Child.Email)
+		if err == nil {
+			return importPlan{}, []string{"child email is already used by another user"}, nil
+		} else if err != pgx.ErrNoRows {
+			return importPlan{}, nil, fmt.Errorf("failed to check child email with err: %s", err)
+		}
+	}
+
+	if plan.parentUID == 0 || plan.childUID == 0 {
+		return plan, nil, nil
+	}
+	// parent who hasn't verified email yet has no parent record
+	parent, err := rru.userPsql.GetParentByUID(ctx, plan.parentUID)
+	if err == pgx.ErrNoRows {
+		return plan, nil, nil
+	} else if err != nil {
+		return importPlan{}, nil, fmt.Errorf("failed to get parent with err: %s", err)
+	}
+	child, err := rru.userPsql.GetChildByUID(ctx, plan.childUID)
+	if err != nil {
+		return importPlan{}, nil, fmt.Errorf("failed to get child with err: %s", err)
+	}
+	_, err = rru.userPsql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err == nil {
+		plan.linked = true
+	} else if err != pgx.ErrNoRows {
+		return importPlan{}, nil, fmt.Errorf("failed to check parent-child pair with err: %s", err)
+	}
+	return plan, nil, nil
+}
+
+// applyImportRow creates missing records of row in one transaction,
+// new child gets failed first stage request so parent fills in the rest of data
+func (rru *regReqUsecase) applyImportRow(ctx context.Context, adminID uint64, plan importPlan) (uint64, uint64, error) {
+	parentUID := plan.parentUID
+	childUID := plan.childUID
+	_, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		if parentUID == 0 {
+			password := pswdgenerator.GeneratePassword(10, 0, 2, 2)
+			hashedPswd, err := hasher.HashAndSalt(password)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to hash password with err: %s", err)
+			}
+			parentUser := plan.row.Parent
+			parentUser.Password = hashedPswd
+			createdUser, err := rru.userPsql.CreateUser(ctx, parentUser)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to create parent user with err: %s", err)
+			}
+			// email is given by school, so parent logs in with sent password at once
+			_, err = rru.userPsql.VerifyEmail(ctx, createdUser.Email)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to verify parent email with err: %s", err)
+			}
+			parentUID = createdUser.ID
+
+			childName := tools.FullName(plan.row.Child.LastName, plan.row.Child.FirstName, plan.row.Child.SecondName)
+			database.AfterCommit(ctx, func(ctx context.Context) {
+				err := mailer.SendImportedParentEmail(createdUser.Email, createdUser.FirstName, createdUser.SecondName, childName, password)
+				if err != nil {
+					rru.logger.Errorf("RegReqUsecase.applyImportRow: failed to send email with credentials to parent %d with err: %s", createdUser.ID, err)
+				}
+			})
+		}
+		_, err := rru.userPsql.CreateParent(ctx, parentUID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to create parent with err: %s", err)
+		}
+		parent, err := rru.userPsql.GetParentByUID(ctx, parentUID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to get parent with err: %s", err)
+		}
+
+		if childUID != 0 {
+			if plan.linked {
+				return http.StatusOK, nil
+			}
+			child, err := rru.userPsql.GetChildByUID(ctx, childUID)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to get child with err: %s", err)
+			}
+			err = rru.userPsql.CreateParentChildLink(ctx, parent.ID, child.ID, plan.row.Relationship)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to link child with err: %s", err)
+			}
+			return http.StatusOK, nil
+		}
+
+		createdUser, err := rru.userPsql.CreateUser(ctx, tools.ChildToUser(plan.row.Child))
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to create child user with err: %s", err)
+		}
+		createdChild, err := rru.userPsql.CreateChild(ctx, createdUser.ID, parent.ID, plan.row.Child)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to create child with err: %s", err)
+		}
+		childUID = createdUser.ID
+		if plan.row.Relationship != "" {
+			err = rru.userPsql.UpdateParentChildRelationship(ctx, parent.ID, createdChild.ID, plan.row.Relationship)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("failed to set relationship with err: %s", err)
+			}
+		}
+
+		req, err := rru.psql.CreateFailedRegReq(ctx, childUID, models.ChildFirstStageForStudent, importedStudentMessage)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to create first stage request with err: %s", err)
+		}
+		err = rru.psql.DetectChildDuplicates(ctx, childUID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to detect duplicates with err: %s", err)
+		}
+		err = rru.addHistory(ctx, req, adminID, CreatedReqAction, "imported from students list")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return parentUID, childUID, nil
+}
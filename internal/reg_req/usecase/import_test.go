@@ -0,0 +1,281 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	user_repository "github.com/VoyakinH/lokle_backend/internal/user/repository"
+	"github.com/jackc/pgx"
+)
+
+// fakeUserRepository panics on methods which aren't overridden
+type fakeUserRepository struct {
+	user_repository.IPostgresqlRepository
+	users   []models.User
+	parents map[uint64]models.Parent
+	// parent id -> linked child ids
+	links map[uint64][]uint64
+}
+
+func (fr *fakeUserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	for _, user := range fr.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return models.User{}, pgx.ErrNoRows
+}
+
+func (fr *fakeUserRepository) GetParentByUID(ctx context.Context, uid uint64) (models.Parent, error) {
+	parent, ok := fr.parents[uid]
+	if !ok {
+		return models.Parent{}, pgx.ErrNoRows
+	}
+	return parent, nil
+}
+
+func (fr *fakeUserRepository) GetChildByUID(ctx context.Context, uid uint64) (models.Child, error) {
+	return models.Child{ID: uid * 10, UserID: uid}, nil
+}
+
+func (fr *fakeUserRepository) CheckParentChildren(ctx context.Context, pid uint64, cid uint64) (bool, error) {
+	for _, linkedID := range fr.links[pid] {
+		if linkedID == cid {
+			return true, nil
+		}
+	}
+	return false, pgx.ErrNoRows
+}
+
+// fakeImportRegReqRepository finds children by normalized full name
+type fakeImportRegReqRepository struct {
+	fakeRegReqRepository
+	children map[string][]uint64
+}
+
+func (fr *fakeImportRegReqRepository) FindChildrenByName(ctx context.Context, fullName string, birthDate uint64) ([]uint64, error) {
+	return fr.children[fullName], nil
+}
+
+func mustParseDate(t *testing.T, date string) uint64 {
+	t.Helper()
+	parsed, err := tools.ParseDate(date)
+	if err != nil {
+		t.Fatalf("failed to parse date %s: %s", date, err)
+	}
+	return parsed
+}
+
+func TestParseImportDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "russian format", value: "01.09.2010", want: "01.09.2010"},
+		{name: "iso format", value: "2010-09-01", want: "01.09.2010"},
+		{name: "excel serial date", value: "40422", want: "01.09.2010"},
+		{name: "excel serial date with time", value: "40422.5", want: "01.09.2010"},
+		{name: "invalid day", value: "31.02.2010", wantErr: true},
+		{name: "text", value: "first of september", wantErr: true},
+		{name: "date before unix epoch", value: "0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportDate(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && tools.FormatDate(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, tools.FormatDate(got))
+			}
+		})
+	}
+}
+
+func TestParseImportRow(t *testing.T) {
+	header := []string{"Фамилия", "Имя", "Отчество", "Дата рождения", "email", "Почта родителя", "Кем приходится", "phone"}
+	columns, err := parseImportHeader(header)
+	if err != nil {
+		t.Fatalf("failed to parse header: %s", err)
+	}
+
+	tests := []struct {
+		name       string
+		record     []string
+		wantErrs   []string
+		wantChild  models.Child
+		wantParent string
+	}{
+		{
+			name:   "valid row",
+			record: []string{" Иванов ", "Иван", "Иванович", "01.09.2010", "ivan@mail.ru", "parent@mail.ru", "мама", "+79990000000"},
+			wantChild: models.Child{
+				Role:       models.ChildRole,
+				FirstName:  "Иван",
+				SecondName: "Иванович",
+				LastName:   "Иванов",
+				Email:      "ivan@mail.ru",
+				Phone:      "+79990000000",
+				BirthDate:  mustParseDate(t, "01.09.2010"),
+			},
+			wantParent: "parent@mail.ru",
+		},
+		{
+			name:   "short record",
+			record: []string{"Иванов", "Иван", "", "2010-09-01", "", "parent@mail.ru"},
+			wantChild: models.Child{
+				Role:      models.ChildRole,
+				FirstName: "Иван",
+				LastName:  "Иванов",
+				BirthDate: mustParseDate(t, "01.09.2010"),
+			},
+			wantParent: "parent@mail.ru",
+		},
+		{
+			name:     "required fields are missing",
+			record:   []string{"", "Иван", "", "", "", ""},
+			wantErrs: []string{"child last name and first name are required", "birth date is required", "invalid parent email"},
+		},
+		{
+			name:     "invalid values",
+			record:   []string{"Иванов", strings.Repeat("и", maxImportNameLen+1), "", "31.02.2010", "ivan", "parent@mail.ru", strings.Repeat("р", maxRelationshipLen+1), strings.Repeat("9", maxImportPhoneLen+1)},
+			wantErrs: []string{"is too long", "phone is too long", "relationship is too long", "invalid birth date 31.02.2010", "invalid child email"},
+		},
+		{
+			name:     "birth date in the future",
+			record:   []string{"Иванов", "Иван", "", "01.01.2999", "", "parent@mail.ru"},
+			wantErrs: []string{"birth date is in the future"},
+		},
+		{
+			name:     "child and parent emails are the same",
+			record:   []string{"Иванов", "Иван", "", "01.09.2010", "Parent@mail.ru", "parent@mail.ru"},
+			wantErrs: []string{"child and parent can't have the same email"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, errs := parseImportRow(columns, tt.record)
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("expected errors %v, got %v", tt.wantErrs, errs)
+			}
+			for i, wantErr := range tt.wantErrs {
+				if !strings.Contains(errs[i], wantErr) {
+					t.Errorf("expected error %q, got %q", wantErr, errs[i])
+				}
+			}
+			if len(tt.wantErrs) != 0 {
+				return
+			}
+			if !reflect.DeepEqual(row.Child, tt.wantChild) {
+				t.Errorf("expected child %+v, got %+v", tt.wantChild, row.Child)
+			}
+			if row.Parent.Email != tt.wantParent || row.Parent.Role != models.ParentRole {
+				t.Errorf("unexpected parent %+v", row.Parent)
+			}
+		})
+	}
+}
+
+func TestImportStudentsDryRun(t *testing.T) {
+	rru := &regReqUsecase{
+		psql: &fakeImportRegReqRepository{children: map[string][]uint64{
+			"петров петр":             {30},
+			"сидоров сидор":           {40, 41},
+			"кузнецова анна ивановна": {50},
+		}},
+		userPsql: &fakeUserRepository{
+			users: []models.User{
+				{ID: 1, Role: models.ParentRole, Email: "known@mail.ru"},
+				{ID: 2, Role: models.ManagerRole, Email: "manager@mail.ru"},
+				{ID: 3, Role: models.ChildRole, Email: "used@mail.ru"},
+				{ID: 4, Role: models.ParentRole, Email: "unverified@mail.ru"},
+			},
+			parents: map[uint64]models.Parent{1: {ID: 100, UserID: 1}},
+			// child record id of fake child is its user id * 10
+			links: map[uint64][]uint64{100: {500}},
+		},
+	}
+
+	csv := strings.Join([]string{
+		"last_name;first_name;second_name;birth_date;email;parent_email",
+		"Иванов;Иван;;01.09.2010;ivan@mail.ru;new@mail.ru",
+		"Иванова;Мария;;02.03.2012;maria@mail.ru;new@mail.ru",
+		"Иванов;Иван;;01.09.2010;;second@mail.ru",
+		"иванов ;Иван;;01.09.2010;;new@mail.ru",
+		";;;;;",
+		"Петров;Петр;;01.09.2010;;known@mail.ru",
+		"Кузнецова;Анна;Ивановна;01.09.2010;;known@mail.ru",
+		"Кузнецова;Анна;Ивановна;01.09.2010;;unverified@mail.ru",
+		"Сидоров;Сидор;;01.09.2010;;known@mail.ru",
+		"Смирнов;Олег;;01.09.2010;;manager@mail.ru",
+		"Смирнов;Олег;;01.09.2010;;other@mail.ru",
+		"Смирнов;Олег;;01.09.2010;used@mail.ru;third@mail.ru",
+	}, "\n")
+
+	report, status, err := rru.ImportStudents(context.Background(), 1, []byte(csv), true)
+	if err != nil {
+		t.Fatalf("unexpected error with status %d: %s", status, err)
+	}
+	if !report.DryRun {
+		t.Error("report must be marked as dry run")
+	}
+
+	type wantResult struct {
+		row                                   int
+		childAction, parentAction, linkAction string
+		childID, parentID                     uint64
+		err                                   string
+	}
+	c, m := models.ImportCreateAction, models.ImportMatchAction
+	want := []wantResult{
+		{row: 2, childAction: c, parentAction: c, linkAction: c},
+		// parent is created by previous row
+		{row: 3, childAction: c, parentAction: m, linkAction: c},
+		// child is created by row 2, so email isn't required
+		{row: 4, childAction: m, parentAction: c, linkAction: c},
+		{row: 5, err: "duplicate of row 2"},
+		{row: 7, childAction: m, parentAction: m, linkAction: c, childID: 30, parentID: 1},
+		{row: 8, childAction: m, parentAction: m, linkAction: m, childID: 50, parentID: 1},
+		// parent without verified email has no parent record yet
+		{row: 9, childAction: m, parentAction: m, linkAction: c, childID: 50, parentID: 4},
+		{row: 10, err: "several children match the row"},
+		{row: 11, err: "parent email belongs to user with role MANAGER"},
+		{row: 12, err: "child email is required"},
+		{row: 13, err: "child email is already used"},
+	}
+	if len(report.Results) != len(want) {
+		t.Fatalf("expected %d results, got %d: %+v", len(want), len(report.Results), report.Results)
+	}
+	for i, w := range want {
+		got := report.Results[i]
+		if got.Row != w.row {
+			t.Errorf("result %d: expected row %d, got %d", i, w.row, got.Row)
+			continue
+		}
+		if w.err != "" {
+			if len(got.Errors) != 1 || !strings.Contains(got.Errors[0], w.err) {
+				t.Errorf("row %d: expected error %q, got %v", w.row, w.err, got.Errors)
+			}
+			continue
+		}
+		if len(got.Errors) != 0 {
+			t.Errorf("row %d: unexpected errors %v", w.row, got.Errors)
+			continue
+		}
+		if got.ChildAction != w.childAction || got.ParentAction != w.parentAction || got.LinkAction != w.linkAction {
+			t.Errorf("row %d: expected child=%s parent=%s link=%s, got child=%s parent=%s link=%s", w.row,
+				w.childAction, w.parentAction, w.linkAction, got.ChildAction, got.ParentAction, got.LinkAction)
+		}
+		if got.ChildID != w.childID || got.ParentID != w.parentID {
+			t.Errorf("row %d: expected child id %d and parent id %d, got %d and %d", w.row, w.childID, w.parentID, got.ChildID, got.ParentID)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/mailer"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/workflow"
+)
+
+type notificationType int8
+
+const (
+	approvedNotification notificationType = iota + 1
+	failedNotification
+	stageUnlockedNotification
+)
+
+const issuesOnlyFailedMessage = "замечания указаны в личном кабинете"
+
+func isNotificationEnabled(settings models.NotificationSettings, notification notificationType) bool {
+	switch notification {
+	case approvedNotification:
+		return settings.ReqApproved
+	case failedNotification:
+		return settings.ReqFailed
+	case stageUnlockedNotification:
+		return settings.StageUnlocked
+	}
+	return false
+}
+
+// all notify methods must be called only after request changes are saved,
+// their failures are only logged and never affect request processing
+
+func (rru *regReqUsecase) notifyRegReqApproved(ctx context.Context, req models.RegReqFull) {
+	recipients, err := rru.userPsql.GetNotificationRecipients(ctx, req.UserID)
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyRegReqApproved: failed to get recipients for request %d with err: %s", req.ID, err)
+		return
+	}
+
+	rru.notifyInApp(ctx, recipients, models.Notification{
+		Type:  models.StageApprovedNotification,
+		Title: "Заявка одобрена",
+		Body:  fmt.Sprintf("Заявка «%s» одобрена", req.Type.String()),
+		ReqID: req.ID,
+	})
+	rru.sendEmails(req, recipients, approvedNotification, func(recipient models.User) error {
+		return mailer.SendRegReqApprovedEmail(recipient.Email, recipient.FirstName, recipient.SecondName, req.Type.String())
+	})
+
+	// stage which becomes available for child after request approval
+	stage, ok := workflow.Get(req.Type)
+	if !ok || stage.Next == 0 {
+		return
+	}
+	nextStage := stage.Next
+	child, err := rru.userPsql.GetChildByUID(ctx, req.UserID)
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyRegReqApproved: failed to get child for request %d with err: %s", req.ID, err)
+		return
+	}
+	childName := fmt.Sprintf("%s %s", child.FirstName, child.SecondName)
+	rru.sendEmails(req, recipients, stageUnlockedNotification, func(recipient models.User) error {
+		return mailer.SendStageUnlockedEmail(recipient.Email, recipient.FirstName, recipient.SecondName, childName, nextStage.String())
+	})
+}
+
+func (rru *regReqUsecase) notifyRegReqFailed(ctx context.Context, req models.RegReqFull, message string) {
+	recipients, err := rru.userPsql.GetNotificationRecipients(ctx, req.UserID)
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyRegReqFailed: failed to get recipients for request %d with err: %s", req.ID, err)
+		return
+	}
+
+	if message == "" {
+		message = issuesOnlyFailedMessage
+	}
+	rru.notifyInApp(ctx, recipients, models.Notification{
+		Type:  models.RegReqFailedNotification,
+		Title: "Заявка отклонена",
+		Body:  fmt.Sprintf("Заявка «%s» отклонена: %s", req.Type.String(), message),
+		ReqID: req.ID,
+	})
+	rru.sendEmails(req, recipients, failedNotification, func(recipient models.User) error {
+		return mailer.SendRegReqFailedEmail(recipient.Email, recipient.FirstName, recipient.SecondName, req.Type.String(), message)
+	})
+}
+
+func (rru *regReqUsecase) notifyCredentialsIssued(ctx context.Context, child models.User) {
+	recipients, err := rru.userPsql.GetNotificationRecipients(ctx, child.ID)
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyCredentialsIssued: failed to get recipients for child %d with err: %s", child.ID, err)
+		return
+	}
+	// child gets notification too as he is able to sign in now
+	recipients = append(recipients, models.NotificationRecipient{User: child})
+
+	rru.notifyInApp(ctx, recipients, models.Notification{
+		Type:  models.CredentialsIssuedNotification,
+		Title: "Регистрация завершена",
+		Body:  fmt.Sprintf("Данные для входа отправлены на почту %s", child.Email),
+	})
+}
+
+func (rru *regReqUsecase) notifyManagersRegReqCreated(ctx context.Context, req models.RegReqFull) {
+	err := rru.nu.NotifyRole(ctx, models.ManagerRole, models.Notification{
+		Type:  models.RegReqCreatedNotification,
+		Title: "Новая заявка",
+		Body:  fmt.Sprintf("В очереди новая заявка «%s»", req.Type.String()),
+		ReqID: req.ID,
+	})
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyManagersRegReqCreated: request %d: %s", req.ID, err)
+	}
+}
+
+// fixed request is shown to manager who failed it or to all managers if nobody did it
+func (rru *regReqUsecase) notifyManagersRegReqFixed(ctx context.Context, req models.RegReqFull) {
+	notification := models.Notification{
+		Type:  models.RegReqFixedNotification,
+		Title: "Заявка исправлена",
+		Body:  fmt.Sprintf("Заявка «%s» исправлена и снова в очереди", req.Type.String()),
+		ReqID: req.ID,
+	}
+	var err error
+	if req.ManagerID != 0 {
+		err = rru.nu.Notify(ctx, []uint64{req.ManagerID}, notification)
+	} else {
+		err = rru.nu.NotifyRole(ctx, models.ManagerRole, notification)
+	}
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyManagersRegReqFixed: request %d: %s", req.ID, err)
+	}
+}
+
+func (rru *regReqUsecase) notifyAdminsRegReqEscalated(ctx context.Context, req models.RegReqFull, reason string) {
+	err := rru.nu.NotifyRole(ctx, models.AdminRole, models.Notification{
+		Type:  models.RegReqEscalatedNotification,
+		Title: "Заявка передана администратору",
+		Body:  fmt.Sprintf("Заявка «%s» требует решения: %s", req.Type.String(), reason),
+		ReqID: req.ID,
+	})
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyAdminsRegReqEscalated: request %d: %s", req.ID, err)
+	}
+}
+
+// request handed over to common queue is shown to all managers
+func (rru *regReqUsecase) notifyManagerRegReqAssigned(ctx context.Context, req models.RegReqFull, managerID uint64) {
+	notification := models.Notification{
+		Type:  models.RegReqAssignedNotification,
+		Title: "Заявка передана",
+		Body:  fmt.Sprintf("Вам передана заявка «%s»", req.Type.String()),
+		ReqID: req.ID,
+	}
+	var err error
+	if managerID != 0 {
+		err = rru.nu.Notify(ctx, []uint64{managerID}, notification)
+	} else {
+		notification.Body = fmt.Sprintf("Заявка «%s» возвращена в очередь", req.Type.String())
+		err = rru.nu.NotifyRole(ctx, models.ManagerRole, notification)
+	}
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyManagerRegReqAssigned: request %d: %s", req.ID, err)
+	}
+}
+
+func (rru *regReqUsecase) notifyChildTransferred(ctx context.Context, child models.Child, fromParent models.Parent, toParent models.Parent) {
+	childName := fmt.Sprintf("%s %s", child.FirstName, child.SecondName)
+	err := rru.nu.Notify(ctx, []uint64{fromParent.UserID}, models.Notification{
+		Type:  models.ChildTransferredNotification,
+		Title: "Ребенок переведен",
+		Body:  fmt.Sprintf("Ребенок %s переведен в аккаунт другого родителя", childName),
+	})
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyChildTransferred: child %d: %s", child.UserID, err)
+	}
+	err = rru.nu.Notify(ctx, []uint64{toParent.UserID}, models.Notification{
+		Type:  models.ChildTransferredNotification,
+		Title: "Ребенок добавлен",
+		Body:  fmt.Sprintf("Ребенок %s со всеми заявками и документами переведен в Ваш аккаунт", childName),
+	})
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyChildTransferred: child %d: %s", child.UserID, err)
+	}
+}
+
+func (rru *regReqUsecase) notifyInApp(ctx context.Context, recipients []models.NotificationRecipient, notification models.Notification) {
+	uids := make([]uint64, 0, len(recipients))
+	for _, recipient := range recipients {
+		uids = append(uids, recipient.User.ID)
+	}
+	err := rru.nu.Notify(ctx, uids, notification)
+	if err != nil {
+		rru.logger.Errorf("RegReqUsecase.notifyInApp: %s", err)
+	}
+}
+
+// emails are sent in background because request ctx may be cancelled after response
+func (rru *regReqUsecase) sendEmails(req models.RegReqFull, recipients []models.NotificationRecipient, notification notificationType, send func(models.User) error) {
+	var enabledRecipients []models.User
+	for _, recipient := range recipients {
+		if isNotificationEnabled(recipient.Settings, notification) {
+			enabledRecipients = append(enabledRecipients, recipient.User)
+		}
+	}
+	if len(enabledRecipients) == 0 {
+		return
+	}
+
+	go func() {
+		for _, recipient := range enabledRecipients {
+			if err := send(recipient); err != nil {
+				rru.logger.Errorf("RegReqUsecase.sendEmails: failed to send notification for request %d to user %d with err: %s", req.ID, recipient.ID, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+)
+
+// limit is taken from users table
+const maxNameLen = 32
+
+// parent fields which are changed after passport re-verification, they are saved with UpdateUserWithoutEmail
+var parentChangeFields = map[string]func(*models.User) *string{
+	"first_name":  func(u *models.User) *string { return &u.FirstName },
+	"second_name": func(u *models.User) *string { return &u.SecondName },
+	"last_name":   func(u *models.User) *string { return &u.LastName },
+}
+
+func validateName(name string, required bool) bool {
+	if name == "" {
+		return !required
+	}
+	if utf8.RuneCountInString(name) > maxNameLen {
+		return false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && r != '-' && r != ' ' && r != '\'' {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateParentProfile changes parent's name and phone. Verified passport belongs to the old name,
+// so name change of verified parent waits for approval of passport verification request
+// with new scan and old name is kept until then
+func (rru *regReqUsecase) UpdateParentProfile(ctx context.Context, parent models.Parent, update models.ParentProfileUpdate) (models.User, bool, int, error) {
+	update.FirstName = strings.TrimSpace(update.FirstName)
+	update.SecondName = strings.TrimSpace(update.SecondName)
+	update.LastName = strings.TrimSpace(update.LastName)
+	update.Phone = strings.TrimSpace(update.Phone)
+	if !validateName(update.FirstName, true) || !validateName(update.SecondName, true) ||
+		!validateName(update.LastName, false) || !tools.ValidatePhone(update.Phone) {
+		return models.User{}, false, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.UpdateParentProfile: invalid profile fields")
+	}
+
+	user := models.User{
+		ID:            parent.UserID,
+		Role:          parent.Role,
+		FirstName:     update.FirstName,
+		SecondName:    update.SecondName,
+		LastName:      update.LastName,
+		Email:         parent.Email,
+		EmailVerified: parent.EmailVerified,
+		Phone:         update.Phone,
+	}
+	nameChanged := user.FirstName != parent.FirstName || user.SecondName != parent.SecondName || user.LastName != parent.LastName
+	reverification := nameChanged && parent.PassportVerified
+
+	changes := []models.RegReqChange{}
+	if reverification {
+		reqs, err := rru.psql.GetRegRequestList(ctx, parent.UserID)
+		if err != nil {
+			return models.User{}, false, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.UpdateParentProfile: failed to get parent's requests with err: %s", err)
+		}
+		for _, existsReq := range reqs {
+			if existsReq.Type == models.ParentPassportVerification {
+				return models.User{}, false, http.StatusConflict, newStageError(http.StatusConflict,
+					"name change is already waiting for passport verification")
+			}
+		}
+		// scan of verified passport is removed after approval, so uploaded scan is the new one
+		status, err := rru.checkParentStageRequirements(ctx, parent, models.ParentPassportVerification)
+		if err != nil {
+			return models.User{}, false, status, err
+		}
+
+		oldUser := models.User{FirstName: parent.FirstName, SecondName: parent.SecondName, LastName: parent.LastName}
+		for _, field := range []string{"first_name", "second_name", "last_name"} {
+			oldValue, newValue := parentChangeFields[field](&oldUser), parentChangeFields[field](&user)
+			if *oldValue != *newValue {
+				changes = append(changes, models.RegReqChange{
+					Field:    field,
+					OldValue: *oldValue,
+					NewValue: *newValue,
+				})
+			}
+			*newValue = *oldValue
+		}
+	}
+
+	var createdReq models.RegReqFull
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.userPsql.UpdateUserWithoutEmail(ctx, user)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.UpdateParentProfile: failed to update parent with err: %s", err)
+		}
+		if !reverification {
+			return http.StatusOK, nil
+		}
+
+		createdReq, err = rru.psql.CreateRegReq(ctx, parent.UserID, models.ParentPassportVerification)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.UpdateParentProfile: failed to create verification request with err: %s", err)
+		}
+		err = rru.psql.CreateRegReqChanges(ctx, createdReq.ID, changes)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.UpdateParentProfile: failed to save name changes with err: %s", err)
+		}
+		err = rru.addHistory(ctx, createdReq, 0, CreatedReqAction, "name changed")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.UpdateParentProfile: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return models.User{}, false, status, err
+	}
+
+	if reverification {
+		rru.notifyManagersRegReqCreated(ctx, createdReq)
+		rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, createdReq, 0)
+	}
+	return user, reverification, http.StatusOK, nil
+}
+
+// applyParentDataChange saves name approved with passport verification, it fails
+// if name has been changed in other way since request creation
+func (rru *regReqUsecase) applyParentDataChange(ctx context.Context, managerID uint64, req models.RegReqFull) (int, error) {
+	changes, err := rru.psql.GetRegReqChanges(ctx, req.ID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyParentDataChange: failed to get changes with err: %s", err)
+	}
+	if len(changes) == 0 {
+		return http.StatusOK, nil
+	}
+	user, err := rru.userPsql.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyParentDataChange: failed to get parent with err: %s", err)
+	}
+
+	for _, change := range changes {
+		current, ok := parentChangeFields[change.Field]
+		if !ok {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyParentDataChange: unknown changed field %s", change.Field)
+		}
+		value := current(&user)
+		if *value != change.OldValue {
+			return http.StatusConflict, newStageError(http.StatusConflict,
+				"field %s has been changed since request creation, request must be failed", change.Field)
+		}
+		*value = change.NewValue
+	}
+
+	err = rru.userPsql.UpdateUserWithoutEmail(ctx, user)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyParentDataChange: failed to update parent with err: %s", err)
+	}
+
+	// changes are deleted with approved request
+	err = rru.auditPsql.AddRecord(ctx, models.AuditRecord{
+		ActorID:    managerID,
+		Action:     models.ParentDataChangedAudit,
+		EntityType: models.ParentRole.String(),
+		EntityID:   user.ID,
+		Details:    changesAuditDetails(changes),
+	})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.applyParentDataChange: failed to add audit record with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
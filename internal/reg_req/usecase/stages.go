@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/workflow"
+	"github.com/jackc/pgx"
+)
+
+// StageError is returned when request breaks stage progression.
+// Its message is safe to show to user
+type StageError struct {
+	Status  int
+	Message string
+}
+
+func (se *StageError) Error() string {
+	return se.Message
+}
+
+func newStageError(status int, format string, args ...interface{}) *StageError {
+	return &StageError{
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// checkStageProgression validates that child can submit request of reqType
+// or fix request with fixedReqID which is 0 on creation
+func (rru *regReqUsecase) checkStageProgression(ctx context.Context, child models.Child, reqType models.RegReqType, fixedReqID uint64) (int, error) {
+	stage, ok := workflow.Get(reqType)
+	if !ok || stage.Owner != models.ChildRole {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.checkStageProgression: request type %d isn't child registration stage", reqType)
+	}
+
+	if child.DoneStage >= stage.Grants {
+		return http.StatusConflict, newStageError(http.StatusConflict,
+			"stage %d of child registration has been already approved", stage.Grants)
+	}
+	if child.DoneStage < stage.Requires {
+		return http.StatusUnprocessableEntity, newStageError(http.StatusUnprocessableEntity,
+			"stage %d of child registration must be approved first", child.DoneStage+1)
+	}
+
+	reqs, err := rru.psql.GetRegRequestList(ctx, child.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.checkStageProgression: failed to get child's requests with err: %s", err)
+	}
+	for _, existsReq := range reqs {
+		if existsReq.ID == fixedReqID {
+			continue
+		}
+		if existsStage, ok := workflow.Get(existsReq.Type); ok && existsStage.Owner == models.ChildRole {
+			return http.StatusConflict, newStageError(http.StatusConflict,
+				"child already has request for stage %d of registration", existsStage.Grants)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+// lockStageProgression locks child until the end of current unit of work and checks
+// stage progression again, so concurrent requests of the same child can't both pass the check
+func (rru *regReqUsecase) lockStageProgression(ctx context.Context, uid uint64, reqType models.RegReqType, fixedReqID uint64) (int, error) {
+	err := rru.userPsql.LockUser(ctx, uid)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.lockStageProgression: child not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.lockStageProgression: failed to lock child with err: %s", err)
+	}
+
+	child, err := rru.userPsql.GetChildByUID(ctx, uid)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.lockStageProgression: failed to get child data with err: %s", err)
+	}
+	return rru.checkStageProgression(ctx, child, reqType, fixedReqID)
+}
+
+// withSubmittedData returns stored child with second stage data from request
+func withSubmittedData(child models.Child, submitted models.Child) models.Child {
+	child.Passport = submitted.Passport
+	child.PlaceOfResidence = submitted.PlaceOfResidence
+	child.PlaceOfRegistration = submitted.PlaceOfRegistration
+	return child
+}
+
+// checkChildStageRequirements validates fields and documents required by workflow stage
+func (rru *regReqUsecase) checkChildStageRequirements(ctx context.Context, child models.Child, reqType models.RegReqType) (int, error) {
+	stage, ok := workflow.Get(reqType)
+	if !ok {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.checkChildStageRequirements: unknown request type %d", reqType)
+	}
+	missingFields := workflow.MissingChildFields(stage, child)
+	if len(missingFields) != 0 {
+		return http.StatusUnprocessableEntity, newStageError(http.StatusUnprocessableEntity,
+			"required fields are not filled: %s", strings.Join(missingFields, ", "))
+	}
+	return rru.checkStageDocuments(ctx, stage, child.UserID)
+}
+
+func (rru *regReqUsecase) checkParentStageRequirements(ctx context.Context, parent models.Parent, reqType models.RegReqType) (int, error) {
+	stage, ok := workflow.Get(reqType)
+	if !ok {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.checkParentStageRequirements: unknown request type %d", reqType)
+	}
+	missingFields := workflow.MissingParentFields(stage, parent)
+	if len(missingFields) != 0 {
+		return http.StatusUnprocessableEntity, newStageError(http.StatusUnprocessableEntity,
+			"required fields are not filled: %s", strings.Join(missingFields, ", "))
+	}
+	return rru.checkStageDocuments(ctx, stage, parent.UserID)
+}
+
+// uploaded documents are named as "<category>_<number>.<ext>"
+func stageDocuments(stage workflow.Stage, files []string) []models.ChecklistDocument {
+	documents := make([]models.ChecklistDocument, 0, len(stage.RequiredDocuments))
+	for _, category := range stage.RequiredDocuments {
+		document := models.ChecklistDocument{
+			Category: category,
+			Files:    []string{},
+		}
+		for _, fileName := range files {
+			if strings.HasPrefix(fileName, category+"_") {
+				document.Files = append(document.Files, fileName)
+			}
+		}
+		document.Uploaded = len(document.Files) != 0
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+func (rru *regReqUsecase) checkStageDocuments(ctx context.Context, stage workflow.Stage, uid uint64) (int, error) {
+	if len(stage.RequiredDocuments) == 0 {
+		return http.StatusOK, nil
+	}
+	files, err := rru.fm.ListFiles(ctx, uid, stage.Owner)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.checkStageDocuments: failed to list user files with err: %s", err)
+	}
+
+	missingDocuments := []string{}
+	for _, document := range stageDocuments(stage, files) {
+		if !document.Uploaded {
+			missingDocuments = append(missingDocuments, document.Category)
+		}
+	}
+	if len(missingDocuments) != 0 {
+		return http.StatusUnprocessableEntity, newStageError(http.StatusUnprocessableEntity,
+			"required documents are not uploaded: %s", strings.Join(missingDocuments, ", "))
+	}
+	return http.StatusOK, nil
+}
+
+// checklistStages returns stages of active requests or stages which user can submit next
+func checklistStages(owner models.Role, doneStage models.Stage, reqs []models.RegReqFull) []models.ChecklistStage {
+	checklist := []models.ChecklistStage{}
+	for _, req := range reqs {
+		stage, ok := workflow.Get(req.Type)
+		if ok && stage.Owner == owner {
+			checklist = append(checklist, models.ChecklistStage{
+				Type:  stage.Type,
+				Title: stage.Title,
+				ReqID: req.ID,
+			})
+		}
+	}
+	if len(checklist) != 0 || owner != models.ChildRole {
+		return checklist
+	}
+
+	for _, stage := range workflow.List() {
+		if stage.Owner == models.ChildRole && stage.Requires == doneStage && stage.Grants > doneStage {
+			checklist = append(checklist, models.ChecklistStage{
+				Type:  stage.Type,
+				Title: stage.Title,
+			})
+		}
+	}
+	return checklist
+}
+
+func (rru *regReqUsecase) fillChecklist(ctx context.Context, checklist *models.DocumentChecklist, owner models.Role) error {
+	files, err := rru.fm.ListFiles(ctx, checklist.UserID, owner)
+	if err != nil {
+		return err
+	}
+	for i := range checklist.Stages {
+		stage, _ := workflow.Get(checklist.Stages[i].Type)
+		checklist.Stages[i].Documents = stageDocuments(stage, files)
+		checklist.Stages[i].Complete = true
+		for _, document := range checklist.Stages[i].Documents {
+			if !document.Uploaded {
+				checklist.Stages[i].Complete = false
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/VoyakinH/lokle_backend/config"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/workflow"
+	"github.com/VoyakinH/lokle_backend/internal/reg_req/repository"
+)
+
+// fakeRegReqRepository panics on methods which aren't overridden
+type fakeRegReqRepository struct {
+	repository.IPostgresqlRepository
+	reqs    []models.RegReqFull
+	reqsErr error
+}
+
+func (fr *fakeRegReqRepository) GetRegRequestList(ctx context.Context, uid uint64) ([]models.RegReqFull, error) {
+	return fr.reqs, fr.reqsErr
+}
+
+type fakeFileStorage struct {
+	files    []string
+	filesErr error
+}
+
+func (fs *fakeFileStorage) ListFiles(ctx context.Context, uid uint64, userRole models.Role) ([]string, error) {
+	return fs.files, fs.filesErr
+}
+
+func (fs *fakeFileStorage) DeleteFile(ctx context.Context, uid uint64, userRole models.Role, fileName string) error {
+	return nil
+}
+
+func (fs *fakeFileStorage) RemoveDir(userDirPath string) error {
+	return nil
+}
+
+func (fs *fakeFileStorage) MoveDir(fromDirPath string, toDirPath string) error {
+	return nil
+}
+
+var testWorkflow = []config.WorkflowStageConfig{
+	{Type: 1, Title: "passport", Owner: "parent", RequiredDocuments: []string{"passport"}, Action: "verify_passport", DeleteDocuments: []string{"passport"}},
+	{Type: 2, Title: "first student", Owner: "child", Action: "issue_credentials", GrantsStage: 3},
+	{Type: 3, Title: "first", Owner: "child", Action: "set_stage", GrantsStage: 1, NextType: 4},
+	{Type: 4, Title: "second", Owner: "child", Action: "set_stage", RequiresStage: 1, GrantsStage: 2, NextType: 5},
+	{Type: 5, Title: "third", Owner: "child", RequiredDocuments: []string{"passport"}, Action: "issue_credentials", RequiresStage: 2, GrantsStage: 3, DeleteDocuments: []string{"passport"}},
+}
+
+func loadTestWorkflow(t *testing.T) {
+	t.Helper()
+	if err := workflow.Load(testWorkflow); err != nil {
+		t.Fatalf("failed to load workflow: %s", err)
+	}
+}
+
+func TestCheckStageProgression(t *testing.T) {
+	loadTestWorkflow(t)
+
+	tests := []struct {
+		name           string
+		doneStage      models.Stage
+		reqType        models.RegReqType
+		fixedReqID     uint64
+		reqs           []models.RegReqFull
+		reqsErr        error
+		wantStatus     int
+		wantStageError bool
+	}{
+		{
+			name:       "first stage of new child",
+			reqType:    models.ChildFirstStage,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "next stage after approved one",
+			doneStage:  models.FirstStage,
+			reqType:    models.ChildSecondStage,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "parent stage",
+			reqType:    models.ParentPassportVerification,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown stage",
+			reqType:    models.ChildDataChange,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "stage has been already approved",
+			doneStage:      models.SecondStage,
+			reqType:        models.ChildSecondStage,
+			wantStatus:     http.StatusConflict,
+			wantStageError: true,
+		},
+		{
+			name:           "student is registered at once",
+			doneStage:      models.ThirdStage,
+			reqType:        models.ChildFirstStageForStudent,
+			wantStatus:     http.StatusConflict,
+			wantStageError: true,
+		},
+		{
+			name:           "previous stage isn't approved",
+			doneStage:      models.FirstStage,
+			reqType:        models.ChildThirdStage,
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantStageError: true,
+		},
+		{
+			name:           "child already has stage request",
+			doneStage:      models.FirstStage,
+			reqType:        models.ChildSecondStage,
+			reqs:           []models.RegReqFull{{ID: 7, Type: models.ChildSecondStage}},
+			wantStatus:     http.StatusConflict,
+			wantStageError: true,
+		},
+		{
+			name:       "fixed request isn't counted",
+			doneStage:  models.FirstStage,
+			reqType:    models.ChildSecondStage,
+			fixedReqID: 7,
+			reqs:       []models.RegReqFull{{ID: 7, Type: models.ChildSecondStage}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "data change request isn't stage",
+			doneStage:  models.FirstStage,
+			reqType:    models.ChildSecondStage,
+			reqs:       []models.RegReqFull{{ID: 8, Type: models.ChildDataChange}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "failed to get requests",
+			reqType:    models.ChildFirstStage,
+			reqsErr:    errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rru := &regReqUsecase{psql: &fakeRegReqRepository{reqs: tt.reqs, reqsErr: tt.reqsErr}}
+			child := models.Child{UserID: 1, DoneStage: tt.doneStage}
+
+			status, err := rru.checkStageProgression(context.Background(), child, tt.reqType, tt.fixedReqID)
+			if status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d with err: %v", tt.wantStatus, status, err)
+			}
+			if (err != nil) != (tt.wantStatus != http.StatusOK) {
+				t.Errorf("unexpected error: %v", err)
+			}
+			var stageErr *StageError
+			if errors.As(err, &stageErr) != tt.wantStageError {
+				t.Errorf("expected stage error=%t, got %v", tt.wantStageError, err)
+			}
+			if stageErr != nil && stageErr.Status != status {
+				t.Errorf("stage error status %d differs from returned status %d", stageErr.Status, status)
+			}
+		})
+	}
+}
+
+func TestCheckStageDocuments(t *testing.T) {
+	tests := []struct {
+		name           string
+		documents      []string
+		files          []string
+		filesErr       error
+		wantStatus     int
+		wantStageError bool
+		wantMissing    []string
+	}{
+		{
+			name:       "stage without documents",
+			filesErr:   errors.New("files mustn't be listed"),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "all documents are uploaded",
+			documents:  []string{"passport", "snils"},
+			files:      []string{"passport_1.pdf", "passport_2.jpg", "snils_1.png"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:           "nothing is uploaded",
+			documents:      []string{"passport"},
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantStageError: true,
+			wantMissing:    []string{"passport"},
+		},
+		{
+			name:           "file name must start with category",
+			documents:      []string{"passport", "snils"},
+			files:          []string{"passport_1.pdf", "old_snils_1.pdf", "snils.pdf"},
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantStageError: true,
+			wantMissing:    []string{"snils"},
+		},
+		{
+			name:       "failed to list files",
+			documents:  []string{"passport"},
+			filesErr:   errors.New("permission denied"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rru := &regReqUsecase{fm: &fakeFileStorage{files: tt.files, filesErr: tt.filesErr}}
+			stage := workflow.Stage{Owner: models.ChildRole, RequiredDocuments: tt.documents}
+
+			status, err := rru.checkStageDocuments(context.Background(), stage, 1)
+			if status != tt.wantStatus {
+				t.Errorf("expected status %d, got %d with err: %v", tt.wantStatus, status, err)
+			}
+			var stageErr *StageError
+			if errors.As(err, &stageErr) != tt.wantStageError {
+				t.Fatalf("expected stage error=%t, got %v", tt.wantStageError, err)
+			}
+			for _, missing := range tt.wantMissing {
+				if !strings.Contains(stageErr.Message, missing) {
+					t.Errorf("expected %s in missing documents, got %q", missing, stageErr.Message)
+				}
+			}
+		})
+	}
+}
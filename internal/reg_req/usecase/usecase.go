@@ -2,34 +2,90 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/VoyakinH/lokle_backend/config"
+	audit_repository "github.com/VoyakinH/lokle_backend/internal/audit/repository"
+	events_usecase "github.com/VoyakinH/lokle_backend/internal/events/usecase"
 	"github.com/VoyakinH/lokle_backend/internal/file"
 	"github.com/VoyakinH/lokle_backend/internal/models"
+	notification_usecase "github.com/VoyakinH/lokle_backend/internal/notification/usecase"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/crypt"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/hasher"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/mailer"
 	pswdgenerator "github.com/VoyakinH/lokle_backend/internal/pkg/psw_generator"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/workflow"
 	"github.com/VoyakinH/lokle_backend/internal/reg_req/repository"
 	user_repository "github.com/VoyakinH/lokle_backend/internal/user/repository"
 	"github.com/jackc/pgx"
 	"github.com/sirupsen/logrus"
+	"github.com/xuri/excelize/v2"
 )
 
 const (
-	PendingReqStatus = "pending"
-	FailedReqStatus  = "failed"
+	PendingReqStatus   = "pending"
+	FailedReqStatus    = "failed"
+	EscalatedReqStatus = "escalated"
 )
 
+const (
+	CreatedReqAction    = "created"
+	FixedReqAction      = "fixed"
+	CompletedReqAction  = "completed"
+	FailedReqAction     = "failed"
+	ClaimedReqAction    = "claimed"
+	CancelledReqAction  = "cancelled"
+	WithdrawnReqAction  = "withdrawn"
+	EscalatedReqAction  = "escalated"
+	ReassignedReqAction = "reassigned"
+	ReturnedReqAction   = "returned"
+	MergedReqAction     = "merged"
+)
+
+const defaultStatsPeriod = 30 * 24 * time.Hour
+
+const maxBatchSize = 500
+
+const documentIssueField = "document"
+
+const maxMessageLen = 4096
+
+const maxMessageAttachments = 10
+
+const maxEscalationReasonLen = 1024
+
+const maxRelationshipLen = 16
+
+// fields which manager can mark as wrong in failed request
+var issueFields = map[string]bool{
+	"first_name":            true,
+	"second_name":           true,
+	"last_name":             true,
+	"email":                 true,
+	"phone":                 true,
+	"birth_date":            true,
+	"passport":              true,
+	"place_of_residence":    true,
+	"place_of_registration": true,
+	"relationship":          true,
+	documentIssueField:      true,
+}
+
 type IRegReqUsecase interface {
 	CreateVerifyParentPassportReq(context.Context, models.Parent, models.ParentPassportReq) (int, error)
 	GetRegRequestsList(context.Context, uint64) ([]models.RegReqFull, int, error)
 	GetRegRequestsListAll(context.Context) ([]models.RegReqWithUser, int, error)
 	CreateChild(context.Context, models.ChildFirstRegReq, uint64) (models.Child, int, error)
-	CompleteRegReq(context.Context, uint64) (int, error)
+	CompleteRegReq(context.Context, uint64, uint64) (int, error)
+	ClaimRegReq(context.Context, uint64, uint64) (int, error)
 	SecondRegistrationChildStage(context.Context, models.ChildSecondRegReq, models.Parent) (models.RegReqFull, int, error)
 	ThirdRegistrationChildStage(context.Context, models.ChildThirdRegReq, models.Parent) (models.RegReqFull, int, error)
 	FailedRegReq(context.Context, uint64, models.FailedReq) (int, error)
@@ -37,27 +93,106 @@ type IRegReqUsecase interface {
 	FixChild(context.Context, models.FixChildFirstRegReq) (int, error)
 	FixSecondRegistrationChildStage(context.Context, models.FixChildSecondRegReq, models.Parent) (int, error)
 	FixThirdRegistrationChildStage(context.Context, models.FixChildThirdRegReq, models.Parent) (int, error)
+	SubmitChildStage(context.Context, models.ChildStageReq, models.Parent) (models.RegReqFull, int, error)
+	FixChildStage(context.Context, models.FixChildStageReq, models.Parent) (int, error)
+	GetWorkflowStages(context.Context) (models.WorkflowStageRespList, int, error)
+	GetParentChecklist(context.Context, models.Parent) (models.DocumentChecklistList, int, error)
+	CreateChildDataChangeReq(context.Context, models.Parent, models.ChildDataChangeReq) (models.RegReqFull, int, error)
+	GetRegReqChanges(context.Context, uint64) ([]models.RegReqChange, int, error)
+	GetParentRegReqChanges(context.Context, models.Parent, uint64) ([]models.RegReqChange, int, error)
+	UpdateParentProfile(context.Context, models.Parent, models.ParentProfileUpdate) (models.User, bool, int, error)
+	GetChildChecklist(context.Context, models.Child) (models.DocumentChecklist, int, error)
+	GetRegReqHistory(context.Context, uint64) ([]models.RegReqHistory, int, error)
+	CancelRegReq(context.Context, models.Parent, uint64) (int, error)
+	WithdrawChild(context.Context, models.Parent, uint64) (int, error)
+	TransferChild(context.Context, uint64, models.TransferChildReq) (int, error)
+	GetChildDuplicates(context.Context) ([]models.ChildDuplicate, int, error)
+	DismissChildDuplicate(context.Context, uint64, uint64) (int, error)
+	MergeChildDuplicate(context.Context, uint64, models.MergeChildrenReq) (int, error)
+	EscalateRegReq(context.Context, uint64, models.EscalateReq) (int, error)
+	ReassignRegReq(context.Context, uint64, models.ReassignReq) (int, error)
+	GetEscalatedRegReqs(context.Context) ([]models.RegReqWithUser, int, error)
+	ResolveEscalation(context.Context, uint64, models.ResolveEscalationReq) (int, error)
+	GetRegReqStats(context.Context, models.RegReqStatsFilter) (models.RegReqStats, int, error)
+	BatchCompleteRegReq(context.Context, uint64, models.BatchCompleteReq) ([]models.BatchItemResult, int, error)
+	BatchFailedRegReq(context.Context, uint64, models.BatchFailedReq) ([]models.BatchItemResult, int, error)
+	GetRejectionReasons(context.Context) (models.RejectionReasonList, int, error)
+	GetParentRegReqMessages(context.Context, models.Parent, uint64) ([]models.RegReqMessage, int, error)
+	CreateParentRegReqMessage(context.Context, models.Parent, models.RegReqMessageReq) (models.RegReqMessage, int, error)
+	GetManagerRegReqMessages(context.Context, uint64, uint64) ([]models.RegReqMessage, int, error)
+	CreateManagerRegReqMessage(context.Context, uint64, models.RegReqMessageReq) (models.RegReqMessage, int, error)
+	ExportRegReqs(context.Context, string) (*excelize.File, int, error)
+	ExportChildren(context.Context, uint64) (*excelize.File, int, error)
+	ExportRegReqStats(context.Context, models.RegReqStatsFilter) (*excelize.File, int, error)
+	ImportStudents(context.Context, uint64, []byte, bool) (models.StudentImportReport, int, error)
+}
+
+// fileStorage is part of file.FileManager used by requests
+type fileStorage interface {
+	ListFiles(context.Context, uint64, models.Role) ([]string, error)
+	DeleteFile(context.Context, uint64, models.Role, string) error
+	RemoveDir(string) error
+	MoveDir(string, string) error
 }
 
 type regReqUsecase struct {
-	psql     repository.IPostgresqlRepository
-	userPsql user_repository.IPostgresqlRepository
-	fm       file.FileManager
-	logger   logrus.Logger
+	psql      repository.IPostgresqlRepository
+	userPsql  user_repository.IPostgresqlRepository
+	auditPsql audit_repository.IPostgresqlRepository
+	fm        fileStorage
+	nu        notification_usecase.INotificationUsecase
+	eu        events_usecase.IEventsUsecase
+	uow       database.IUnitOfWork
+	logger    logrus.Logger
 }
 
 func NewRegReqUsecase(pr repository.IPostgresqlRepository,
 	ur user_repository.IPostgresqlRepository,
+	ar audit_repository.IPostgresqlRepository,
 	fm file.FileManager,
+	nu notification_usecase.INotificationUsecase,
+	eu events_usecase.IEventsUsecase,
+	uow database.IUnitOfWork,
 	logger logrus.Logger) IRegReqUsecase {
 	return &regReqUsecase{
-		psql:     pr,
-		userPsql: ur,
-		fm:       fm,
-		logger:   logger,
+		psql:      pr,
+		userPsql:  ur,
+		auditPsql: ar,
+		fm:        &fm,
+		nu:        nu,
+		eu:        eu,
+		uow:       uow,
+		logger:    logger,
 	}
 }
 
+// inTx runs fn in single transaction for both user and request repositories
+// and keeps status returned by fn
+func (rru *regReqUsecase) inTx(ctx context.Context, fn func(context.Context) (int, error)) (int, error) {
+	status := http.StatusOK
+	err := rru.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		status, err = fn(ctx)
+		return err
+	})
+	if err != nil && status == http.StatusOK {
+		status = http.StatusInternalServerError
+	}
+	return status, err
+}
+
+func (rru *regReqUsecase) addHistory(ctx context.Context, req models.RegReqFull, managerID uint64, action string, message string) error {
+	return rru.psql.AddRegReqHistory(ctx, models.RegReqHistory{
+		ReqID:         req.ID,
+		UserID:        req.UserID,
+		ManagerID:     managerID,
+		Type:          req.Type,
+		Action:        action,
+		Message:       message,
+		ReqCreateTime: req.CreateTime,
+	})
+}
+
 func (rru *regReqUsecase) CreateVerifyParentPassportReq(ctx context.Context, parent models.Parent, req models.ParentPassportReq) (int, error) {
 	if parent.PassportVerified {
 		return http.StatusOK, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: parent passport has been already verified")
@@ -73,22 +208,43 @@ func (rru *regReqUsecase) CreateVerifyParentPassportReq(ctx context.Context, par
 		}
 	}
 
+	parent.Passport = req.Passport
+	status, err := rru.checkParentStageRequirements(ctx, parent, models.ParentPassportVerification)
+	if err != nil {
+		return status, err
+	}
+
 	encryptedPassport, err := crypt.Encrypt(req.Passport)
 	if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: failed to encrypt parent passport with err: %s", err)
 	}
 	req.Passport = encryptedPassport
 
-	_, err = rru.userPsql.UpdateParentPassport(ctx, parent.ID, req.Passport)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: failed to update parent passport with err: %s", err)
-	}
+	var createdReq models.RegReqFull
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		_, err := rru.userPsql.UpdateParentPassport(ctx, parent.ID, req.Passport)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: failed to update parent passport with err: %s", err)
+		}
+
+		createdReq, err = rru.psql.CreateRegReq(ctx, parent.UserID, models.ParentPassportVerification)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: failed to create verification request with err: %s", err)
+		}
 
-	_, err = rru.psql.CreateRegReq(ctx, parent.UserID, models.ParentPassportVerification)
+		err = rru.addHistory(ctx, createdReq, 0, CreatedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateVerifyParentPassportReq: failed to create verification request with err: %s", err)
+		return status, err
 	}
 
+	rru.notifyManagersRegReqCreated(ctx, createdReq)
+	rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, createdReq, 0)
+
 	return http.StatusOK, nil
 }
 
@@ -112,6 +268,12 @@ func (rru *regReqUsecase) FixVerifyParentPassportReq(ctx context.Context, parent
 		return http.StatusForbidden, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: try to fix another's passport reg req")
 	}
 
+	parent.Passport = reqFix.Passport
+	status, err := rru.checkParentStageRequirements(ctx, parent, regReq.Type)
+	if err != nil {
+		return status, err
+	}
+
 	// encrypt new passport data for updating request
 	encryptedPassport, err := crypt.Encrypt(reqFix.Passport)
 	if err != nil {
@@ -119,18 +281,34 @@ func (rru *regReqUsecase) FixVerifyParentPassportReq(ctx context.Context, parent
 	}
 	reqFix.Passport = encryptedPassport
 
-	// update parent passport in psql
-	_, err = rru.userPsql.UpdateParentPassport(ctx, parent.ID, reqFix.Passport)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: failed to update parent passport with err: %s", err)
-	}
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		// update parent passport in psql
+		_, err := rru.userPsql.UpdateParentPassport(ctx, parent.ID, reqFix.Passport)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: failed to update parent passport with err: %s", err)
+		}
+
+		// update request (create_time, etc.)
+		err = rru.psql.FixRegReq(ctx, reqFix.ReqID)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: request isn't in failed status anymore")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: failed to fix verification request with err: %s", err)
+		}
 
-	// update request (create_time, etc.)
-	err = rru.psql.FixRegReq(ctx, reqFix.ReqID)
+		err = rru.addHistory(ctx, regReq, 0, FixedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixVerifyParentPassportReq: failed to fix verification request with err: %s", err)
+		return status, err
 	}
 
+	rru.notifyManagersRegReqFixed(ctx, regReq)
+	rru.publishRegReqEvent(ctx, models.RegReqFixedEvent, regReq, regReq.ManagerID)
+
 	return http.StatusOK, nil
 }
 
@@ -165,27 +343,62 @@ func (rru *regReqUsecase) CreateChild(ctx context.Context, childReq models.Child
 	child.Password = ""
 	child.Role = models.ChildRole
 
-	createdChildUser, err := rru.userPsql.CreateUser(ctx, tools.ChildToUser(child))
-	if err != nil {
-		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to create child user with err: %s", err)
-	}
-
-	createdChild, err := rru.userPsql.CreateChild(ctx, createdChildUser.ID, pid, child)
-	if err != nil {
-		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to create child with err: %s", err)
-	}
-
 	var reqType models.RegReqType
 	if childReq.IsStudent {
 		reqType = models.ChildFirstStageForStudent
 	} else {
 		reqType = models.ChildFirstStage
 	}
-	_, err = rru.psql.CreateRegReq(ctx, createdChild.UserID, reqType)
+	// child has no uploaded documents yet, so only fields are checked
+	stage, ok := workflow.Get(reqType)
+	if !ok {
+		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: unknown request type %d", reqType)
+	}
+	missingFields := workflow.MissingChildFields(stage, child)
+	if len(missingFields) != 0 {
+		return models.Child{}, http.StatusUnprocessableEntity, newStageError(http.StatusUnprocessableEntity,
+			"required fields are not filled: %s", strings.Join(missingFields, ", "))
+	}
+
+	var createdChildUser models.User
+	var createdChild models.Child
+	var createdReq models.RegReqFull
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		var err error
+		createdChildUser, err = rru.userPsql.CreateUser(ctx, tools.ChildToUser(child))
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to create child user with err: %s", err)
+		}
+
+		createdChild, err = rru.userPsql.CreateChild(ctx, createdChildUser.ID, pid, child)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to create child with err: %s", err)
+		}
+
+		createdReq, err = rru.psql.CreateRegReq(ctx, createdChild.UserID, reqType)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to create first stage request with err: %s", err)
+		}
+
+		// the same student can be registered by another parent with different email
+		err = rru.psql.DetectChildDuplicates(ctx, createdChild.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to detect duplicates with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, createdReq, 0, CreatedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateChild: failed to create first stage request with err: %s", err)
+		return models.Child{}, status, err
 	}
 
+	rru.notifyManagersRegReqCreated(ctx, createdReq)
+	rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, createdReq, 0)
+
 	return models.Child{
 		ID:            createdChild.ID,
 		UserID:        createdChild.UserID,
@@ -207,55 +420,98 @@ func (rru *regReqUsecase) FixChild(ctx context.Context, childReq models.FixChild
 	} else if err != nil {
 		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to get request with err: %s", err)
 	}
-	if req.Status == PendingReqStatus {
-		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixChild: can't update request in pending status")
+	if req.Status != FailedReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixChild: can't update request which isn't in failed status")
 	}
-
-	err = rru.userPsql.UpdateChild(ctx, childReq.Child)
-	if err == pgx.ErrNoRows {
-		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: child not found")
-	} else if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to update child with err: %s", err)
+	if req.Type != models.ChildFirstStage && req.Type != models.ChildFirstStageForStudent {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixChild: request is not first stage of child registration")
 	}
-
-	childUser, err := rru.userPsql.GetUserByID(ctx, childReq.Child.UserID)
-	if err == pgx.ErrNoRows {
-		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: user not found")
-	} else if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to get user with err: %s", err)
-	}
-	if childUser.Email != childReq.Child.Email {
-		err = rru.userPsql.UpdateUserWithEmail(ctx, models.User{
-			ID:         childReq.Child.UserID,
-			FirstName:  childReq.Child.FirstName,
-			SecondName: childReq.Child.SecondName,
-			LastName:   childReq.Child.LastName,
-			Phone:      childReq.Child.Phone,
-			Email:      childReq.Child.Email,
-		})
-	} else {
-		err = rru.userPsql.UpdateUserWithoutEmail(ctx, models.User{
-			ID:         childReq.Child.UserID,
-			FirstName:  childReq.Child.FirstName,
-			SecondName: childReq.Child.SecondName,
-			LastName:   childReq.Child.LastName,
-			Phone:      childReq.Child.Phone,
-		})
+	if req.UserID != childReq.Child.UserID {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixChild: request doesn't belong to child")
 	}
 
-	if err == pgx.ErrNoRows {
-		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: user not found")
-	} else if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to update user with err: %s", err)
+	child, err := rru.userPsql.GetChildByUID(ctx, req.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to get child data with err: %s", err)
+	}
+	status, err := rru.checkStageProgression(ctx, child, req.Type, req.ID)
+	if err != nil {
+		return status, err
+	}
+	status, err = rru.checkChildStageRequirements(ctx, childReq.Child, req.Type)
+	if err != nil {
+		return status, err
 	}
 
-	err = rru.psql.FixRegReq(ctx, childReq.ReqID)
-	if err == pgx.ErrNoRows {
-		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: request not found")
-	} else if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to fix request with err: %s", err)
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, req.Type, req.ID)
+		if err != nil {
+			return status, err
+		}
+
+		err = rru.userPsql.UpdateChild(ctx, childReq.Child)
+		if err == pgx.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: child not found")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to update child with err: %s", err)
+		}
+
+		childUser, err := rru.userPsql.GetUserByID(ctx, childReq.Child.UserID)
+		if err == pgx.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: user not found")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to get user with err: %s", err)
+		}
+		if childUser.Email != childReq.Child.Email {
+			err = rru.userPsql.UpdateUserWithEmail(ctx, models.User{
+				ID:         childReq.Child.UserID,
+				FirstName:  childReq.Child.FirstName,
+				SecondName: childReq.Child.SecondName,
+				LastName:   childReq.Child.LastName,
+				Phone:      childReq.Child.Phone,
+				Email:      childReq.Child.Email,
+			})
+		} else {
+			err = rru.userPsql.UpdateUserWithoutEmail(ctx, models.User{
+				ID:         childReq.Child.UserID,
+				FirstName:  childReq.Child.FirstName,
+				SecondName: childReq.Child.SecondName,
+				LastName:   childReq.Child.LastName,
+				Phone:      childReq.Child.Phone,
+			})
+		}
+
+		if err == pgx.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChild: user not found")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to update user with err: %s", err)
+		}
+
+		err = rru.psql.FixRegReq(ctx, childReq.ReqID)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixChild: request isn't in failed status anymore")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to fix request with err: %s", err)
+		}
+
+		err = rru.psql.DetectChildDuplicates(ctx, childReq.Child.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to detect duplicates with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, 0, FixedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChild: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
 	}
 
+	rru.notifyManagersRegReqFixed(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqFixedEvent, req, req.ManagerID)
+
 	return http.StatusOK, nil
 }
 
@@ -274,17 +530,13 @@ func (rru *regReqUsecase) SecondRegistrationChildStage(ctx context.Context, chil
 		return models.RegReqFull{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: current child isn't child of current parent")
 	}
 
-	respList, err := rru.psql.GetRegRequestList(ctx, child.UserID)
+	status, err := rru.checkStageProgression(ctx, child, models.ChildSecondStage, 0)
 	if err != nil {
-		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to get child's requests: %s", err)
+		return models.RegReqFull{}, status, err
 	}
-	for _, existsReq := range respList {
-		if existsReq.Type == models.ChildFirstStage ||
-			existsReq.Type == models.ChildSecondStage ||
-			existsReq.Type == models.ChildThirdStage {
-			// && existsReq.Status == PendingReqStatus {
-			return models.RegReqFull{}, http.StatusConflict, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: child has already created this request")
-		}
+	status, err = rru.checkChildStageRequirements(ctx, withSubmittedData(child, childReq.Child), models.ChildSecondStage)
+	if err != nil {
+		return models.RegReqFull{}, status, err
 	}
 	childReq.Child.BirthDate = child.BirthDate
 	encryptedPassport, err := crypt.Encrypt(childReq.Child.Passport)
@@ -293,21 +545,46 @@ func (rru *regReqUsecase) SecondRegistrationChildStage(ctx context.Context, chil
 	}
 	childReq.Child.Passport = encryptedPassport
 
-	err = rru.userPsql.UpdateChild(ctx, childReq.Child)
-	if err != nil {
-		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to update child data with err: %s", err)
-	}
+	var req models.RegReqFull
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, models.ChildSecondStage, 0)
+		if err != nil {
+			return status, err
+		}
 
-	err = rru.userPsql.UpdateParentChildRelationship(ctx, parent.ID, child.ID, childReq.Relationship)
-	if err != nil {
-		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to update parent and child relationship with err: %s", err)
-	}
+		err = rru.userPsql.UpdateChild(ctx, childReq.Child)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to update child data with err: %s", err)
+		}
+
+		err = rru.psql.DetectChildDuplicates(ctx, child.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to detect duplicates with err: %s", err)
+		}
 
-	req, err := rru.psql.CreateRegReq(ctx, child.UserID, models.ChildSecondStage)
+		err = rru.userPsql.UpdateParentChildRelationship(ctx, parent.ID, child.ID, childReq.Relationship)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to update parent and child relationship with err: %s", err)
+		}
+
+		req, err = rru.psql.CreateRegReq(ctx, child.UserID, models.ChildSecondStage)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to create verification request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, 0, CreatedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SecondRegistrationChildStage: failed to create verification request with err: %s", err)
+		return models.RegReqFull{}, status, err
 	}
 
+	rru.notifyManagersRegReqCreated(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, req, 0)
+
 	return req, http.StatusOK, nil
 }
 
@@ -325,12 +602,12 @@ func (rru *regReqUsecase) FixSecondRegistrationChildStage(
 	}
 
 	// if request status is "pending" parent can't to fix this one
-	if req.Status == PendingReqStatus {
-		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: can't update request in pending status")
+	if req.Status != FailedReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: can't update request which isn't in failed status")
 	}
 
 	// checking that request is a second stage of child registration
-	if req.Type != models.ChildThirdStage {
+	if req.Type != models.ChildSecondStage {
 		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: request is not second stage of child registration")
 	}
 
@@ -349,6 +626,15 @@ func (rru *regReqUsecase) FixSecondRegistrationChildStage(
 		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: current child isn't child of current parent")
 	}
 
+	status, err := rru.checkStageProgression(ctx, child, req.Type, req.ID)
+	if err != nil {
+		return status, err
+	}
+	status, err = rru.checkChildStageRequirements(ctx, withSubmittedData(child, childReq.Child), req.Type)
+	if err != nil {
+		return status, err
+	}
+
 	// updating child birth date for next update in db
 	childReq.Child.BirthDate = child.BirthDate
 
@@ -359,24 +645,50 @@ func (rru *regReqUsecase) FixSecondRegistrationChildStage(
 	}
 	childReq.Child.Passport = encryptedPassport
 
-	// updating child in db
-	err = rru.userPsql.UpdateChild(ctx, childReq.Child)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to update child data with err: %s", err)
-	}
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, req.Type, req.ID)
+		if err != nil {
+			return status, err
+		}
 
-	// updating parent-child relationship
-	err = rru.userPsql.UpdateParentChildRelationship(ctx, parent.ID, child.ID, childReq.Relationship)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to update parent and child relationship with err: %s", err)
-	}
+		// updating child in db
+		err = rru.userPsql.UpdateChild(ctx, childReq.Child)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to update child data with err: %s", err)
+		}
+
+		err = rru.psql.DetectChildDuplicates(ctx, child.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to detect duplicates with err: %s", err)
+		}
+
+		// updating parent-child relationship
+		err = rru.userPsql.UpdateParentChildRelationship(ctx, parent.ID, child.ID, childReq.Relationship)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to update parent and child relationship with err: %s", err)
+		}
+
+		// updating request in db
+		err = rru.psql.FixRegReq(ctx, childReq.ReqID)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: request isn't in failed status anymore")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to fix request with err: %s", err)
+		}
 
-	// updating request in db
-	err = rru.psql.FixRegReq(ctx, childReq.ReqID)
+		err = rru.addHistory(ctx, req, 0, FixedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixSecondRegistrationChildStage: failed to fix request with err: %s", err)
+		return status, err
 	}
 
+	rru.notifyManagersRegReqFixed(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqFixedEvent, req, req.ManagerID)
+
 	return http.StatusOK, nil
 }
 
@@ -399,24 +711,40 @@ func (rru *regReqUsecase) ThirdRegistrationChildStage(
 		return models.RegReqFull{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ThirdRegistrationChildStage: current child isn't child of current parent")
 	}
 
-	respList, err := rru.psql.GetRegRequestList(ctx, child.UserID)
+	status, err := rru.checkStageProgression(ctx, child, models.ChildThirdStage, 0)
 	if err != nil {
-		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ThirdRegistrationChildStage: failed to get child's requests: %s", err)
+		return models.RegReqFull{}, status, err
 	}
-	for _, existsReq := range respList {
-		if existsReq.Type == models.ChildFirstStage ||
-			existsReq.Type == models.ChildSecondStage ||
-			existsReq.Type == models.ChildThirdStage {
-			// && existsReq.Status == PendingReqStatus {
-			return models.RegReqFull{}, http.StatusConflict, fmt.Errorf("RegReqUsecase.ThirdRegistrationChildStage: child has already created this request")
-		}
+	status, err = rru.checkChildStageRequirements(ctx, child, models.ChildThirdStage)
+	if err != nil {
+		return models.RegReqFull{}, status, err
 	}
 
-	req, err := rru.psql.CreateRegReq(ctx, child.UserID, models.ChildThirdStage)
+	var req models.RegReqFull
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, models.ChildThirdStage, 0)
+		if err != nil {
+			return status, err
+		}
+
+		req, err = rru.psql.CreateRegReq(ctx, child.UserID, models.ChildThirdStage)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ThirdRegistrationChildStage: failed to create verification request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, 0, CreatedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ThirdRegistrationChildStage: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ThirdRegistrationChildStage: failed to create verification request with err: %s", err)
+		return models.RegReqFull{}, status, err
 	}
 
+	rru.notifyManagersRegReqCreated(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, req, 0)
+
 	return req, http.StatusOK, nil
 }
 
@@ -434,8 +762,8 @@ func (rru *regReqUsecase) FixThirdRegistrationChildStage(
 	}
 
 	// if request status is "pending" parent can't to fix this one
-	if req.Status == PendingReqStatus {
-		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: can't update request in pending status")
+	if req.Status != FailedReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: can't update request which isn't in failed status")
 	}
 
 	// checking that request is a third stage of child registration
@@ -458,109 +786,1195 @@ func (rru *regReqUsecase) FixThirdRegistrationChildStage(
 		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: current child isn't child of current parent")
 	}
 
-	// update registration request
-	err = rru.psql.FixRegReq(ctx, childReq.ReqID)
+	status, err := rru.checkStageProgression(ctx, child, req.Type, req.ID)
+	if err != nil {
+		return status, err
+	}
+	status, err = rru.checkChildStageRequirements(ctx, child, req.Type)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: failed to create verification request with err: %s", err)
+		return status, err
 	}
 
-	return http.StatusOK, nil
-}
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, req.Type, req.ID)
+		if err != nil {
+			return status, err
+		}
 
-func (rru *regReqUsecase) completeThirdRegistrationChildStage(ctx context.Context, uid uint64) error {
-	err := rru.userPsql.VerifyStageForChild(ctx, uid, models.ThirdStage)
+		// update registration request
+		err = rru.psql.FixRegReq(ctx, childReq.ReqID)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: request isn't in failed status anymore")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: failed to create verification request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, 0, FixedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixThirdRegistrationChildStage: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to verify first stage for old students in db with err: %s", err)
+		return status, err
 	}
-	user, err := rru.userPsql.GetUserByID(ctx, uid)
-	if err != nil {
-		return fmt.Errorf("failed to find user for child with err: %s", err)
+
+	rru.notifyManagersRegReqFixed(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqFixedEvent, req, req.ManagerID)
+
+	return http.StatusOK, nil
+}
+
+// SubmitChildStage creates request of workflow stage defined in config
+func (rru *regReqUsecase) SubmitChildStage(ctx context.Context, stageReq models.ChildStageReq, parent models.Parent) (models.RegReqFull, int, error) {
+	stage, ok := workflow.Get(stageReq.Type)
+	if !ok || stage.Owner != models.ChildRole || stage.Builtin() {
+		return models.RegReqFull{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.SubmitChildStage: request type %d can't be submitted without data", stageReq.Type)
 	}
-	childPswd := pswdgenerator.GeneratePassword(10, 0, 2, 2)
-	hashedPswd, err := hasher.HashAndSalt(childPswd)
-	if err != nil {
-		return fmt.Errorf("failed to hash password with err: %s", err)
+
+	child, err := rru.userPsql.GetChildByUID(ctx, stageReq.ChildID)
+	if err == pgx.ErrNoRows {
+		return models.RegReqFull{}, http.StatusNotFound, fmt.Errorf("RegReqUsecase.SubmitChildStage: child not found")
+	} else if err != nil {
+		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SubmitChildStage: failed to get child data with err: %s", err)
 	}
-	err = rru.userPsql.UpdateUserPswd(ctx, user.ID, hashedPswd)
-	if err != nil {
-		return fmt.Errorf("failed to update child password with err: %s", err)
+
+	// checking that current child is a child of current parent
+	isParent, err := rru.userPsql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SubmitChildStage: failed to check parent-child pair with err: %s", err)
 	}
-	err = mailer.SendCompleteChildRegistrationEmail(user.Email, user.FirstName, user.SecondName, childPswd)
-	if err != nil {
-		return fmt.Errorf("failed to send email with credentials to child with err: %s", err)
+	if !isParent {
+		return models.RegReqFull{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.SubmitChildStage: current child isn't child of current parent")
 	}
-	return nil
-}
 
-func (rru *regReqUsecase) CompleteRegReq(ctx context.Context, reqID uint64) (int, error) {
-	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	status, err := rru.checkStageProgression(ctx, child, stage.Type, 0)
 	if err != nil {
-		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to find request with err: %s", err)
+		return models.RegReqFull{}, status, err
 	}
-	if req.Status == FailedReqStatus {
-		return http.StatusConflict, fmt.Errorf("RegReqUsecase.CompleteRegReq: request has been already in failed status")
+	status, err = rru.checkChildStageRequirements(ctx, child, stage.Type)
+	if err != nil {
+		return models.RegReqFull{}, status, err
 	}
 
-	switch req.Type {
-	case models.ParentPassportVerification:
-		err = rru.userPsql.VerifyParentPassport(ctx, req.UserID)
-		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to verify parent passport in db with err: %s", err)
-		}
-		err = rru.fm.DeleteFile(ctx, req.UserID, models.ParentRole, "passport")
+	var req models.RegReqFull
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, stage.Type, 0)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to delete parent dir with err: %s", err)
+			return status, err
 		}
-	case models.ChildFirstStageForStudent:
-		err = rru.completeThirdRegistrationChildStage(ctx, req.UserID)
+
+		req, err = rru.psql.CreateRegReq(ctx, child.UserID, stage.Type)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: %s", err)
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SubmitChildStage: failed to create request with err: %s", err)
 		}
-	case models.ChildFirstStage:
-		err = rru.userPsql.VerifyStageForChild(ctx, req.UserID, models.FirstStage)
+
+		err = rru.addHistory(ctx, req, 0, CreatedReqAction, "")
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to verify first stage for new students in db with err: %s", err)
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.SubmitChildStage: failed to add request history with err: %s", err)
 		}
-	case models.ChildSecondStage:
-		err = rru.userPsql.VerifyStageForChild(ctx, req.UserID, models.SecondStage)
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return models.RegReqFull{}, status, err
+	}
+
+	rru.notifyManagersRegReqCreated(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqCreatedEvent, req, 0)
+
+	return req, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) FixChildStage(ctx context.Context, fixReq models.FixChildStageReq, parent models.Parent) (int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, fixReq.ReqID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FixChildStage: request not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChildStage: failed to get request with err: %s", err)
+	}
+	if req.Status != FailedReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixChildStage: can't update request which isn't in failed status")
+	}
+	stage, ok := workflow.Get(req.Type)
+	if !ok || stage.Owner != models.ChildRole || stage.Builtin() {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixChildStage: request type %d can't be fixed without data", req.Type)
+	}
+
+	child, err := rru.userPsql.GetChildByUID(ctx, req.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChildStage: failed to get child data with err: %s", err)
+	}
+
+	// checking that current child is a child of current parent
+	isParent, err := rru.userPsql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChildStage: failed to check parent-child pair with err: %s", err)
+	}
+	if !isParent {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FixChildStage: current child isn't child of current parent")
+	}
+
+	status, err := rru.checkStageProgression(ctx, child, req.Type, req.ID)
+	if err != nil {
+		return status, err
+	}
+	status, err = rru.checkChildStageRequirements(ctx, child, req.Type)
+	if err != nil {
+		return status, err
+	}
+
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		status, err := rru.lockStageProgression(ctx, child.UserID, req.Type, req.ID)
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to verify first stage for new students in db with err: %s", err)
+			return status, err
 		}
-	case models.ChildThirdStage:
-		err = rru.completeThirdRegistrationChildStage(ctx, req.UserID)
-		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: %s", err)
+
+		err = rru.psql.FixRegReq(ctx, req.ID)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FixChildStage: request isn't in failed status anymore")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChildStage: failed to fix request with err: %s", err)
 		}
-		err = rru.fm.DeleteFile(ctx, req.UserID, models.ChildRole, "passport")
+
+		err = rru.addHistory(ctx, req, 0, FixedReqAction, "")
 		if err != nil {
-			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to delete child dir with err: %s", err)
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FixChildStage: failed to add request history with err: %s", err)
 		}
-	default:
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: unknown request type %s", req.Type.String())
-	}
-
-	_, err = rru.psql.DeleteRegReq(ctx, reqID)
+		return http.StatusOK, nil
+	})
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to delete request after completed with err: %s", err)
+		return status, err
 	}
 
+	rru.notifyManagersRegReqFixed(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqFixedEvent, req, req.ManagerID)
+
 	return http.StatusOK, nil
 }
 
-func (rru *regReqUsecase) FailedRegReq(ctx context.Context, managerID uint64, failedReq models.FailedReq) (int, error) {
-	req, err := rru.psql.GetRegRequestByID(ctx, failedReq.ReqId)
+// issueChildCredentials completes child registration with granted stage and sends password to child
+func (rru *regReqUsecase) issueChildCredentials(ctx context.Context, uid uint64, grants models.Stage) error {
+	err := rru.userPsql.VerifyStageForChild(ctx, uid, grants)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FailedRegReq: failed to get request with err: %s", err)
+		return fmt.Errorf("failed to verify stage %d for child in db with err: %s", grants, err)
 	}
-	if req.Status == FailedReqStatus {
-		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FailedRegReq: request has been already in failed status")
+	user, err := rru.userPsql.GetUserByID(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to find user for child with err: %s", err)
 	}
-	err = rru.psql.FailedRegReq(ctx, managerID, failedReq)
-	if err == pgx.ErrNoRows {
-		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.FailedRegReq: request not found")
+	childPswd := pswdgenerator.GeneratePassword(10, 0, 2, 2)
+	hashedPswd, err := hasher.HashAndSalt(childPswd)
+	if err != nil {
+		return fmt.Errorf("failed to hash password with err: %s", err)
+	}
+	err = rru.userPsql.UpdateUserPswd(ctx, user.ID, hashedPswd)
+	if err != nil {
+		return fmt.Errorf("failed to update child password with err: %s", err)
+	}
+
+	// credentials are sent only if new password is committed
+	database.AfterCommit(ctx, func(ctx context.Context) {
+		err := mailer.SendCompleteChildRegistrationEmail(user.Email, user.FirstName, user.SecondName, childPswd)
+		if err != nil {
+			rru.logger.Errorf("RegReqUsecase.issueChildCredentials: failed to send email with credentials to child %d with err: %s", user.ID, err)
+		}
+		rru.notifyCredentialsIssued(ctx, user)
+	})
+	return nil
+}
+
+func (rru *regReqUsecase) CompleteRegReq(ctx context.Context, managerID uint64, reqID uint64) (int, error) {
+	return rru.completeRegReq(ctx, managerID, reqID, false)
+}
+
+// escalated requests can be completed only by admin resolving escalation
+func (rru *regReqUsecase) completeRegReq(ctx context.Context, managerID uint64, reqID uint64, resolvesEscalation bool) (int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	if err != nil {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to find request with err: %s", err)
+	}
+	if req.Status == FailedReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.CompleteRegReq: request has been already in failed status")
+	}
+	if (req.Status == EscalatedReqStatus) != resolvesEscalation {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.CompleteRegReq: request escalation status doesn't allow to complete it")
+	}
+	// admin resolving escalation isn't bound by manager's claim
+	if !resolvesEscalation && req.ManagerID != 0 && req.ManagerID != managerID {
+		return http.StatusForbidden, fmt.Errorf("RegReqUsecase.CompleteRegReq: request is claimed by another manager")
+	}
+	stage, ok := workflow.Get(req.Type)
+	if !ok && req.Type != models.ChildDataChange {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: unknown request type %d", req.Type)
+	}
+
+	// file removing and emails are deferred until request completion is committed
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		// concurrent fail or fix of the same request waits for lock and then sees request deleted
+		err := rru.psql.LockRegReq(ctx, reqID, req.Status)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.CompleteRegReq: request has been changed by someone else")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to lock request with err: %s", err)
+		}
+
+		switch {
+		case req.Type == models.ChildDataChange:
+			// stage error is returned as is to show changed fields
+			status, err := rru.applyChildDataChange(ctx, managerID, req)
+			if err != nil {
+				return status, err
+			}
+		case stage.Action == workflow.VerifyPassportAction:
+			// name change is saved only with passport verification
+			status, err := rru.applyParentDataChange(ctx, managerID, req)
+			if err != nil {
+				return status, err
+			}
+			err = rru.userPsql.VerifyParentPassport(ctx, req.UserID)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to verify parent passport in db with err: %s", err)
+			}
+		case stage.Action == workflow.SetStageAction:
+			err = rru.userPsql.VerifyStageForChild(ctx, req.UserID, stage.Grants)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to verify stage %d for child in db with err: %s", stage.Grants, err)
+			}
+		case stage.Action == workflow.IssueCredentialsAction:
+			err = rru.issueChildCredentials(ctx, req.UserID, stage.Grants)
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: %s", err)
+			}
+		default:
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: unknown action %s of request type %s", stage.Action, req.Type.String())
+		}
+
+		for _, document := range stage.DeleteDocuments {
+			document := document
+			database.AfterCommit(ctx, func(ctx context.Context) {
+				err := rru.fm.DeleteFile(ctx, req.UserID, stage.Owner, document)
+				if err != nil {
+					rru.logger.Errorf("RegReqUsecase.CompleteRegReq: failed to delete %s files of user %d with err: %s", document, req.UserID, err)
+				}
+			})
+		}
+
+		_, err = rru.psql.DeleteRegReq(ctx, reqID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to delete request after completed with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, managerID, CompletedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CompleteRegReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.notifyRegReqApproved(ctx, req)
+	rru.publishRegReqEvent(ctx, models.RegReqApprovedEvent, req, managerID)
+
+	return http.StatusOK, nil
+}
+
+// CancelRegReq removes parent's or child's request from managers queue
+func (rru *regReqUsecase) CancelRegReq(ctx context.Context, parent models.Parent, reqID uint64) (int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.CancelRegReq: request not found")
 	} else if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FailedRegReq: failed to update request with err: %s", err)
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CancelRegReq: failed to get request with err: %s", err)
+	}
+	status, err := rru.checkParentReqAccess(ctx, parent, req)
+	if err != nil {
+		return status, fmt.Errorf("RegReqUsecase.CancelRegReq: %s", err)
+	}
+	// child can't be left without first stage request, so whole application must be withdrawn
+	if req.Type == models.ChildFirstStage || req.Type == models.ChildFirstStageForStudent {
+		return http.StatusConflict, newStageError(http.StatusConflict,
+			"first stage request can't be cancelled, withdraw child application instead")
+	}
+	// escalated request is already being resolved by admins
+	if req.Status != PendingReqStatus && req.Status != FailedReqStatus {
+		return http.StatusConflict, newStageError(http.StatusConflict,
+			"only pending or failed request can be cancelled")
+	}
+
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		// concurrent completion or cancellation waits for lock and then sees request changed
+		err := rru.psql.LockRegReq(ctx, req.ID, req.Status)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.CancelRegReq: request has been changed by someone else")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CancelRegReq: failed to lock request with err: %s", err)
+		}
+
+		deletedReq, err := rru.psql.DeleteRegReq(ctx, req.ID)
+		if err == pgx.ErrNoRows || (err == nil && deletedReq.ID == 0) {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.CancelRegReq: request has been already deleted")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CancelRegReq: failed to delete request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, 0, CancelledReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CancelRegReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.publishRegReqEvent(ctx, models.RegReqCancelledEvent, req, req.ManagerID)
+
+	return http.StatusOK, nil
+}
+
+// WithdrawChild removes child user with all requests and documents until child registration is completed
+func (rru *regReqUsecase) WithdrawChild(ctx context.Context, parent models.Parent, childUID uint64) (int, error) {
+	child, err := rru.userPsql.GetChildByUID(ctx, childUID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.WithdrawChild: child not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to get child data with err: %s", err)
+	}
+
+	// checking that current child is a child of current parent
+	isParent, err := rru.userPsql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to check parent-child pair with err: %s", err)
+	}
+	if !isParent {
+		return http.StatusForbidden, fmt.Errorf("RegReqUsecase.WithdrawChild: current child isn't child of current parent")
+	}
+
+	if child.DoneStage >= models.ThirdStage {
+		return http.StatusConflict, newStageError(http.StatusConflict,
+			"child application can't be withdrawn after stage %d is approved", models.ThirdStage)
+	}
+
+	reqs, err := rru.psql.GetRegRequestList(ctx, child.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to get child's requests with err: %s", err)
+	}
+	// history keeps withdrawal even if child has no active requests
+	historyReqs := reqs
+	if len(historyReqs) == 0 {
+		historyReqs = []models.RegReqFull{{
+			UserID:     child.UserID,
+			Type:       models.ChildFirstStage,
+			CreateTime: uint64(time.Now().Unix()),
+		}}
+	}
+
+	var recipients []models.NotificationRecipient
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.userPsql.LockUser(ctx, child.UserID)
+		if err == pgx.ErrNoRows {
+			return http.StatusNotFound, fmt.Errorf("RegReqUsecase.WithdrawChild: child not found")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to lock child with err: %s", err)
+		}
+
+		// child shared with another parent isn't deleted, parent can only be unlinked from child
+		linkedParents, err := rru.userPsql.GetChildParents(ctx, child.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to get child's parents with err: %s", err)
+		}
+		for _, linkedParent := range linkedParents {
+			if linkedParent.User.ID != parent.UserID {
+				return http.StatusConflict, newStageError(http.StatusConflict,
+					"child is linked to another parent, so application can't be withdrawn")
+			}
+		}
+
+		// recipients are resolved by parent-child links which are deleted with child
+		recipients, err = rru.userPsql.GetNotificationRecipients(ctx, child.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to get notification recipients with err: %s", err)
+		}
+
+		for _, req := range historyReqs {
+			err := rru.addHistory(ctx, req, 0, WithdrawnReqAction, "")
+			if err != nil {
+				return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to add request history with err: %s", err)
+			}
+		}
+
+		// child, parent-child link and requests are removed by cascade
+		_, err = rru.userPsql.DeleteUser(ctx, child.UserID)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.WithdrawChild: failed to delete child user with err: %s", err)
+		}
+
+		database.AfterCommit(ctx, func(ctx context.Context) {
+			err := rru.fm.RemoveDir(child.DirPath)
+			if err != nil {
+				rru.logger.Errorf("RegReqUsecase.WithdrawChild: failed to delete documents of child %d with err: %s", child.UserID, err)
+			}
+		})
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	for _, req := range reqs {
+		rru.publishRegReqEventTo(ctx, models.RegReqCancelledEvent, req, req.ManagerID, recipients)
 	}
 
 	return http.StatusOK, nil
 }
+
+// TransferChild moves child to another parent account. Requests and documents
+// belong to child user, so only parent-child link is changed
+func (rru *regReqUsecase) TransferChild(ctx context.Context, adminID uint64, transferReq models.TransferChildReq) (int, error) {
+	if transferReq.FromParentID == transferReq.ToParentID {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.TransferChild: child can't be transferred to the same parent")
+	}
+	if utf8.RuneCountInString(transferReq.Relationship) > maxRelationshipLen {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.TransferChild: relationship is too long")
+	}
+	child, err := rru.userPsql.GetChildByUID(ctx, transferReq.ChildID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.TransferChild: child not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.TransferChild: failed to get child data with err: %s", err)
+	}
+	fromParent, err := rru.userPsql.GetParentByUID(ctx, transferReq.FromParentID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.TransferChild: current parent not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.TransferChild: failed to get current parent with err: %s", err)
+	}
+	toParent, err := rru.userPsql.GetParentByUID(ctx, transferReq.ToParentID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.TransferChild: new parent not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.TransferChild: failed to get new parent with err: %s", err)
+	}
+	_, err = rru.userPsql.CheckParentChildren(ctx, toParent.ID, child.ID)
+	if err == nil {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.TransferChild: child is already linked to new parent")
+	} else if err != pgx.ErrNoRows {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.TransferChild: failed to check parent-child pair with err: %s", err)
+	}
+
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.userPsql.MoveParentChildLink(ctx, fromParent.ID, toParent.ID, child.ID, transferReq.Relationship)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.TransferChild: child isn't linked to current parent")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.TransferChild: failed to move parent-child link with err: %s", err)
+		}
+
+		err = rru.auditPsql.AddRecord(ctx, models.AuditRecord{
+			ActorID:    adminID,
+			Action:     models.ChildTransferredAudit,
+			EntityType: models.ChildRole.String(),
+			EntityID:   child.UserID,
+			Details:    fmt.Sprintf("from parent %d to parent %d", fromParent.UserID, toParent.UserID),
+		})
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.TransferChild: failed to add audit record with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.notifyChildTransferred(ctx, child, fromParent, toParent)
+
+	return http.StatusOK, nil
+}
+
+// EscalateRegReq moves request from managers queue to admins queue
+func (rru *regReqUsecase) EscalateRegReq(ctx context.Context, managerID uint64, escalateReq models.EscalateReq) (int, error) {
+	if escalateReq.Reason == "" || utf8.RuneCountInString(escalateReq.Reason) > maxEscalationReasonLen {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.EscalateRegReq: escalation reason must be from 1 to %d symbols", maxEscalationReasonLen)
+	}
+	req, err := rru.psql.GetRegRequestByID(ctx, escalateReq.ReqID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.EscalateRegReq: request not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.EscalateRegReq: failed to get request with err: %s", err)
+	}
+	if req.ManagerID != 0 && req.ManagerID != managerID {
+		return http.StatusForbidden, fmt.Errorf("RegReqUsecase.EscalateRegReq: request is claimed by another manager")
+	}
+
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.psql.EscalateRegReq(ctx, escalateReq)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.EscalateRegReq: only pending request can be escalated")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.EscalateRegReq: failed to escalate request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, managerID, EscalatedReqAction, escalateReq.Reason)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.EscalateRegReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.notifyAdminsRegReqEscalated(ctx, req, escalateReq.Reason)
+	rru.publishRegReqEvent(ctx, models.RegReqEscalatedEvent, req, managerID)
+
+	return http.StatusOK, nil
+}
+
+// ReassignRegReq hands pending request over to another manager
+func (rru *regReqUsecase) ReassignRegReq(ctx context.Context, managerID uint64, reassignReq models.ReassignReq) (int, error) {
+	if reassignReq.ManagerID == managerID {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ReassignRegReq: request can't be reassigned to the same manager")
+	}
+	req, err := rru.psql.GetRegRequestByID(ctx, reassignReq.ReqID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.ReassignRegReq: request not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ReassignRegReq: failed to get request with err: %s", err)
+	}
+	if req.ManagerID != 0 && req.ManagerID != managerID {
+		return http.StatusForbidden, fmt.Errorf("RegReqUsecase.ReassignRegReq: request is claimed by another manager")
+	}
+	status, err := rru.checkManager(ctx, reassignReq.ManagerID)
+	if err != nil {
+		return status, fmt.Errorf("RegReqUsecase.ReassignRegReq: %s", err)
+	}
+
+	status, err = rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.psql.AssignRegReq(ctx, req.ID, reassignReq.ManagerID, PendingReqStatus)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.ReassignRegReq: only pending request can be reassigned")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ReassignRegReq: failed to reassign request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, managerID, ReassignedReqAction, handoverMessage(reassignReq.ManagerID))
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ReassignRegReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.notifyManagerRegReqAssigned(ctx, req, reassignReq.ManagerID)
+	rru.publishRegReqEvent(ctx, models.RegReqAssignedEvent, req, reassignReq.ManagerID)
+
+	return http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetEscalatedRegReqs(ctx context.Context) ([]models.RegReqWithUser, int, error) {
+	reqs, err := rru.psql.GetEscalatedRegRequestList(ctx)
+	if err != nil {
+		return []models.RegReqWithUser{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetEscalatedRegReqs: failed to get requests with err: %s", err)
+	}
+	now := uint64(time.Now().Unix())
+	for i := range reqs {
+		reqs[i].TimeInQueue = uint32((now - reqs[i].CreateTime) / 86400)
+	}
+	return reqs, http.StatusOK, nil
+}
+
+// ResolveEscalation applies admin decision: request is approved, failed or returned to managers
+func (rru *regReqUsecase) ResolveEscalation(ctx context.Context, adminID uint64, resolveReq models.ResolveEscalationReq) (int, error) {
+	switch resolveReq.Decision {
+	case models.ApproveEscalationDecision:
+		return rru.completeRegReq(ctx, adminID, resolveReq.ReqID, true)
+	case models.FailEscalationDecision:
+		return rru.failedRegReq(ctx, adminID, models.FailedReq{
+			ReqId:         resolveReq.ReqID,
+			FailedMessage: resolveReq.Message,
+			Issues:        resolveReq.Issues,
+		}, true)
+	case models.ReturnEscalationDecision:
+	default:
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.ResolveEscalation: unknown decision %s", resolveReq.Decision)
+	}
+
+	req, err := rru.psql.GetRegRequestByID(ctx, resolveReq.ReqID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.ResolveEscalation: request not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ResolveEscalation: failed to get request with err: %s", err)
+	}
+	if resolveReq.ManagerID != 0 {
+		status, err := rru.checkManager(ctx, resolveReq.ManagerID)
+		if err != nil {
+			return status, fmt.Errorf("RegReqUsecase.ResolveEscalation: %s", err)
+		}
+	}
+
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.psql.AssignRegReq(ctx, req.ID, resolveReq.ManagerID, EscalatedReqStatus)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.ResolveEscalation: request isn't escalated")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ResolveEscalation: failed to return request with err: %s", err)
+		}
+
+		message := resolveReq.Message
+		if message == "" {
+			message = handoverMessage(resolveReq.ManagerID)
+		}
+		err = rru.addHistory(ctx, req, adminID, ReturnedReqAction, message)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ResolveEscalation: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.notifyManagerRegReqAssigned(ctx, req, resolveReq.ManagerID)
+	rru.publishRegReqEvent(ctx, models.RegReqAssignedEvent, req, resolveReq.ManagerID)
+
+	return http.StatusOK, nil
+}
+
+// checkManager validates that request can be handed over to user with uid
+func (rru *regReqUsecase) checkManager(ctx context.Context, uid uint64) (int, error) {
+	user, err := rru.userPsql.GetUserByID(ctx, uid)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("manager not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to get manager with err: %s", err)
+	}
+	if user.Role != models.ManagerRole {
+		return http.StatusBadRequest, fmt.Errorf("user %d isn't manager", uid)
+	}
+	if user.Deactivated {
+		return http.StatusConflict, fmt.Errorf("manager %d is deactivated", uid)
+	}
+	return http.StatusOK, nil
+}
+
+// history message keeps manager who got request, 0 means common queue
+func handoverMessage(managerID uint64) string {
+	if managerID == 0 {
+		return "to common queue"
+	}
+	return fmt.Sprintf("to manager %d", managerID)
+}
+
+func (rru *regReqUsecase) ClaimRegReq(ctx context.Context, managerID uint64, reqID uint64) (int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("RegReqUsecase.ClaimRegReq: request not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ClaimRegReq: failed to get request with err: %s", err)
+	}
+	if req.Status != PendingReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.ClaimRegReq: request isn't in pending status")
+	}
+
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.psql.ClaimRegReq(ctx, managerID, reqID)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.ClaimRegReq: request has been already claimed by another manager")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ClaimRegReq: failed to claim request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, managerID, ClaimedReqAction, "")
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.ClaimRegReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.publishRegReqEvent(ctx, models.RegReqClaimedEvent, req, managerID)
+
+	return http.StatusOK, nil
+}
+
+func validateIssues(issues models.FieldIssueList) error {
+	reasons := make(map[string]bool, len(config.RegReq.RejectionReasons))
+	for _, reason := range config.RegReq.RejectionReasons {
+		reasons[reason.Code] = true
+	}
+	for _, issue := range issues {
+		if !issueFields[issue.Field] {
+			return fmt.Errorf("unknown field %s", issue.Field)
+		}
+		if issue.Field == documentIssueField && issue.Document == "" {
+			return fmt.Errorf("document name is required for document issue")
+		}
+		if !reasons[issue.ReasonCode] {
+			return fmt.Errorf("unknown reason code %s", issue.ReasonCode)
+		}
+	}
+	return nil
+}
+
+func (rru *regReqUsecase) FailedRegReq(ctx context.Context, managerID uint64, failedReq models.FailedReq) (int, error) {
+	return rru.failedRegReq(ctx, managerID, failedReq, false)
+}
+
+// escalated requests can be failed only by admin resolving escalation
+func (rru *regReqUsecase) failedRegReq(ctx context.Context, managerID uint64, failedReq models.FailedReq, resolvesEscalation bool) (int, error) {
+	if failedReq.FailedMessage == "" && len(failedReq.Issues) == 0 {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FailedRegReq: empty failed message and issues")
+	}
+	err := validateIssues(failedReq.Issues)
+	if err != nil {
+		return http.StatusBadRequest, fmt.Errorf("RegReqUsecase.FailedRegReq: invalid issues: %s", err)
+	}
+
+	req, err := rru.psql.GetRegRequestByID(ctx, failedReq.ReqId)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FailedRegReq: failed to get request with err: %s", err)
+	}
+	if req.Status == FailedReqStatus {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FailedRegReq: request has been already in failed status")
+	}
+	if (req.Status == EscalatedReqStatus) != resolvesEscalation {
+		return http.StatusConflict, fmt.Errorf("RegReqUsecase.FailedRegReq: request escalation status doesn't allow to fail it")
+	}
+	// admin resolving escalation isn't bound by manager's claim
+	if !resolvesEscalation && req.ManagerID != 0 && req.ManagerID != managerID {
+		return http.StatusForbidden, fmt.Errorf("RegReqUsecase.FailedRegReq: request is claimed by another manager")
+	}
+	status, err := rru.inTx(ctx, func(ctx context.Context) (int, error) {
+		err := rru.psql.LockRegReq(ctx, failedReq.ReqId, req.Status)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FailedRegReq: request has been changed by someone else")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FailedRegReq: failed to lock request with err: %s", err)
+		}
+
+		err = rru.psql.FailedRegReq(ctx, managerID, failedReq)
+		if err == pgx.ErrNoRows {
+			return http.StatusConflict, fmt.Errorf("RegReqUsecase.FailedRegReq: request has been already in failed status")
+		} else if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FailedRegReq: failed to update request with err: %s", err)
+		}
+
+		err = rru.addHistory(ctx, req, managerID, FailedReqAction, failedReq.FailedMessage)
+		if err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.FailedRegReq: failed to add request history with err: %s", err)
+		}
+		return http.StatusOK, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	rru.notifyRegReqFailed(ctx, req, failedReq.FailedMessage)
+	rru.publishRegReqEvent(ctx, models.RegReqFailedEvent, req, managerID)
+
+	return http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetRegReqStats(ctx context.Context, filter models.RegReqStatsFilter) (models.RegReqStats, int, error) {
+	// by default we show stats for the last month
+	if filter.To == 0 {
+		filter.To = uint64(time.Now().Unix())
+	}
+	if filter.From == 0 && filter.To > uint64(defaultStatsPeriod.Seconds()) {
+		filter.From = filter.To - uint64(defaultStatsPeriod.Seconds())
+	}
+	if filter.From > filter.To {
+		return models.RegReqStats{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.GetRegReqStats: invalid date range")
+	}
+
+	stats, err := rru.psql.GetRegReqStats(ctx, filter)
+	if err != nil {
+		return models.RegReqStats{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetRegReqStats: failed to get stats with err: %s", err)
+	}
+	return stats, http.StatusOK, nil
+}
+
+// every request in batch is processed independently
+// so one failed request doesn't abort the others
+func (rru *regReqUsecase) BatchCompleteRegReq(ctx context.Context, managerID uint64, batch models.BatchCompleteReq) ([]models.BatchItemResult, int, error) {
+	if len(batch.ReqIDs) == 0 || len(batch.ReqIDs) > maxBatchSize {
+		return []models.BatchItemResult{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.BatchCompleteRegReq: invalid batch size %d", len(batch.ReqIDs))
+	}
+
+	results := make([]models.BatchItemResult, 0, len(batch.ReqIDs))
+	processed := make(map[uint64]bool, len(batch.ReqIDs))
+	for _, reqID := range batch.ReqIDs {
+		if processed[reqID] {
+			continue
+		}
+		processed[reqID] = true
+
+		status, err := rru.CompleteRegReq(ctx, managerID, reqID)
+		if err != nil || status != http.StatusOK {
+			rru.logger.Errorf("RegReqUsecase.BatchCompleteRegReq: request %d failed with [status=%d] [error=%s]", reqID, status, err)
+		}
+		results = append(results, models.BatchItemResult{
+			ReqID:   reqID,
+			Status:  status,
+			Err:     err,
+			Message: batchErrorMessage(status, err),
+		})
+	}
+
+	return results, http.StatusOK, nil
+}
+
+// batchFailedReqs merges req_ids with shared message and items with own messages.
+// Item replaces request with the same id from req_ids
+func batchFailedReqs(batch models.BatchFailedReq) []models.FailedReq {
+	itemIDs := make(map[uint64]bool, len(batch.Items))
+	for _, item := range batch.Items {
+		itemIDs[item.ReqId] = true
+	}
+
+	failedReqs := make([]models.FailedReq, 0, len(batch.ReqIDs)+len(batch.Items))
+	for _, reqID := range batch.ReqIDs {
+		if itemIDs[reqID] {
+			continue
+		}
+		failedReqs = append(failedReqs, models.FailedReq{
+			ReqId:         reqID,
+			FailedMessage: batch.FailedMessage,
+		})
+	}
+	return append(failedReqs, batch.Items...)
+}
+
+// batchErrorMessage explains why request of batch isn't processed.
+// Only stage errors are safe to show, other errors are described by status.
+// Internal errors aren't shown, so empty message is returned for them
+func batchErrorMessage(status int, err error) string {
+	if err == nil || status >= http.StatusInternalServerError {
+		return ""
+	}
+	var stageErr *StageError
+	if errors.As(err, &stageErr) {
+		return stageErr.Message
+	}
+	return ioutils.ResolveErrorToString(status)
+}
+
+func (rru *regReqUsecase) BatchFailedRegReq(ctx context.Context, managerID uint64, batch models.BatchFailedReq) ([]models.BatchItemResult, int, error) {
+	failedReqs := batchFailedReqs(batch)
+	if len(failedReqs) == 0 || len(failedReqs) > maxBatchSize {
+		return []models.BatchItemResult{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.BatchFailedRegReq: invalid batch size %d", len(failedReqs))
+	}
+
+	results := make([]models.BatchItemResult, 0, len(failedReqs))
+	processed := make(map[uint64]bool, len(failedReqs))
+	for _, failedReq := range failedReqs {
+		if processed[failedReq.ReqId] {
+			continue
+		}
+		processed[failedReq.ReqId] = true
+
+		status, err := rru.FailedRegReq(ctx, managerID, failedReq)
+		if err != nil || status != http.StatusOK {
+			rru.logger.Errorf("RegReqUsecase.BatchFailedRegReq: request %d failed with [status=%d] [error=%s]", failedReq.ReqId, status, err)
+		}
+		results = append(results, models.BatchItemResult{
+			ReqID:   failedReq.ReqId,
+			Status:  status,
+			Err:     err,
+			Message: batchErrorMessage(status, err),
+		})
+	}
+
+	return results, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetRejectionReasons(ctx context.Context) (models.RejectionReasonList, int, error) {
+	reasons := make(models.RejectionReasonList, 0, len(config.RegReq.RejectionReasons))
+	for _, reason := range config.RegReq.RejectionReasons {
+		reasons = append(reasons, models.RejectionReason{
+			Code:  reason.Code,
+			Title: reason.Title,
+		})
+	}
+	return reasons, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetWorkflowStages(ctx context.Context) (models.WorkflowStageRespList, int, error) {
+	stages := make(models.WorkflowStageRespList, 0, len(workflow.List()))
+	for _, stage := range workflow.List() {
+		stages = append(stages, models.WorkflowStageResp{
+			Type:              stage.Type,
+			Title:             stage.Title,
+			Owner:             stage.Owner.String(),
+			RequiredFields:    append([]string{}, stage.RequiredFields...),
+			RequiredDocuments: append([]string{}, stage.RequiredDocuments...),
+			RequiresStage:     stage.Requires,
+			GrantsStage:       stage.Grants,
+			NextType:          stage.Next,
+			Builtin:           stage.Builtin(),
+		})
+	}
+	return stages, http.StatusOK, nil
+}
+
+// GetParentChecklist shows uploaded and missing documents of parent and each of parent's children
+func (rru *regReqUsecase) GetParentChecklist(ctx context.Context, parent models.Parent) (models.DocumentChecklistList, int, error) {
+	checklists := models.DocumentChecklistList{}
+
+	parentReqs, err := rru.psql.GetRegRequestList(ctx, parent.UserID)
+	if err != nil {
+		return models.DocumentChecklistList{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetParentChecklist: failed to get parent's requests with err: %s", err)
+	}
+	parentChecklist := models.DocumentChecklist{
+		UserID:     parent.UserID,
+		Role:       parent.Role.String(),
+		FirstName:  parent.FirstName,
+		SecondName: parent.SecondName,
+		Stages:     checklistStages(models.ParentRole, 0, parentReqs),
+	}
+	if len(parentChecklist.Stages) == 0 && !parent.PassportVerified {
+		if stage, ok := workflow.Get(models.ParentPassportVerification); ok {
+			parentChecklist.Stages = append(parentChecklist.Stages, models.ChecklistStage{
+				Type:  stage.Type,
+				Title: stage.Title,
+			})
+		}
+	}
+	err = rru.fillChecklist(ctx, &parentChecklist, models.ParentRole)
+	if err != nil {
+		return models.DocumentChecklistList{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetParentChecklist: failed to get parent's documents with err: %s", err)
+	}
+	checklists = append(checklists, parentChecklist)
+
+	children, err := rru.userPsql.GetParentChildren(ctx, parent.ID)
+	if err != nil {
+		return models.DocumentChecklistList{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetParentChecklist: failed to get parent's children with err: %s", err)
+	}
+	// children are listed once per their request
+	seen := make(map[uint64]bool, len(children))
+	for _, childWithReq := range children {
+		child := childWithReq.Child
+		if seen[child.UserID] {
+			continue
+		}
+		seen[child.UserID] = true
+
+		childChecklist, status, err := rru.GetChildChecklist(ctx, child)
+		if err != nil {
+			return models.DocumentChecklistList{}, status, err
+		}
+		checklists = append(checklists, childChecklist)
+	}
+	return checklists, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetChildChecklist(ctx context.Context, child models.Child) (models.DocumentChecklist, int, error) {
+	childReqs, err := rru.psql.GetRegRequestList(ctx, child.UserID)
+	if err != nil {
+		return models.DocumentChecklist{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetChildChecklist: failed to get child's requests with err: %s", err)
+	}
+	childChecklist := models.DocumentChecklist{
+		UserID:     child.UserID,
+		Role:       models.ChildRole.String(),
+		FirstName:  child.FirstName,
+		SecondName: child.SecondName,
+		Stages:     checklistStages(models.ChildRole, child.DoneStage, childReqs),
+	}
+	err = rru.fillChecklist(ctx, &childChecklist, models.ChildRole)
+	if err != nil {
+		return models.DocumentChecklist{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetChildChecklist: failed to get child's documents with err: %s", err)
+	}
+	return childChecklist, http.StatusOK, nil
+}
+
+// GetRegReqHistory returns actions on all requests of user including completed ones
+func (rru *regReqUsecase) GetRegReqHistory(ctx context.Context, uid uint64) ([]models.RegReqHistory, int, error) {
+	history, err := rru.psql.GetRegReqHistoryByUser(ctx, uid)
+	if err != nil {
+		return []models.RegReqHistory{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetRegReqHistory: failed to get history with err: %s", err)
+	}
+	return history, http.StatusOK, nil
+}
+
+// parent has access to own requests and to requests of own children
+func (rru *regReqUsecase) checkParentReqAccess(ctx context.Context, parent models.Parent, req models.RegReqFull) (int, error) {
+	if req.UserID == parent.UserID {
+		return http.StatusOK, nil
+	}
+	child, err := rru.userPsql.GetChildByUID(ctx, req.UserID)
+	if err == pgx.ErrNoRows {
+		return http.StatusForbidden, fmt.Errorf("request doesn't belong to parent or parent's child")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to get request owner with err: %s", err)
+	}
+	isParent, err := rru.userPsql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err != nil && err != pgx.ErrNoRows {
+		return http.StatusInternalServerError, fmt.Errorf("failed to check parent-child pair with err: %s", err)
+	}
+	if !isParent {
+		return http.StatusForbidden, fmt.Errorf("request doesn't belong to parent or parent's child")
+	}
+	return http.StatusOK, nil
+}
+
+// attachments are names of files already uploaded to request owner's dir,
+// managers upload their attachments there through FileManager too
+func (rru *regReqUsecase) checkMessageAttachments(ctx context.Context, req models.RegReqFull, attachments []string) (int, error) {
+	if len(attachments) == 0 {
+		return http.StatusOK, nil
+	}
+	if len(attachments) > maxMessageAttachments {
+		return http.StatusBadRequest, fmt.Errorf("too many attachments %d", len(attachments))
+	}
+
+	owner, err := rru.userPsql.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to get request owner with err: %s", err)
+	}
+	return rru.checkUploadedFiles(ctx, owner, attachments)
+}
+
+func (rru *regReqUsecase) checkUploadedFiles(ctx context.Context, owner models.User, files []string) (int, error) {
+	ownerFiles, err := rru.fm.ListFiles(ctx, owner.ID, owner.Role)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to get request owner files with err: %s", err)
+	}
+	uploaded := make(map[string]bool, len(ownerFiles))
+	for _, fileName := range ownerFiles {
+		uploaded[fileName] = true
+	}
+	for _, file := range files {
+		if !uploaded[file] {
+			return http.StatusBadRequest, fmt.Errorf("attachment %s not found", file)
+		}
+	}
+	return http.StatusOK, nil
+}
+
+func validateMessage(msgReq models.RegReqMessageReq) error {
+	msgLen := len([]rune(strings.TrimSpace(msgReq.Body)))
+	if msgLen == 0 && len(msgReq.Attachments) == 0 {
+		return fmt.Errorf("empty message")
+	}
+	if msgLen > maxMessageLen {
+		return fmt.Errorf("message is too long")
+	}
+	return nil
+}
+
+// getThreadRegReq returns request of message thread. Thread outlives request deleted
+// after completion, so it is read by request history
+func (rru *regReqUsecase) getThreadRegReq(ctx context.Context, reqID uint64) (models.RegReqFull, int, error) {
+	req, err := rru.psql.GetRegRequestByID(ctx, reqID)
+	if err == pgx.ErrNoRows {
+		req, err = rru.psql.GetArchivedRegReq(ctx, reqID)
+	}
+	if err == pgx.ErrNoRows {
+		return models.RegReqFull{}, http.StatusNotFound, fmt.Errorf("request not found")
+	} else if err != nil {
+		return models.RegReqFull{}, http.StatusInternalServerError, fmt.Errorf("failed to get request with err: %s", err)
+	}
+	return req, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetParentRegReqMessages(ctx context.Context, parent models.Parent, reqID uint64) ([]models.RegReqMessage, int, error) {
+	req, status, err := rru.getThreadRegReq(ctx, reqID)
+	if err != nil {
+		return []models.RegReqMessage{}, status, fmt.Errorf("RegReqUsecase.GetParentRegReqMessages: %s", err)
+	}
+	status, err = rru.checkParentReqAccess(ctx, parent, req)
+	if err != nil {
+		return []models.RegReqMessage{}, status, fmt.Errorf("RegReqUsecase.GetParentRegReqMessages: %s", err)
+	}
+
+	// internal manager notes are never shown to parent
+	msgs, err := rru.psql.GetRegReqMessages(ctx, reqID, false)
+	if err != nil {
+		return []models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetParentRegReqMessages: failed to get messages with err: %s", err)
+	}
+
+	err = rru.psql.MarkRegReqMessagesRead(ctx, reqID, parent.UserID, false)
+	if err != nil {
+		return []models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetParentRegReqMessages: failed to mark messages as read with err: %s", err)
+	}
+	return msgs, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) CreateParentRegReqMessage(ctx context.Context, parent models.Parent, msgReq models.RegReqMessageReq) (models.RegReqMessage, int, error) {
+	if msgReq.Internal {
+		return models.RegReqMessage{}, http.StatusForbidden, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: parent can't create internal note")
+	}
+	err := validateMessage(msgReq)
+	if err != nil {
+		return models.RegReqMessage{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: %s", err)
+	}
+
+	req, err := rru.psql.GetRegRequestByID(ctx, msgReq.ReqID)
+	if err == pgx.ErrNoRows {
+		return models.RegReqMessage{}, http.StatusNotFound, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: request not found")
+	} else if err != nil {
+		return models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: failed to get request with err: %s", err)
+	}
+	status, err := rru.checkParentReqAccess(ctx, parent, req)
+	if err != nil {
+		return models.RegReqMessage{}, status, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: %s", err)
+	}
+	status, err = rru.checkMessageAttachments(ctx, req, msgReq.Attachments)
+	if err != nil {
+		return models.RegReqMessage{}, status, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: %s", err)
+	}
+
+	msg, err := rru.psql.CreateRegReqMessage(ctx, parent.UserID, msgReq)
+	if err != nil {
+		return models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateParentRegReqMessage: failed to create message with err: %s", err)
+	}
+	return msg, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) GetManagerRegReqMessages(ctx context.Context, managerUID uint64, reqID uint64) ([]models.RegReqMessage, int, error) {
+	_, status, err := rru.getThreadRegReq(ctx, reqID)
+	if err != nil {
+		return []models.RegReqMessage{}, status, fmt.Errorf("RegReqUsecase.GetManagerRegReqMessages: %s", err)
+	}
+
+	msgs, err := rru.psql.GetRegReqMessages(ctx, reqID, true)
+	if err != nil {
+		return []models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetManagerRegReqMessages: failed to get messages with err: %s", err)
+	}
+
+	err = rru.psql.MarkRegReqMessagesRead(ctx, reqID, managerUID, true)
+	if err != nil {
+		return []models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.GetManagerRegReqMessages: failed to mark messages as read with err: %s", err)
+	}
+	return msgs, http.StatusOK, nil
+}
+
+func (rru *regReqUsecase) CreateManagerRegReqMessage(ctx context.Context, managerUID uint64, msgReq models.RegReqMessageReq) (models.RegReqMessage, int, error) {
+	err := validateMessage(msgReq)
+	if err != nil {
+		return models.RegReqMessage{}, http.StatusBadRequest, fmt.Errorf("RegReqUsecase.CreateManagerRegReqMessage: %s", err)
+	}
+
+	req, err := rru.psql.GetRegRequestByID(ctx, msgReq.ReqID)
+	if err == pgx.ErrNoRows {
+		return models.RegReqMessage{}, http.StatusNotFound, fmt.Errorf("RegReqUsecase.CreateManagerRegReqMessage: request not found")
+	} else if err != nil {
+		return models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateManagerRegReqMessage: failed to get request with err: %s", err)
+	}
+	status, err := rru.checkMessageAttachments(ctx, req, msgReq.Attachments)
+	if err != nil {
+		return models.RegReqMessage{}, status, fmt.Errorf("RegReqUsecase.CreateManagerRegReqMessage: %s", err)
+	}
+
+	msg, err := rru.psql.CreateRegReqMessage(ctx, managerUID, msgReq)
+	if err != nil {
+		return models.RegReqMessage{}, http.StatusInternalServerError, fmt.Errorf("RegReqUsecase.CreateManagerRegReqMessage: failed to create message with err: %s", err)
+	}
+	return msg, http.StatusOK, nil
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	events_usecase "github.com/VoyakinH/lokle_backend/internal/events/usecase"
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	user_repository "github.com/VoyakinH/lokle_backend/internal/user/repository"
+	"github.com/jackc/pgx"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeUnitOfWork runs fn without transaction, so after commit hooks are called at once
+type fakeUnitOfWork struct{}
+
+func (fakeUnitOfWork) Do(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeEventsUsecase struct {
+	events_usecase.IEventsUsecase
+	events []models.RegReqEvent
+}
+
+func (fe *fakeEventsUsecase) PublishRegReqEvent(ctx context.Context, event models.RegReqEvent) error {
+	fe.events = append(fe.events, event)
+	return nil
+}
+
+type fakeHistoryRegReqRepository struct {
+	fakeRegReqRepository
+	history []models.RegReqHistory
+}
+
+func (fr *fakeHistoryRegReqRepository) AddRegReqHistory(ctx context.Context, record models.RegReqHistory) error {
+	fr.history = append(fr.history, record)
+	return nil
+}
+
+// fakeCancelRegReqRepository loses request before deletion if it has been cancelled concurrently
+type fakeCancelRegReqRepository struct {
+	fakeHistoryRegReqRepository
+	req     models.RegReqFull
+	deleted bool
+}
+
+func (fr *fakeCancelRegReqRepository) GetRegRequestByID(ctx context.Context, id uint64) (models.RegReqFull, error) {
+	return fr.req, nil
+}
+
+func (fr *fakeCancelRegReqRepository) LockRegReq(ctx context.Context, id uint64, status string) error {
+	return nil
+}
+
+func (fr *fakeCancelRegReqRepository) DeleteRegReq(ctx context.Context, id uint64) (models.RegReqFull, error) {
+	if fr.deleted {
+		return models.RegReqFull{}, pgx.ErrNoRows
+	}
+	fr.deleted = true
+	return fr.req, nil
+}
+
+// fakeWithdrawUserRepository loses parent-child links when child is deleted like db cascade does
+type fakeWithdrawUserRepository struct {
+	user_repository.IPostgresqlRepository
+	parents []models.ChildParent
+	deleted bool
+}
+
+func (fr *fakeWithdrawUserRepository) GetChildByUID(ctx context.Context, uid uint64) (models.Child, error) {
+	return models.Child{ID: uid * 10, UserID: uid}, nil
+}
+
+func (fr *fakeWithdrawUserRepository) CheckParentChildren(ctx context.Context, pid uint64, cid uint64) (bool, error) {
+	return true, nil
+}
+
+func (fr *fakeWithdrawUserRepository) LockUser(ctx context.Context, id uint64) error {
+	return nil
+}
+
+func (fr *fakeWithdrawUserRepository) GetChildParents(ctx context.Context, uid uint64) ([]models.ChildParent, error) {
+	return fr.parents, nil
+}
+
+func (fr *fakeWithdrawUserRepository) GetNotificationRecipients(ctx context.Context, uid uint64) ([]models.NotificationRecipient, error) {
+	if fr.deleted {
+		return []models.NotificationRecipient{}, nil
+	}
+	recipients := make([]models.NotificationRecipient, 0, len(fr.parents))
+	for _, parent := range fr.parents {
+		recipients = append(recipients, models.NotificationRecipient{User: parent.User})
+	}
+	return recipients, nil
+}
+
+func (fr *fakeWithdrawUserRepository) DeleteUser(ctx context.Context, id uint64) (models.User, error) {
+	fr.deleted = true
+	return models.User{ID: id}, nil
+}
+
+func TestBatchFailedReqs(t *testing.T) {
+	batch := models.BatchFailedReq{
+		ReqIDs:        []uint64{1, 2, 3},
+		FailedMessage: "shared",
+		Items: []models.FailedReq{
+			{ReqId: 2, FailedMessage: "own"},
+			{ReqId: 4, FailedMessage: "other"},
+		},
+	}
+
+	want := []models.FailedReq{
+		{ReqId: 1, FailedMessage: "shared"},
+		{ReqId: 3, FailedMessage: "shared"},
+		{ReqId: 2, FailedMessage: "own"},
+		{ReqId: 4, FailedMessage: "other"},
+	}
+	if got := batchFailedReqs(batch); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestBatchErrorMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   string
+	}{
+		{name: "success", status: http.StatusOK},
+		{
+			name:   "stage error",
+			status: http.StatusUnprocessableEntity,
+			err:    fmt.Errorf("wrapped: %w", newStageError(http.StatusUnprocessableEntity, "passport isn't uploaded")),
+			want:   "passport isn't uploaded",
+		},
+		{
+			name:   "usecase error is described by status",
+			status: http.StatusConflict,
+			err:    errors.New("RegReqUsecase.FailedRegReq: failed to check request with err: ERROR: lock timeout"),
+			want:   "conflict",
+		},
+		{
+			name:   "internal error isn't shown",
+			status: http.StatusInternalServerError,
+			err:    errors.New("RegReqUsecase.FailedRegReq: failed to update request with err: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchErrorMessage(tt.status, tt.err); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithdrawChild(t *testing.T) {
+	tests := []struct {
+		name           string
+		parents        []uint64
+		wantStatus     int
+		wantDeleted    bool
+		wantRecipients []uint64
+	}{
+		{
+			name:           "parents are notified after child is deleted",
+			parents:        []uint64{1},
+			wantStatus:     http.StatusOK,
+			wantDeleted:    true,
+			wantRecipients: []uint64{5, 1},
+		},
+		{
+			name:       "child shared with another parent isn't deleted",
+			parents:    []uint64{1, 2},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userPsql := &fakeWithdrawUserRepository{}
+			for _, uid := range tt.parents {
+				userPsql.parents = append(userPsql.parents, models.ChildParent{User: models.User{ID: uid, Role: models.ParentRole}})
+			}
+			psql := &fakeHistoryRegReqRepository{fakeRegReqRepository: fakeRegReqRepository{
+				reqs: []models.RegReqFull{{ID: 7, UserID: 5, Type: models.ChildFirstStage}},
+			}}
+			eu := &fakeEventsUsecase{}
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			rru := &regReqUsecase{
+				psql:     psql,
+				userPsql: userPsql,
+				fm:       &fakeFileStorage{},
+				eu:       eu,
+				uow:      fakeUnitOfWork{},
+				logger:   *logger,
+			}
+
+			status, err := rru.WithdrawChild(context.Background(), models.Parent{ID: 100, UserID: 1}, 5)
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d with err: %v", tt.wantStatus, status, err)
+			}
+			if userPsql.deleted != tt.wantDeleted {
+				t.Errorf("expected deleted=%t, got %t", tt.wantDeleted, userPsql.deleted)
+			}
+			if !tt.wantDeleted {
+				if len(eu.events) != 0 || len(psql.history) != 0 {
+					t.Errorf("nothing must be changed, got events %+v and history %+v", eu.events, psql.history)
+				}
+				return
+			}
+			if len(eu.events) != 1 {
+				t.Fatalf("expected one event, got %+v", eu.events)
+			}
+			if !reflect.DeepEqual(eu.events[0].Recipients, tt.wantRecipients) {
+				t.Errorf("expected recipients %v, got %v", tt.wantRecipients, eu.events[0].Recipients)
+			}
+		})
+	}
+}
+
+func TestCancelRegReq(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      string
+		deleted     bool
+		wantStatus  int
+		wantHistory bool
+	}{
+		{name: "pending request", status: PendingReqStatus, wantStatus: http.StatusOK, wantHistory: true},
+		{name: "failed request", status: FailedReqStatus, wantStatus: http.StatusOK, wantHistory: true},
+		{name: "escalated request", status: EscalatedReqStatus, wantStatus: http.StatusConflict},
+		{name: "request cancelled concurrently", status: PendingReqStatus, deleted: true, wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			psql := &fakeCancelRegReqRepository{
+				req:     models.RegReqFull{ID: 7, UserID: 1, Type: models.ChildSecondStage, Status: tt.status},
+				deleted: tt.deleted,
+			}
+			eu := &fakeEventsUsecase{}
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+			rru := &regReqUsecase{
+				psql:     psql,
+				userPsql: &fakeWithdrawUserRepository{},
+				eu:       eu,
+				uow:      fakeUnitOfWork{},
+				logger:   *logger,
+			}
+
+			status, err := rru.CancelRegReq(context.Background(), models.Parent{ID: 100, UserID: 1}, 7)
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d with err: %v", tt.wantStatus, status, err)
+			}
+			if got := len(psql.history) != 0; got != tt.wantHistory {
+				t.Errorf("expected history=%t, got %+v", tt.wantHistory, psql.history)
+			}
+		})
+	}
+}
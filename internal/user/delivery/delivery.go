@@ -1,32 +1,39 @@
 package delivery
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/VoyakinH/lokle_backend/internal/models"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/ctx_utils"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/ioutils"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/middleware"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
+	reg_req_usecase "github.com/VoyakinH/lokle_backend/internal/reg_req/usecase"
 	"github.com/VoyakinH/lokle_backend/internal/user/usecase"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 )
 
 type UserDelivery struct {
-	userUseCase usecase.IUserUsecase
-	logger      logrus.Logger
+	userUseCase   usecase.IUserUsecase
+	regReqUseCase reg_req_usecase.IRegReqUsecase
+	logger        logrus.Logger
 }
 
 func SetUserRouting(router *mux.Router,
 	uu usecase.IUserUsecase,
+	rru reg_req_usecase.IRegReqUsecase,
 	auth middleware.AuthMiddleware,
 	roleMw middleware.RoleMiddleware,
 	logger logrus.Logger) {
 	userDelivery := &UserDelivery{
-		userUseCase: uu,
-		logger:      logger,
+		userUseCase:   uu,
+		regReqUseCase: rru,
+		logger:        logger,
 	}
 
 	userAPI := router.PathPrefix("/api/v1/user/").Subrouter()
@@ -38,16 +45,47 @@ func SetUserRouting(router *mux.Router,
 
 	userAPI.HandleFunc("/parent", userDelivery.SignupParent).Methods(http.MethodPost)
 	userAPI.Handle("/parent", auth.WithAuth(http.HandlerFunc(userDelivery.GetParent))).Methods(http.MethodGet)
+	userAPI.Handle("/parent", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.UpdateParentProfile)))).Methods(http.MethodPut)
 	userAPI.Handle("/parent/children", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.GetParentChildren)))).Methods(http.MethodGet)
+	userAPI.Handle("/parent/notifications", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.GetNotificationSettings)))).Methods(http.MethodGet)
+	userAPI.Handle("/parent/notifications", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.UpdateNotificationSettings)))).Methods(http.MethodPut)
+
+	userAPI.Handle("/child", auth.WithAuth(roleMw.CheckChild(http.HandlerFunc(userDelivery.GetChild)))).Methods(http.MethodGet)
+	userAPI.Handle("/child", auth.WithAuth(roleMw.CheckChild(http.HandlerFunc(userDelivery.UpdateChildProfile)))).Methods(http.MethodPut)
+	userAPI.Handle("/child/parents", auth.WithAuth(roleMw.CheckChild(http.HandlerFunc(userDelivery.GetChildParents)))).Methods(http.MethodGet)
+
+	userAPI.Handle("/parent/invite", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.InviteParent)))).Methods(http.MethodPost)
+	userAPI.Handle("/parent/child/invitations", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.GetChildInvitations)))).Methods(http.MethodGet)
+	userAPI.Handle("/parent/invitations", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.GetReceivedInvitations)))).Methods(http.MethodGet)
+	userAPI.Handle("/parent/invitation/accept", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.AcceptInvitation)))).Methods(http.MethodPost)
+	userAPI.Handle("/parent/invitation/decline", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.DeclineInvitation)))).Methods(http.MethodPost)
+	userAPI.Handle("/parent/invitation/revoke", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.RevokeInvitation)))).Methods(http.MethodPost)
+	userAPI.Handle("/parent/child/unlink", auth.WithAuth(roleMw.CheckParent(http.HandlerFunc(userDelivery.UnlinkParent)))).Methods(http.MethodPost)
 
 	userAPI.HandleFunc("/email", userDelivery.EmailVerification).Methods(http.MethodGet)
 	userAPI.HandleFunc("/email", userDelivery.RepeatEmailVerification).Methods(http.MethodPost)
 
 	userAPI.Handle("/admin/manager", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.SignupManager)))).Methods(http.MethodPost)
 	userAPI.Handle("/admin/managers", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.GetManagers)))).Methods(http.MethodGet)
+	userAPI.Handle("/admin/manager", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.UpdateManager)))).Methods(http.MethodPut)
+	userAPI.Handle("/admin/manager", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.DeleteManager)))).Methods(http.MethodDelete)
+	userAPI.Handle("/admin/manager/deactivate", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.DeactivateManager)))).Methods(http.MethodPost)
+	userAPI.Handle("/admin/manager/reactivate", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.ReactivateManager)))).Methods(http.MethodPost)
+	userAPI.Handle("/admin/manager/password/reset", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.SendManagerPasswordReset)))).Methods(http.MethodPost)
+
+	userAPI.Handle("/admin/users", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.GetUserDirectory)))).Methods(http.MethodGet)
+	userAPI.Handle("/admin/permissions", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.GetUserPermissions)))).Methods(http.MethodGet)
+	userAPI.Handle("/admin/permission", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.GrantPermission)))).Methods(http.MethodPost)
+	userAPI.Handle("/admin/permission", auth.WithAuth(roleMw.CheckAdmin(http.HandlerFunc(userDelivery.RevokePermission)))).Methods(http.MethodDelete)
+
+	userAPI.HandleFunc("/password/reset", userDelivery.ResetPassword).Methods(http.MethodPost)
 
 	userAPI.Handle("/manager/child", auth.WithAuth(roleMw.CheckManager(http.HandlerFunc(userDelivery.GetChildByUID)))).Methods(http.MethodGet)
 	userAPI.Handle("/manager/parent", auth.WithAuth(roleMw.CheckManager(http.HandlerFunc(userDelivery.GetParentByUID)))).Methods(http.MethodGet)
+	userAPI.Handle("/manager/users", auth.WithAuth(roleMw.CheckManager(http.HandlerFunc(userDelivery.GetUserDirectory)))).Methods(http.MethodGet)
+	userAPI.Handle("/manager/invitations", auth.WithAuth(roleMw.CheckManager(http.HandlerFunc(userDelivery.GetAcceptedInvitations)))).Methods(http.MethodGet)
+	userAPI.Handle("/manager/invitation/approve", auth.WithAuth(roleMw.CheckManager(http.HandlerFunc(userDelivery.ApproveInvitation)))).Methods(http.MethodPost)
+	userAPI.Handle("/manager/invitation/reject", auth.WithAuth(roleMw.CheckManager(http.HandlerFunc(userDelivery.RejectInvitation)))).Methods(http.MethodPost)
 }
 
 const expCookieTime = 1382400
@@ -246,6 +284,37 @@ func (ud *UserDelivery) GetParent(w http.ResponseWriter, r *http.Request) {
 	ioutils.Send(w, status, tools.ParentToParentRes(parent))
 }
 
+// name change of parent with verified passport is checked by managers again
+func (ud *UserDelivery) UpdateParentProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var update models.ParentProfileUpdate
+	err := ioutils.ReadJSON(r, &update)
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	user, reverification, status, err := ud.regReqUseCase.UpdateParentProfile(ctx, *parent, update)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.ParentProfileUpdateRes{
+		User:                   tools.UserToUserRes(user),
+		PassportReverification: reverification,
+	})
+}
+
 func (ud *UserDelivery) SignupManager(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	user := ctx_utils.GetUser(ctx)
@@ -374,3 +443,665 @@ func (ud *UserDelivery) GetManagers(w http.ResponseWriter, r *http.Request) {
 
 	ioutils.Send(w, status, tools.UsersToUserResList(respList))
 }
+
+func (ud *UserDelivery) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	settings, status, err := ud.userUseCase.GetNotificationSettings(ctx, parent.UserID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, settings)
+}
+
+func (ud *UserDelivery) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var settings models.NotificationSettings
+	err := ioutils.ReadJSON(r, &settings)
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.UpdateNotificationSettings(ctx, parent.UserID, settings)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, settings)
+}
+
+func (ud *UserDelivery) GetChild(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	child := ctx_utils.GetChild(ctx)
+	if child == nil {
+		ud.logger.Errorf("%s failed get ctx child with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	ioutils.Send(w, http.StatusOK, tools.ChildToChildRes(*child))
+}
+
+func (ud *UserDelivery) UpdateChildProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	child := ctx_utils.GetChild(ctx)
+	if child == nil {
+		ud.logger.Errorf("%s failed get ctx child with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var update models.ChildProfileUpdate
+	err := ioutils.ReadJSON(r, &update)
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	updatedChild, status, err := ud.userUseCase.UpdateChildProfile(ctx, *child, update)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.ChildToChildFullRes(updatedChild))
+}
+
+func (ud *UserDelivery) GetChildParents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	child := ctx_utils.GetChild(ctx)
+	if child == nil {
+		ud.logger.Errorf("%s failed get ctx child with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	parents, status, err := ud.userUseCase.GetChildParents(ctx, child.UserID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.ChildParentsToResList(parents))
+}
+
+func (ud *UserDelivery) InviteParent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var inviteReq models.ParentInviteReq
+	err := ioutils.ReadJSON(r, &inviteReq)
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	invitation, status, err := ud.userUseCase.InviteParent(ctx, *parent, inviteReq)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, invitation)
+}
+
+func (ud *UserDelivery) GetChildInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	childID, err := strconv.ParseUint(r.URL.Query().Get("child"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid child id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	invitations, status, err := ud.userUseCase.GetChildInvitations(ctx, *parent, childID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.ParentInvitationList(invitations))
+}
+
+func (ud *UserDelivery) GetReceivedInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	invitations, status, err := ud.userUseCase.GetReceivedInvitations(ctx, *parent)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.ParentInvitationList(invitations))
+}
+
+func (ud *UserDelivery) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(r.URL.Query().Get("invitation"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid invitation id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.AcceptInvitation(ctx, *parent, invitationID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(r.URL.Query().Get("invitation"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid invitation id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.DeclineInvitation(ctx, *parent, invitationID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(r.URL.Query().Get("invitation"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid invitation id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.RevokeInvitation(ctx, *parent, invitationID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) UnlinkParent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	parent := ctx_utils.GetParent(ctx)
+	if parent == nil {
+		ud.logger.Errorf("%s failed get ctx parent with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	childID, err := strconv.ParseUint(r.URL.Query().Get("child"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid child id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.UnlinkParent(ctx, *parent, childID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) GetAcceptedInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	invitations, status, err := ud.userUseCase.GetAcceptedInvitations(ctx)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, models.ParentInvitationList(invitations))
+}
+
+func (ud *UserDelivery) ApproveInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(r.URL.Query().Get("invitation"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid invitation id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.ApproveInvitation(ctx, manager.ID, invitationID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) RejectInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	manager := ctx_utils.GetUser(ctx)
+	if manager == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	invitationID, err := strconv.ParseUint(r.URL.Query().Get("invitation"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid invitation id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.RejectInvitation(ctx, manager.ID, invitationID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) UpdateManager(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	managerID, err := strconv.ParseUint(r.URL.Query().Get("manager"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	var update models.ManagerUpdateReq
+	err = ioutils.ReadJSON(r, &update)
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	manager, status, err := ud.userUseCase.UpdateManager(ctx, managerID, update)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.UserToUserRes(manager))
+}
+
+func (ud *UserDelivery) DeactivateManager(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	managerID, err := strconv.ParseUint(r.URL.Query().Get("manager"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.DeactivateManager(ctx, managerID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) ReactivateManager(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	managerID, err := strconv.ParseUint(r.URL.Query().Get("manager"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.ReactivateManager(ctx, managerID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) DeleteManager(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	managerID, err := strconv.ParseUint(r.URL.Query().Get("manager"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	// requests go to common queue if reassign_to isn't set
+	var toManagerID uint64
+	if reassignTo := r.URL.Query().Get("reassign_to"); reassignTo != "" {
+		toManagerID, err = strconv.ParseUint(reassignTo, 10, 64)
+		if err != nil {
+			ud.logger.Errorf("%s invalid reassign_to manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+			ioutils.SendDefaultError(w, http.StatusBadRequest)
+			return
+		}
+	}
+
+	status, err := ud.userUseCase.DeleteManager(ctx, managerID, toManagerID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) SendManagerPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	managerID, err := strconv.ParseUint(r.URL.Query().Get("manager"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid manager id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.SendManagerPasswordReset(ctx, managerID)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var resetReq models.PasswordResetReq
+	err := ioutils.ReadJSON(r, &resetReq)
+	if err != nil || resetReq.Token == "" || resetReq.Password == "" {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.ResetPassword(ctx, resetReq)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func parseOptionalBool(value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func parseUserDirectoryFilter(query url.Values) (models.UserDirectoryFilter, error) {
+	var filter models.UserDirectoryFilter
+	var err error
+	if roleName := query.Get("role"); roleName != "" {
+		role, ok := models.ParseRole(strings.ToUpper(roleName))
+		if !ok {
+			return filter, fmt.Errorf("unknown role %s", roleName)
+		}
+		filter.Role = &role
+	}
+	filter.EmailVerified, err = parseOptionalBool(query.Get("email_verified"))
+	if err != nil {
+		return filter, fmt.Errorf("invalid email_verified parametr")
+	}
+	filter.PassportVerified, err = parseOptionalBool(query.Get("passport_verified"))
+	if err != nil {
+		return filter, fmt.Errorf("invalid passport_verified parametr")
+	}
+	if doneStage := query.Get("done_stage"); doneStage != "" {
+		stage, err := strconv.ParseInt(doneStage, 10, 8)
+		if err != nil || stage < 0 {
+			return filter, fmt.Errorf("invalid done_stage parametr")
+		}
+		parsedStage := models.Stage(stage)
+		filter.DoneStage = &parsedStage
+	}
+	for name, value := range map[string]*uint64{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+		"limit":        &filter.Limit,
+		"offset":       &filter.Offset,
+	} {
+		if param := query.Get(name); param != "" {
+			*value, err = strconv.ParseUint(param, 10, 64)
+			if err != nil {
+				return filter, fmt.Errorf("invalid %s parametr", name)
+			}
+		}
+	}
+	filter.Search = strings.TrimSpace(query.Get("q"))
+	return filter, nil
+}
+
+func (ud *UserDelivery) GetUserDirectory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user := ctx_utils.GetUser(ctx)
+	if user == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	filter, err := parseUserDirectoryFilter(r.URL.Query())
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	directory, status, err := ud.userUseCase.GetUserDirectory(ctx, filter)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.UserDirectoryToResp(directory))
+}
+
+func (ud *UserDelivery) GetUserPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uid, err := strconv.ParseUint(r.URL.Query().Get("user"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid user id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	permissions, status, err := ud.userUseCase.GetUserPermissions(ctx, uid)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.Send(w, status, tools.UserPermissionsToRespList(permissions))
+}
+
+func (ud *UserDelivery) GrantPermission(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	admin := ctx_utils.GetUser(ctx)
+	if admin == nil {
+		ud.logger.Errorf("%s failed get ctx user with [status=%d]", r.URL, http.StatusForbidden)
+		ioutils.SendDefaultError(w, http.StatusForbidden)
+		return
+	}
+
+	var req models.PermissionReq
+	err := ioutils.ReadJSON(r, &req)
+	if err != nil {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, http.StatusBadRequest, err)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.GrantPermission(ctx, admin.ID, req)
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
+
+func (ud *UserDelivery) RevokePermission(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	uid, err := strconv.ParseUint(query.Get("user"), 10, 64)
+	if err != nil {
+		ud.logger.Errorf("%s invalid user id parameter [status=%d]", r.URL, http.StatusBadRequest)
+		ioutils.SendDefaultError(w, http.StatusBadRequest)
+		return
+	}
+
+	status, err := ud.userUseCase.RevokePermission(ctx, uid, query.Get("permission"))
+	if err != nil || status != http.StatusOK {
+		ud.logger.Errorf("%s failed with [status=%d] [error=%s]", r.URL, status, err)
+		ioutils.SendDefaultError(w, status)
+		return
+	}
+
+	ioutils.SendWithoutBody(w, status)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
-	"github.com/VoyakinH/lokle_backend/config"
 	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/database"
 	"github.com/jackc/pgx"
 	"github.com/sirupsen/logrus"
 )
@@ -19,6 +21,7 @@ type IPostgresqlRepository interface {
 	GetChildByID(context.Context, uint64) (models.Child, error)
 	CreateUser(context.Context, models.User) (models.User, error)
 	DeleteUser(context.Context, uint64) (models.User, error)
+	LockUser(context.Context, uint64) error
 	VerifyEmail(context.Context, string) (uint64, error)
 	CreateParent(context.Context, uint64) (models.Parent, error)
 	CreateChild(context.Context, uint64, uint64, models.Child) (models.Child, error)
@@ -35,6 +38,33 @@ type IPostgresqlRepository interface {
 	CheckParentChildren(context.Context, uint64, uint64) (bool, error)
 	GetParentChildren(context.Context, uint64) (models.ChildWithRegReqList, error)
 	GetManagers(context.Context) ([]models.User, error)
+	DeactivateManager(context.Context, uint64, string) error
+	ReactivateManager(context.Context, uint64) error
+	DeleteManager(context.Context, uint64, uint64, string) error
+	GetNotificationSettings(context.Context, uint64) (models.NotificationSettings, error)
+	UpdateNotificationSettings(context.Context, uint64, models.NotificationSettings) error
+	GetNotificationRecipients(context.Context, uint64) ([]models.NotificationRecipient, error)
+	UpdateChildProfile(context.Context, models.Child) error
+	GetChildParents(context.Context, uint64) ([]models.ChildParent, error)
+	CreateParentInvitation(context.Context, models.ParentInvitation) (uint64, error)
+	GetParentInvitationByID(context.Context, uint64) (models.ParentInvitation, error)
+	GetChildInvitations(context.Context, uint64) ([]models.ParentInvitation, error)
+	GetInvitationsByEmail(context.Context, string, string) ([]models.ParentInvitation, error)
+	GetInvitationsByStatus(context.Context, string) ([]models.ParentInvitation, error)
+	HasActiveInvitation(context.Context, uint64, string) (bool, error)
+	AcceptParentInvitation(context.Context, uint64, uint64) error
+	UpdateParentInvitationStatus(context.Context, uint64, []string, string, uint64) error
+	ApproveParentInvitation(context.Context, uint64, uint64) error
+	CreateParentChildLink(context.Context, uint64, uint64, string) error
+	DeleteParentChildLink(context.Context, uint64, uint64) error
+	MoveParentChildLink(context.Context, uint64, uint64, uint64, string) error
+	CopyParentChildLinks(context.Context, uint64, uint64) error
+	GetUserDirectory(context.Context, models.UserDirectoryFilter) (models.UserDirectory, error)
+	GetUserPermissions(context.Context, uint64) ([]models.UserPermission, error)
+	HasPermission(context.Context, uint64, models.Permission) (bool, error)
+	GrantPermission(context.Context, models.UserPermission) error
+	RevokePermission(context.Context, uint64, models.Permission) error
+	GetChildrenWithParents(context.Context) ([]models.ChildWithParents, error)
 }
 
 type postgresqlRepository struct {
@@ -42,36 +72,19 @@ type postgresqlRepository struct {
 	logger logrus.Logger
 }
 
-func NewPostgresqlRepository(cfg config.PostgresConfig, logger logrus.Logger) IPostgresqlRepository {
-	connStr := fmt.Sprintf("user=%s dbname=%s password=%s host=%s port=%s sslmode=disable",
-		cfg.User,
-		cfg.DBName,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port)
-
-	pgxConnectionConfig, err := pgx.ParseConnectionString(connStr)
-	if err != nil {
-		logger.Fatalf("Invalid config string: %s", err)
-	}
-
-	pool, err := pgx.NewConnPool(pgx.ConnPoolConfig{
-		ConnConfig:     pgxConnectionConfig,
-		MaxConnections: 100,
-		AfterConnect:   nil,
-		AcquireTimeout: 0,
-	})
-	if err != nil {
-		logger.Fatalf("Error %s occurred during connection to database", err)
-	}
-
+func NewPostgresqlRepository(pool *pgx.ConnPool, logger logrus.Logger) IPostgresqlRepository {
 	return &postgresqlRepository{conn: pool, logger: logger}
 }
 
+// db returns transaction if method is called inside unit of work
+func (pr *postgresqlRepository) db(ctx context.Context) database.Querier {
+	return database.GetQuerier(ctx, pr.conn)
+}
+
 func (pr *postgresqlRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	var user models.User
-	err := pr.conn.QueryRow(
-		"SELECT id, role, first_name, second_name, last_name, phone, email, email_verified, password FROM users WHERE email = $1;",
+	err := pr.db(ctx).QueryRow(
+		"SELECT id, role, first_name, second_name, last_name, phone, email, email_verified, password, deactivated FROM users WHERE email = $1;",
 		email,
 	).Scan(
 		&user.ID,
@@ -83,6 +96,7 @@ func (pr *postgresqlRepository) GetUserByEmail(ctx context.Context, email string
 		&user.Email,
 		&user.EmailVerified,
 		&user.Password,
+		&user.Deactivated,
 	)
 	if err != nil {
 		return models.User{}, err
@@ -92,9 +106,9 @@ func (pr *postgresqlRepository) GetUserByEmail(ctx context.Context, email string
 
 func (pr *postgresqlRepository) CreateUser(ctx context.Context, user models.User) (models.User, error) {
 	var createdUser models.User
-	err := pr.conn.QueryRow(
-		`INSERT INTO users (role, first_name, second_name, last_name, phone, email, password)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO users (role, first_name, second_name, last_name, phone, email, password, create_time)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, role, first_name, second_name, last_name, phone, email, email_verified;`,
 		user.Role,
 		user.FirstName,
@@ -103,6 +117,7 @@ func (pr *postgresqlRepository) CreateUser(ctx context.Context, user models.User
 		user.Phone,
 		user.Email,
 		user.Password,
+		time.Now().Unix(),
 	).Scan(
 		&createdUser.ID,
 		&createdUser.Role,
@@ -122,7 +137,7 @@ func (pr *postgresqlRepository) CreateUser(ctx context.Context, user models.User
 
 func (pr *postgresqlRepository) DeleteUser(ctx context.Context, id uint64) (models.User, error) {
 	var deletedUser models.User
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`DELETE FROM users WHERE id = $1
 		RETURNING id, first_name, second_name, last_name, phone, email, email_verified;`,
 		id,
@@ -146,9 +161,21 @@ func (pr *postgresqlRepository) DeleteUser(ctx context.Context, id uint64) (mode
 	return deletedUser, nil
 }
 
+// LockUser locks user row until the end of current unit of work
+func (pr *postgresqlRepository) LockUser(ctx context.Context, id uint64) error {
+	var lockedID uint64
+	err := pr.db(ctx).QueryRow(
+		`SELECT id FROM users WHERE id = $1 FOR UPDATE;`,
+		id,
+	).Scan(
+		&lockedID,
+	)
+	return err
+}
+
 func (pr *postgresqlRepository) VerifyEmail(ctx context.Context, email string) (uint64, error) {
 	var updatedUserID uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE users
 		SET email_verified = true
 		WHERE email = $1
@@ -166,7 +193,7 @@ func (pr *postgresqlRepository) VerifyEmail(ctx context.Context, email string) (
 
 func (pr *postgresqlRepository) CreateParent(ctx context.Context, uid uint64) (models.Parent, error) {
 	var createdParent models.Parent
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`INSERT INTO parents (user_id)
 		VALUES ($1)
 		ON CONFLICT DO NOTHING
@@ -184,7 +211,7 @@ func (pr *postgresqlRepository) CreateParent(ctx context.Context, uid uint64) (m
 
 func (pr *postgresqlRepository) CreateChild(ctx context.Context, uid uint64, pid uint64, child models.Child) (models.Child, error) {
 	var createdChild models.Child
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`INSERT INTO children (user_id, birth_date)
 		VALUES ($1, $2)
 		ON CONFLICT DO NOTHING
@@ -202,7 +229,7 @@ func (pr *postgresqlRepository) CreateChild(ctx context.Context, uid uint64, pid
 	}
 
 	var id uint64
-	err = pr.conn.QueryRow(
+	err = pr.db(ctx).QueryRow(
 		`INSERT INTO parents_children (parent_id, child_id)
 		VALUES ($1, $2)
 		RETURNING id;`,
@@ -221,8 +248,8 @@ func (pr *postgresqlRepository) CreateChild(ctx context.Context, uid uint64, pid
 
 func (pr *postgresqlRepository) GetUserByID(ctx context.Context, uid uint64) (models.User, error) {
 	var user models.User
-	err := pr.conn.QueryRow(
-		`SELECT id, role, first_name, second_name, last_name, phone, email, email_verified, password
+	err := pr.db(ctx).QueryRow(
+		`SELECT id, role, first_name, second_name, last_name, phone, email, email_verified, password, deactivated
 		FROM users
 		WHERE id = $1;`,
 		uid,
@@ -236,6 +263,7 @@ func (pr *postgresqlRepository) GetUserByID(ctx context.Context, uid uint64) (mo
 		&user.Email,
 		&user.EmailVerified,
 		&user.Password,
+		&user.Deactivated,
 	)
 	if err != nil {
 		return models.User{}, err
@@ -245,7 +273,7 @@ func (pr *postgresqlRepository) GetUserByID(ctx context.Context, uid uint64) (mo
 
 func (pr *postgresqlRepository) GetParentByUID(ctx context.Context, uid uint64) (models.Parent, error) {
 	var parent models.Parent
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`SELECT
 			p.id,
 			p.user_id,
@@ -288,7 +316,7 @@ func (pr *postgresqlRepository) GetParentByUID(ctx context.Context, uid uint64)
 
 func (pr *postgresqlRepository) GetChildByUID(ctx context.Context, uid uint64) (models.Child, error) {
 	var child models.Child
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`SELECT
 			c.id,
 			c.user_id,
@@ -337,7 +365,7 @@ func (pr *postgresqlRepository) GetChildByUID(ctx context.Context, uid uint64) (
 
 func (pr *postgresqlRepository) GetChildByID(ctx context.Context, cid uint64) (models.Child, error) {
 	var child models.Child
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`SELECT
 			c.id,
 			c.user_id,
@@ -386,7 +414,7 @@ func (pr *postgresqlRepository) GetChildByID(ctx context.Context, cid uint64) (m
 
 func (pr *postgresqlRepository) UpdateParentDirPath(ctx context.Context, uid uint64, path string) (string, error) {
 	var insertedDirPath string
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE parents
 		SET dir_path = $2
 		WHERE user_id = $1
@@ -405,7 +433,7 @@ func (pr *postgresqlRepository) UpdateParentDirPath(ctx context.Context, uid uin
 
 func (pr *postgresqlRepository) UpdateChildDirPath(ctx context.Context, uid uint64, path string) (string, error) {
 	var insertedDirPath string
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE children
 		SET dir_path = $2
 		WHERE user_id = $1
@@ -424,7 +452,7 @@ func (pr *postgresqlRepository) UpdateChildDirPath(ctx context.Context, uid uint
 
 func (pr *postgresqlRepository) UpdateParentPassport(ctx context.Context, pid uint64, passport string) (string, error) {
 	var updatedPassport string
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE parents
 		SET passport = $2
 		WHERE id = $1
@@ -443,7 +471,7 @@ func (pr *postgresqlRepository) UpdateParentPassport(ctx context.Context, pid ui
 
 func (pr *postgresqlRepository) VerifyParentPassport(ctx context.Context, uid uint64) error {
 	var updatedPid uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE parents
 		SET passport_verified = true
 		WHERE user_id = $1
@@ -461,7 +489,7 @@ func (pr *postgresqlRepository) VerifyParentPassport(ctx context.Context, uid ui
 
 func (pr *postgresqlRepository) VerifyStageForChild(ctx context.Context, uid uint64, completedStage models.Stage) error {
 	var updatedCid uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE children
 		SET done_stage = $2
 		WHERE user_id = $1
@@ -480,7 +508,7 @@ func (pr *postgresqlRepository) VerifyStageForChild(ctx context.Context, uid uin
 
 func (pr *postgresqlRepository) UpdateUserPswd(ctx context.Context, uid uint64, newPswd string) error {
 	var updatedUid uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE users
 		SET password = $2
 		WHERE id = $1
@@ -499,7 +527,7 @@ func (pr *postgresqlRepository) UpdateUserPswd(ctx context.Context, uid uint64,
 
 func (pr *postgresqlRepository) UpdateUserWithoutEmail(ctx context.Context, user models.User) error {
 	var updatedUid uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE users
 		SET (first_name, second_name, last_name, phone) = ($2, $3, $4, $5)
 		WHERE id = $1
@@ -521,7 +549,7 @@ func (pr *postgresqlRepository) UpdateUserWithoutEmail(ctx context.Context, user
 
 func (pr *postgresqlRepository) UpdateUserWithEmail(ctx context.Context, user models.User) error {
 	var updatedUid uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE users
 		SET (first_name, second_name, last_name, phone, email) = ($2, $3, $4, $5, $6)
 		WHERE id = $1
@@ -544,7 +572,7 @@ func (pr *postgresqlRepository) UpdateUserWithEmail(ctx context.Context, user mo
 
 func (pr *postgresqlRepository) UpdateChild(ctx context.Context, child models.Child) error {
 	var cid uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE children
 		SET (birth_date, passport, place_of_residence, place_of_registration) = ($2, $3, $4, $5)
 		WHERE user_id = $1
@@ -564,9 +592,90 @@ func (pr *postgresqlRepository) UpdateChild(ctx context.Context, child models.Ch
 	return nil
 }
 
+// UpdateChildProfile updates user and child rows of child at once
+func (pr *postgresqlRepository) UpdateChildProfile(ctx context.Context, child models.Child) error {
+	var cid uint64
+	err := pr.db(ctx).QueryRow(
+		`WITH updated_user AS (
+			UPDATE users
+			SET (first_name, second_name, last_name, phone) = ($2, $3, $4, $5)
+			WHERE id = $1
+			RETURNING id
+		)
+		UPDATE children
+		SET (place_of_residence, place_of_registration) = ($6, $7)
+		WHERE user_id = (SELECT id FROM updated_user)
+		RETURNING id;`,
+		child.UserID,
+		child.FirstName,
+		child.SecondName,
+		child.LastName,
+		child.Phone,
+		child.PlaceOfResidence,
+		child.PlaceOfRegistration,
+	).Scan(
+		&cid,
+	)
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (pr *postgresqlRepository) GetChildParents(ctx context.Context, uid uint64) ([]models.ChildParent, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT
+			us.id,
+			us.role,
+			us.first_name,
+			us.second_name,
+			us.last_name,
+			us.email,
+			us.email_verified,
+			us.phone,
+			COALESCE(pc.relationship, '')
+		FROM children AS c
+		JOIN parents_children AS pc ON (pc.child_id = c.id)
+		JOIN parents AS p ON (p.id = pc.parent_id)
+		JOIN users AS us ON (us.id = p.user_id)
+		WHERE c.user_id = $1
+		ORDER BY us.id;`,
+		uid,
+	)
+	if err != nil {
+		return []models.ChildParent{}, err
+	}
+	defer rows.Close()
+
+	parents := []models.ChildParent{}
+	var parent models.ChildParent
+	for rows.Next() {
+		err := rows.Scan(
+			&parent.User.ID,
+			&parent.User.Role,
+			&parent.User.FirstName,
+			&parent.User.SecondName,
+			&parent.User.LastName,
+			&parent.User.Email,
+			&parent.User.EmailVerified,
+			&parent.User.Phone,
+			&parent.Relationship,
+		)
+		if err != nil {
+			return []models.ChildParent{}, err
+		}
+		parents = append(parents, parent)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.ChildParent{}, err
+	}
+	return parents, nil
+}
+
 func (pr *postgresqlRepository) UpdateParentChildRelationship(ctx context.Context, pid uint64, cid uint64, relationship string) error {
 	var id uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`UPDATE parents_children
 		SET relationship = $3
 		WHERE parent_id = $1 AND child_id = $2
@@ -586,7 +695,7 @@ func (pr *postgresqlRepository) UpdateParentChildRelationship(ctx context.Contex
 
 func (pr *postgresqlRepository) CheckParentChildren(ctx context.Context, pid uint64, cid uint64) (bool, error) {
 	var id uint64
-	err := pr.conn.QueryRow(
+	err := pr.db(ctx).QueryRow(
 		`SELECT id
 		FROM parents_children
 		WHERE parent_id = $1 AND child_id = $2;`,
@@ -602,15 +711,17 @@ func (pr *postgresqlRepository) CheckParentChildren(ctx context.Context, pid uin
 }
 
 type regReqWithNull struct {
-	ID         *uint64
-	UserID     *uint64
-	Type       *models.RegReqType
-	Status     *string
-	CreateTime *uint64
-	Message    *string
+	ID             *uint64
+	UserID         *uint64
+	Type           *models.RegReqType
+	Status         *string
+	CreateTime     *uint64
+	Message        *string
+	Issues         string
+	UnreadMessages uint64
 }
 
-func (rrwn *regReqWithNull) convertToRegReq() *models.RegReqResp {
+func (rrwn *regReqWithNull) convertToRegReq() (*models.RegReqResp, error) {
 	result := &models.RegReqResp{}
 	isEmpty := true
 	if rrwn.ID != nil {
@@ -638,13 +749,18 @@ func (rrwn *regReqWithNull) convertToRegReq() *models.RegReqResp {
 		isEmpty = false
 	}
 	if isEmpty {
-		return nil
+		return nil, nil
 	}
-	return result
+	result.UnreadMessages = rrwn.UnreadMessages
+	err := result.Issues.UnmarshalJSON([]byte(rrwn.Issues))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 func (pr *postgresqlRepository) GetParentChildren(ctx context.Context, pid uint64) (models.ChildWithRegReqList, error) {
-	rows, err := pr.conn.Query(
+	rows, err := pr.db(ctx).Query(
 		`SELECT
 			c.id,
 			c.user_id,
@@ -664,7 +780,29 @@ func (pr *postgresqlRepository) GetParentChildren(ctx context.Context, pid uint6
 			rr.type,
 			rr.status,
 			rr.create_time,
-			rr.message
+			rr.message,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'field', i.field,
+					'document', i.document,
+					'reason_code', i.reason_code,
+					'comment', i.comment
+				) ORDER BY i.id)
+				FROM registration_request_issues AS i
+				WHERE i.req_id = rr.id
+			), '[]')::text,
+			(
+				SELECT COUNT(*)
+				FROM registration_request_messages AS m
+				WHERE m.req_id = rr.id
+					AND NOT m.internal
+					AND m.author_id IS DISTINCT FROM p.user_id
+					AND NOT EXISTS (
+						SELECT 1
+						FROM registration_request_message_reads AS mr
+						WHERE mr.message_id = m.id AND mr.user_id = p.user_id
+					)
+			)
 		FROM parents AS p
 		JOIN parents_children AS pc ON (p.id = pc.parent_id)
 		JOIN children AS c ON (c.id = pc.child_id)
@@ -702,11 +840,16 @@ func (pr *postgresqlRepository) GetParentChildren(ctx context.Context, pid uint6
 			&tempRegReq.Status,
 			&tempRegReq.CreateTime,
 			&tempRegReq.Message,
+			&tempRegReq.Issues,
+			&tempRegReq.UnreadMessages,
 		)
 		if err != nil {
 			return models.ChildWithRegReqList{}, err
 		}
-		resp.RegReq = tempRegReq.convertToRegReq()
+		resp.RegReq, err = tempRegReq.convertToRegReq()
+		if err != nil {
+			return models.ChildWithRegReqList{}, err
+		}
 		respList = append(respList, resp)
 	}
 	if err := rows.Err(); err != nil {
@@ -716,8 +859,8 @@ func (pr *postgresqlRepository) GetParentChildren(ctx context.Context, pid uint6
 }
 
 func (pr *postgresqlRepository) GetManagers(ctx context.Context) ([]models.User, error) {
-	rows, err := pr.conn.Query(
-		`SELECT id, role, first_name, second_name, last_name, phone, email, email_verified
+	rows, err := pr.db(ctx).Query(
+		`SELECT id, role, first_name, second_name, last_name, phone, email, email_verified, deactivated
 		FROM users
 		WHERE role = $1;`,
 		models.ManagerRole,
@@ -739,6 +882,7 @@ func (pr *postgresqlRepository) GetManagers(ctx context.Context) ([]models.User,
 			&user.Phone,
 			&user.Email,
 			&user.EmailVerified,
+			&user.Deactivated,
 		)
 		if err != nil {
 			return []models.User{}, err
@@ -750,3 +894,768 @@ func (pr *postgresqlRepository) GetManagers(ctx context.Context) ([]models.User,
 	}
 	return respList, nil
 }
+
+func (pr *postgresqlRepository) GetNotificationSettings(ctx context.Context, uid uint64) (models.NotificationSettings, error) {
+	var settings models.NotificationSettings
+	err := pr.db(ctx).QueryRow(
+		`SELECT
+			COALESCE(ns.req_approved, true),
+			COALESCE(ns.req_failed, true),
+			COALESCE(ns.stage_unlocked, true)
+		FROM users AS us
+		LEFT JOIN notification_settings AS ns ON (ns.user_id = us.id)
+		WHERE us.id = $1;`,
+		uid,
+	).Scan(
+		&settings.ReqApproved,
+		&settings.ReqFailed,
+		&settings.StageUnlocked,
+	)
+	if err != nil {
+		return models.NotificationSettings{}, err
+	}
+	return settings, nil
+}
+
+func (pr *postgresqlRepository) UpdateNotificationSettings(ctx context.Context, uid uint64, settings models.NotificationSettings) error {
+	_, err := pr.db(ctx).Exec(
+		`INSERT INTO notification_settings (user_id, req_approved, req_failed, stage_unlocked)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET (req_approved, req_failed, stage_unlocked) = ($2, $3, $4);`,
+		uid,
+		settings.ReqApproved,
+		settings.ReqFailed,
+		settings.StageUnlocked,
+	)
+	return err
+}
+
+// returns parent himself for parent's uid or all child's parents for child's uid
+func (pr *postgresqlRepository) GetNotificationRecipients(ctx context.Context, uid uint64) ([]models.NotificationRecipient, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT
+			us.id,
+			us.role,
+			us.first_name,
+			us.second_name,
+			us.last_name,
+			us.email,
+			COALESCE(ns.req_approved, true),
+			COALESCE(ns.req_failed, true),
+			COALESCE(ns.stage_unlocked, true)
+		FROM users AS us
+		LEFT JOIN notification_settings AS ns ON (ns.user_id = us.id)
+		WHERE us.id IN (
+			SELECT p.user_id
+			FROM parents AS p
+			WHERE p.user_id = $1
+			UNION
+			SELECT p.user_id
+			FROM children AS c
+			JOIN parents_children AS pc ON (pc.child_id = c.id)
+			JOIN parents AS p ON (p.id = pc.parent_id)
+			WHERE c.user_id = $1
+		);`,
+		uid,
+	)
+	if err != nil {
+		return []models.NotificationRecipient{}, err
+	}
+	defer rows.Close()
+
+	var recipients []models.NotificationRecipient
+	var recipient models.NotificationRecipient
+	for rows.Next() {
+		err := rows.Scan(
+			&recipient.User.ID,
+			&recipient.User.Role,
+			&recipient.User.FirstName,
+			&recipient.User.SecondName,
+			&recipient.User.LastName,
+			&recipient.User.Email,
+			&recipient.Settings.ReqApproved,
+			&recipient.Settings.ReqFailed,
+			&recipient.Settings.StageUnlocked,
+		)
+		if err != nil {
+			return []models.NotificationRecipient{}, err
+		}
+		recipients = append(recipients, recipient)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.NotificationRecipient{}, err
+	}
+	return recipients, nil
+}
+
+const parentInvitationColumns = `
+			pi.id,
+			pi.child_id,
+			c.user_id,
+			cu.first_name,
+			cu.second_name,
+			pi.inviter_id,
+			p.user_id,
+			pu.first_name,
+			pu.second_name,
+			COALESCE(pi.invitee_id, 0),
+			COALESCE(ip.user_id, 0),
+			pi.email,
+			pi.relationship,
+			pi.status,
+			pi.create_time
+		FROM parent_invitations AS pi
+		JOIN children AS c ON (c.id = pi.child_id)
+		JOIN users AS cu ON (cu.id = c.user_id)
+		JOIN parents AS p ON (p.id = pi.inviter_id)
+		JOIN users AS pu ON (pu.id = p.user_id)
+		LEFT JOIN parents AS ip ON (ip.id = pi.invitee_id)`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanParentInvitation(row rowScanner) (models.ParentInvitation, error) {
+	var invitation models.ParentInvitation
+	err := row.Scan(
+		&invitation.ID,
+		&invitation.ChildID,
+		&invitation.ChildUserID,
+		&invitation.ChildFirstName,
+		&invitation.ChildSecondName,
+		&invitation.InviterID,
+		&invitation.InviterUserID,
+		&invitation.InviterFirstName,
+		&invitation.InviterSecondName,
+		&invitation.InviteeID,
+		&invitation.InviteeUserID,
+		&invitation.Email,
+		&invitation.Relationship,
+		&invitation.Status,
+		&invitation.CreateTime,
+	)
+	return invitation, err
+}
+
+func (pr *postgresqlRepository) queryParentInvitations(ctx context.Context, query string, args ...interface{}) ([]models.ParentInvitation, error) {
+	rows, err := pr.db(ctx).Query(query, args...)
+	if err != nil {
+		return []models.ParentInvitation{}, err
+	}
+	defer rows.Close()
+
+	invitations := []models.ParentInvitation{}
+	for rows.Next() {
+		invitation, err := scanParentInvitation(rows)
+		if err != nil {
+			return []models.ParentInvitation{}, err
+		}
+		invitations = append(invitations, invitation)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.ParentInvitation{}, err
+	}
+	return invitations, nil
+}
+
+func (pr *postgresqlRepository) CreateParentInvitation(ctx context.Context, invitation models.ParentInvitation) (uint64, error) {
+	var id uint64
+	now := time.Now().Unix()
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO parent_invitations (child_id, inviter_id, email, relationship, status, create_time, update_time)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		RETURNING id;`,
+		invitation.ChildID,
+		invitation.InviterID,
+		invitation.Email,
+		invitation.Relationship,
+		models.InvitationPending,
+		now,
+	).Scan(
+		&id,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (pr *postgresqlRepository) GetParentInvitationByID(ctx context.Context, id uint64) (models.ParentInvitation, error) {
+	return scanParentInvitation(pr.db(ctx).QueryRow(
+		`SELECT`+parentInvitationColumns+`
+		WHERE pi.id = $1;`,
+		id,
+	))
+}
+
+func (pr *postgresqlRepository) GetChildInvitations(ctx context.Context, cid uint64) ([]models.ParentInvitation, error) {
+	return pr.queryParentInvitations(ctx,
+		`SELECT`+parentInvitationColumns+`
+		WHERE pi.child_id = $1
+		ORDER BY pi.create_time DESC;`,
+		cid,
+	)
+}
+
+func (pr *postgresqlRepository) GetInvitationsByEmail(ctx context.Context, email string, status string) ([]models.ParentInvitation, error) {
+	return pr.queryParentInvitations(ctx,
+		`SELECT`+parentInvitationColumns+`
+		WHERE pi.email = $1 AND pi.status = $2
+		ORDER BY pi.create_time DESC;`,
+		email,
+		status,
+	)
+}
+
+func (pr *postgresqlRepository) GetInvitationsByStatus(ctx context.Context, status string) ([]models.ParentInvitation, error) {
+	return pr.queryParentInvitations(ctx,
+		`SELECT`+parentInvitationColumns+`
+		WHERE pi.status = $1
+		ORDER BY pi.create_time;`,
+		status,
+	)
+}
+
+// HasActiveInvitation checks if child has pending or accepted invitation for email
+func (pr *postgresqlRepository) HasActiveInvitation(ctx context.Context, cid uint64, email string) (bool, error) {
+	var exists bool
+	err := pr.db(ctx).QueryRow(
+		`SELECT EXISTS (
+			SELECT 1
+			FROM parent_invitations
+			WHERE child_id = $1 AND email = $2 AND status IN ('pending', 'accepted')
+		);`,
+		cid,
+		email,
+	).Scan(
+		&exists,
+	)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (pr *postgresqlRepository) AcceptParentInvitation(ctx context.Context, id uint64, inviteeID uint64) error {
+	var acceptedID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE parent_invitations
+		SET (invitee_id, status, update_time) = ($2, 'accepted', $3)
+		WHERE id = $1 AND status = 'pending'
+		RETURNING id;`,
+		id,
+		inviteeID,
+		time.Now().Unix(),
+	).Scan(
+		&acceptedID,
+	)
+	return err
+}
+
+// UpdateParentInvitationStatus changes status only if invitation is in one of fromStatuses
+func (pr *postgresqlRepository) UpdateParentInvitationStatus(ctx context.Context, id uint64, fromStatuses []string, status string, managerID uint64) error {
+	var updatedID uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE parent_invitations
+		SET (status, manager_id, update_time) = ($3, COALESCE(NULLIF($4::bigint, 0), manager_id), $5)
+		WHERE id = $1 AND status = ANY($2)
+		RETURNING id;`,
+		id,
+		fromStatuses,
+		status,
+		managerID,
+		time.Now().Unix(),
+	).Scan(
+		&updatedID,
+	)
+	return err
+}
+
+// ApproveParentInvitation marks accepted invitation as approved and links invitee to child
+func (pr *postgresqlRepository) ApproveParentInvitation(ctx context.Context, id uint64, managerID uint64) error {
+	var linkID uint64
+	err := pr.db(ctx).QueryRow(
+		`WITH approved AS (
+			UPDATE parent_invitations
+			SET (status, manager_id, update_time) = ('approved', $2, $3)
+			WHERE id = $1 AND status = 'accepted'
+			RETURNING invitee_id, child_id, relationship
+		)
+		INSERT INTO parents_children (parent_id, child_id, relationship)
+		SELECT invitee_id, child_id, relationship
+		FROM approved
+		RETURNING id;`,
+		id,
+		managerID,
+		time.Now().Unix(),
+	).Scan(
+		&linkID,
+	)
+	return err
+}
+
+// MoveParentChildLink relinks child from one parent to another,
+// empty relationship keeps the old one
+func (pr *postgresqlRepository) MoveParentChildLink(ctx context.Context, fromPid uint64, toPid uint64, cid uint64, relationship string) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE parents_children
+		SET (parent_id, relationship) = ($2, COALESCE(NULLIF($4, ''), relationship))
+		WHERE parent_id = $1 AND child_id = $3
+		RETURNING id;`,
+		fromPid,
+		toPid,
+		cid,
+		relationship,
+	).Scan(
+		&id,
+	)
+	return err
+}
+
+// CopyParentChildLinks links parents of child fromCid to child toCid if they aren't linked yet
+func (pr *postgresqlRepository) CopyParentChildLinks(ctx context.Context, fromCid uint64, toCid uint64) error {
+	_, err := pr.db(ctx).Exec(
+		`INSERT INTO parents_children (parent_id, child_id, relationship)
+		SELECT pc.parent_id, $2, pc.relationship
+		FROM parents_children AS pc
+		WHERE pc.child_id = $1 AND pc.parent_id NOT IN (
+			SELECT parent_id
+			FROM parents_children
+			WHERE child_id = $2
+		);`,
+		fromCid,
+		toCid,
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) CreateParentChildLink(ctx context.Context, pid uint64, cid uint64, relationship string) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`INSERT INTO parents_children (parent_id, child_id, relationship)
+		VALUES ($1, $2, NULLIF($3, ''))
+		RETURNING id;`,
+		pid,
+		cid,
+		relationship,
+	).Scan(
+		&id,
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) DeleteParentChildLink(ctx context.Context, pid uint64, cid uint64) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`DELETE FROM parents_children
+		WHERE parent_id = $1 AND child_id = $2
+		RETURNING id;`,
+		pid,
+		cid,
+	).Scan(
+		&id,
+	)
+	return err
+}
+
+// DeactivateManager marks manager as deactivated and returns his in-flight requests to common queue
+func (pr *postgresqlRepository) DeactivateManager(ctx context.Context, uid uint64, message string) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`WITH deactivated AS (
+			UPDATE users
+			SET deactivated = true
+			WHERE id = $1 AND role = $2 AND NOT deactivated
+			RETURNING id
+		), released AS (
+			UPDATE registration_requests
+			SET manager_id = NULL
+			WHERE manager_id IN (SELECT id FROM deactivated) AND status IN ('pending', 'escalated')
+			RETURNING id, user_id, type, create_time
+		), history AS (
+			INSERT INTO registration_requests_history (req_id, user_id, manager_id, type, action, message, req_create_time, create_time)
+			SELECT id, user_id, $1, type, 'reassigned', $3, create_time, $4
+			FROM released
+		)
+		SELECT id FROM deactivated;`,
+		uid,
+		models.ManagerRole,
+		message,
+		time.Now().Unix(),
+	).Scan(
+		&id,
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) ReactivateManager(ctx context.Context, uid uint64) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`UPDATE users
+		SET deactivated = false
+		WHERE id = $1 AND role = $2 AND deactivated
+		RETURNING id;`,
+		uid,
+		models.ManagerRole,
+	).Scan(
+		&id,
+	)
+	return err
+}
+
+// DeleteManager hands over in-flight requests of manager to toManagerID
+// (or to common queue if it is 0) and deletes manager
+func (pr *postgresqlRepository) DeleteManager(ctx context.Context, uid uint64, toManagerID uint64, message string) error {
+	var id uint64
+	err := pr.db(ctx).QueryRow(
+		`WITH handed_over AS (
+			UPDATE registration_requests
+			SET manager_id = NULLIF($2::bigint, 0)
+			WHERE manager_id = $1 AND status IN ('pending', 'escalated')
+			RETURNING id, user_id, type, create_time
+		), history AS (
+			INSERT INTO registration_requests_history (req_id, user_id, manager_id, type, action, message, req_create_time, create_time)
+			SELECT id, user_id, $1, type, 'reassigned', $4, create_time, $5
+			FROM handed_over
+		)
+		DELETE FROM users
+		WHERE id = $1 AND role = $3
+		RETURNING id;`,
+		uid,
+		toManagerID,
+		models.ManagerRole,
+		message,
+		time.Now().Unix(),
+	).Scan(
+		&id,
+	)
+	return err
+}
+
+// the same expression is indexed by users_search_index
+const userSearchExpr = `lower(coalesce(us.last_name, '') || ' ' || us.first_name || ' ' || us.second_name || ' ' || us.email::text || ' ' || us.phone)`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetUserDirectory returns page of users matching filter and links between parents and children on it
+func (pr *postgresqlRepository) GetUserDirectory(ctx context.Context, filter models.UserDirectoryFilter) (models.UserDirectory, error) {
+	conditions := []string{"TRUE"}
+	args := []interface{}{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Role != nil {
+		addCondition("us.role = $%d", *filter.Role)
+	}
+	if filter.EmailVerified != nil {
+		addCondition("us.email_verified = $%d", *filter.EmailVerified)
+	}
+	// role specific filters leave only users of this role
+	if filter.PassportVerified != nil {
+		addCondition("p.passport_verified = $%d", *filter.PassportVerified)
+	}
+	if filter.DoneStage != nil {
+		addCondition("c.done_stage = $%d", *filter.DoneStage)
+	}
+	if filter.CreatedFrom != 0 {
+		addCondition("us.create_time >= $%d", filter.CreatedFrom)
+	}
+	if filter.CreatedTo != 0 {
+		addCondition("us.create_time <= $%d", filter.CreatedTo)
+	}
+	order := "us.id"
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		args = append(args, likeEscaper.Replace(search), search)
+		// substring match or similar words to find names with typos
+		conditions = append(conditions, fmt.Sprintf("(%[1]s LIKE '%%' || $%[2]d || '%%' OR $%[3]d <%% %[1]s)",
+			userSearchExpr, len(args)-1, len(args)))
+		order = fmt.Sprintf("word_similarity($%d, %s) DESC, us.id", len(args), userSearchExpr)
+	}
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := pr.db(ctx).Query(
+		fmt.Sprintf(`SELECT
+			us.id,
+			us.role,
+			us.first_name,
+			us.second_name,
+			us.last_name,
+			us.email,
+			us.email_verified,
+			us.phone,
+			us.deactivated,
+			us.create_time,
+			COALESCE(p.passport_verified, false),
+			COALESCE(c.done_stage, 0),
+			COUNT(*) OVER ()
+		FROM users AS us
+		LEFT JOIN parents AS p ON (p.user_id = us.id)
+		LEFT JOIN children AS c ON (c.user_id = us.id)
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d;`, strings.Join(conditions, " AND "), order, len(args)-1, len(args)),
+		args...,
+	)
+	if err != nil {
+		return models.UserDirectory{}, err
+	}
+	defer rows.Close()
+
+	directory := models.UserDirectory{Users: []models.DirectoryUser{}}
+	var uids []int64
+	for rows.Next() {
+		var user models.DirectoryUser
+		err := rows.Scan(
+			&user.ID,
+			&user.Role,
+			&user.FirstName,
+			&user.SecondName,
+			&user.LastName,
+			&user.Email,
+			&user.EmailVerified,
+			&user.Phone,
+			&user.Deactivated,
+			&user.CreateTime,
+			&user.PassportVerified,
+			&user.DoneStage,
+			&directory.Total,
+		)
+		if err != nil {
+			return models.UserDirectory{}, err
+		}
+		directory.Users = append(directory.Users, user)
+		uids = append(uids, int64(user.ID))
+	}
+	if err := rows.Err(); err != nil {
+		return models.UserDirectory{}, err
+	}
+	rows.Close()
+	if len(uids) == 0 {
+		return directory, nil
+	}
+
+	links, err := pr.db(ctx).Query(
+		`SELECT
+			pu.id,
+			pu.first_name,
+			pu.second_name,
+			pu.last_name,
+			cu.id,
+			cu.first_name,
+			cu.second_name,
+			cu.last_name,
+			COALESCE(pc.relationship, '')
+		FROM parents_children AS pc
+		JOIN parents AS p ON (p.id = pc.parent_id)
+		JOIN users AS pu ON (pu.id = p.user_id)
+		JOIN children AS c ON (c.id = pc.child_id)
+		JOIN users AS cu ON (cu.id = c.user_id)
+		WHERE pu.id = ANY($1) OR cu.id = ANY($1)
+		ORDER BY pu.id, cu.id;`,
+		uids,
+	)
+	if err != nil {
+		return models.UserDirectory{}, err
+	}
+	defer links.Close()
+
+	userIndex := make(map[uint64]int, len(directory.Users))
+	for i, user := range directory.Users {
+		userIndex[user.ID] = i
+	}
+	for links.Next() {
+		var parent, child models.UserLink
+		err := links.Scan(
+			&parent.UserID,
+			&parent.FirstName,
+			&parent.SecondName,
+			&parent.LastName,
+			&child.UserID,
+			&child.FirstName,
+			&child.SecondName,
+			&child.LastName,
+			&parent.Relationship,
+		)
+		if err != nil {
+			return models.UserDirectory{}, err
+		}
+		// relationship is parent's role for child, so it is shown on parent link only
+		if i, ok := userIndex[parent.UserID]; ok {
+			directory.Users[i].Children = append(directory.Users[i].Children, child)
+		}
+		if i, ok := userIndex[child.UserID]; ok {
+			directory.Users[i].Parents = append(directory.Users[i].Parents, parent)
+		}
+	}
+	if err := links.Err(); err != nil {
+		return models.UserDirectory{}, err
+	}
+	return directory, nil
+}
+
+func (pr *postgresqlRepository) GetUserPermissions(ctx context.Context, uid uint64) ([]models.UserPermission, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT user_id, permission, COALESCE(granted_by, 0), create_time
+		FROM user_permissions
+		WHERE user_id = $1
+		ORDER BY permission;`,
+		uid,
+	)
+	if err != nil {
+		return []models.UserPermission{}, err
+	}
+	defer rows.Close()
+
+	permissions := []models.UserPermission{}
+	var permission models.UserPermission
+	for rows.Next() {
+		err := rows.Scan(
+			&permission.UserID,
+			&permission.Permission,
+			&permission.GrantedBy,
+			&permission.CreateTime,
+		)
+		if err != nil {
+			return []models.UserPermission{}, err
+		}
+		permissions = append(permissions, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return []models.UserPermission{}, err
+	}
+	return permissions, nil
+}
+
+func (pr *postgresqlRepository) HasPermission(ctx context.Context, uid uint64, permission models.Permission) (bool, error) {
+	var exists bool
+	err := pr.db(ctx).QueryRow(
+		`SELECT EXISTS (
+			SELECT 1
+			FROM user_permissions
+			WHERE user_id = $1 AND permission = $2
+		);`,
+		uid,
+		permission,
+	).Scan(
+		&exists,
+	)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GrantPermission keeps first grant if permission is granted again
+func (pr *postgresqlRepository) GrantPermission(ctx context.Context, permission models.UserPermission) error {
+	_, err := pr.db(ctx).Exec(
+		`INSERT INTO user_permissions (user_id, permission, granted_by, create_time)
+		VALUES ($1, $2, NULLIF($3::bigint, 0), $4)
+		ON CONFLICT (user_id, permission) DO NOTHING;`,
+		permission.UserID,
+		permission.Permission,
+		permission.GrantedBy,
+		time.Now().Unix(),
+	)
+	return err
+}
+
+func (pr *postgresqlRepository) RevokePermission(ctx context.Context, uid uint64, permission models.Permission) error {
+	var revokedID uint64
+	err := pr.db(ctx).QueryRow(
+		`DELETE FROM user_permissions
+		WHERE user_id = $1 AND permission = $2
+		RETURNING user_id;`,
+		uid,
+		permission,
+	).Scan(
+		&revokedID,
+	)
+	return err
+}
+
+// GetChildrenWithParents returns all children, rows of child with several parents are merged
+func (pr *postgresqlRepository) GetChildrenWithParents(ctx context.Context) ([]models.ChildWithParents, error) {
+	rows, err := pr.db(ctx).Query(
+		`SELECT
+			c.id,
+			cu.id,
+			cu.first_name,
+			cu.second_name,
+			cu.last_name,
+			cu.email,
+			cu.phone,
+			cu.create_time,
+			c.birth_date,
+			c.done_stage,
+			c.passport,
+			c.place_of_residence,
+			c.place_of_registration,
+			COALESCE(pu.id, 0),
+			COALESCE(pu.first_name, ''),
+			COALESCE(pu.second_name, ''),
+			COALESCE(pu.last_name, ''),
+			COALESCE(pu.email::text, ''),
+			COALESCE(pu.phone, ''),
+			COALESCE(p.passport, ''),
+			COALESCE(pc.relationship, '')
+		FROM children AS c
+		JOIN users AS cu ON (cu.id = c.user_id)
+		LEFT JOIN parents_children AS pc ON (pc.child_id = c.id)
+		LEFT JOIN parents AS p ON (p.id = pc.parent_id)
+		LEFT JOIN users AS pu ON (pu.id = p.user_id)
+		ORDER BY cu.last_name, cu.first_name, cu.id, pu.id;`,
+	)
+	if err != nil {
+		return []models.ChildWithParents{}, err
+	}
+	defer rows.Close()
+
+	children := []models.ChildWithParents{}
+	for rows.Next() {
+		var child models.ChildWithParents
+		var parent models.ParentWithRelationship
+		err := rows.Scan(
+			&child.Child.ID,
+			&child.Child.UserID,
+			&child.Child.FirstName,
+			&child.Child.SecondName,
+			&child.Child.LastName,
+			&child.Child.Email,
+			&child.Child.Phone,
+			&child.CreateTime,
+			&child.Child.BirthDate,
+			&child.Child.DoneStage,
+			&child.Child.Passport,
+			&child.Child.PlaceOfResidence,
+			&child.Child.PlaceOfRegistration,
+			&parent.Parent.UserID,
+			&parent.Parent.FirstName,
+			&parent.Parent.SecondName,
+			&parent.Parent.LastName,
+			&parent.Parent.Email,
+			&parent.Parent.Phone,
+			&parent.Parent.Passport,
+			&parent.Relationship,
+		)
+		if err != nil {
+			return []models.ChildWithParents{}, err
+		}
+		if last := len(children) - 1; last < 0 || children[last].Child.UserID != child.Child.UserID {
+			child.Child.Role = models.ChildRole
+			children = append(children, child)
+		}
+		if parent.Parent.UserID != 0 {
+			parent.Parent.Role = models.ParentRole
+			last := len(children) - 1
+			children[last].Parents = append(children[last].Parents, parent)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return []models.ChildWithParents{}, err
+	}
+	return children, nil
+}
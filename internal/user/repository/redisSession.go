@@ -14,6 +14,7 @@ type IRedisSessionRepository interface {
 	DeleteSession(context.Context, string) error
 	CheckSession(context.Context, string) (string, error)
 	ProlongSession(context.Context, string, time.Duration) error
+	DeleteUserSessions(context.Context, string) error
 }
 
 type redisSessionRepository struct {
@@ -32,16 +33,41 @@ func NewRedisSessionRepository(cfg config.RedisConfig, logger logrus.Logger) IRe
 	}
 }
 
+// sessions of each user are indexed to revoke them all at once
+func userSessionsKey(email string) string {
+	return "user-sessions:" + email
+}
+
 func (rsr *redisSessionRepository) CreateSession(ctx context.Context, sessionID string, email string, expCookieTime time.Duration) error {
 	_, err := rsr.client.SetNX(ctx, sessionID, email, expCookieTime*time.Second).Result()
-	return err
+	if err != nil {
+		return err
+	}
+	err = rsr.client.SAdd(ctx, userSessionsKey(email), sessionID).Err()
+	if err != nil {
+		return err
+	}
+	rsr.client.Expire(ctx, userSessionsKey(email), expCookieTime*time.Second)
+	return nil
 }
 
 func (rsr *redisSessionRepository) DeleteSession(ctx context.Context, cookie string) error {
+	email, err := rsr.client.Get(ctx, cookie).Result()
+	if err == nil {
+		rsr.client.SRem(ctx, userSessionsKey(email), cookie)
+	}
 	rsr.client.Del(ctx, cookie).Val()
 	return nil
 }
 
+func (rsr *redisSessionRepository) DeleteUserSessions(ctx context.Context, email string) error {
+	sessions, err := rsr.client.SMembers(ctx, userSessionsKey(email)).Result()
+	if err != nil {
+		return err
+	}
+	return rsr.client.Del(ctx, append(sessions, userSessionsKey(email))...).Err()
+}
+
 func (rsr *redisSessionRepository) CheckSession(ctx context.Context, cookie string) (string, error) {
 	val, err := rsr.client.Get(ctx, cookie).Result()
 	if err != nil {
@@ -52,5 +78,9 @@ func (rsr *redisSessionRepository) CheckSession(ctx context.Context, cookie stri
 
 func (rsr *redisSessionRepository) ProlongSession(ctx context.Context, cookie string, expCookieTime time.Duration) error {
 	rsr.client.Expire(ctx, cookie, expCookieTime*time.Second)
+	email, err := rsr.client.Get(ctx, cookie).Result()
+	if err == nil {
+		rsr.client.Expire(ctx, userSessionsKey(email), expCookieTime*time.Second)
+	}
 	return nil
 }
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/mailer"
+	"github.com/jackc/pgx"
+)
+
+const maxRelationshipLen = 16
+
+// checkLinkedChild returns child with user id childUID if parent is linked to him
+func (uu *userUsecase) checkLinkedChild(ctx context.Context, parent models.Parent, childUID uint64) (models.Child, int, error) {
+	child, err := uu.psql.GetChildByUID(ctx, childUID)
+	if err == pgx.ErrNoRows {
+		return models.Child{}, http.StatusNotFound, fmt.Errorf("child not found")
+	} else if err != nil {
+		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("failed to get child with err: %s", err)
+	}
+	_, err = uu.psql.CheckParentChildren(ctx, parent.ID, child.ID)
+	if err == pgx.ErrNoRows {
+		return models.Child{}, http.StatusForbidden, fmt.Errorf("child %d isn't linked to parent", childUID)
+	} else if err != nil {
+		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("failed to check parent-child pair with err: %s", err)
+	}
+	return child, http.StatusOK, nil
+}
+
+func (uu *userUsecase) InviteParent(ctx context.Context, parent models.Parent, inviteReq models.ParentInviteReq) (models.ParentInvitation, int, error) {
+	email := strings.TrimSpace(inviteReq.Email)
+	if !strings.Contains(email, "@") || utf8.RuneCountInString(inviteReq.Relationship) > maxRelationshipLen {
+		return models.ParentInvitation{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.InviteParent: invalid email or relationship")
+	}
+	if strings.EqualFold(email, parent.Email) {
+		return models.ParentInvitation{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.InviteParent: parent can't invite himself")
+	}
+	child, status, err := uu.checkLinkedChild(ctx, parent, inviteReq.ChildID)
+	if err != nil {
+		return models.ParentInvitation{}, status, fmt.Errorf("UserUsecase.InviteParent: %s", err)
+	}
+
+	linkedParents, err := uu.psql.GetChildParents(ctx, child.UserID)
+	if err != nil {
+		return models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.InviteParent: failed to get child's parents with err: %s", err)
+	}
+	for _, linkedParent := range linkedParents {
+		if strings.EqualFold(linkedParent.User.Email, email) {
+			return models.ParentInvitation{}, http.StatusConflict, fmt.Errorf("UserUsecase.InviteParent: parent is already linked to child")
+		}
+	}
+	exists, err := uu.psql.HasActiveInvitation(ctx, child.ID, email)
+	if err != nil {
+		return models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.InviteParent: failed to check invitations with err: %s", err)
+	}
+	if exists {
+		return models.ParentInvitation{}, http.StatusConflict, fmt.Errorf("UserUsecase.InviteParent: child already has active invitation for this email")
+	}
+
+	id, err := uu.psql.CreateParentInvitation(ctx, models.ParentInvitation{
+		ChildID:      child.ID,
+		InviterID:    parent.ID,
+		Email:        email,
+		Relationship: inviteReq.Relationship,
+	})
+	if err != nil {
+		return models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.InviteParent: failed to create invitation with err: %s", err)
+	}
+	invitation, err := uu.psql.GetParentInvitationByID(ctx, id)
+	if err != nil {
+		return models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.InviteParent: failed to get created invitation with err: %s", err)
+	}
+
+	// invitation is shown in account after login, so email is best effort
+	err = mailer.SendParentInvitationEmail(email,
+		fmt.Sprintf("%s %s", parent.FirstName, parent.SecondName),
+		fmt.Sprintf("%s %s", child.FirstName, child.SecondName))
+	if err != nil {
+		uu.logger.Errorf("UserUsecase.InviteParent: failed to send invitation %d email with err: %s", id, err)
+	}
+	return invitation, http.StatusOK, nil
+}
+
+func (uu *userUsecase) GetChildInvitations(ctx context.Context, parent models.Parent, childUID uint64) ([]models.ParentInvitation, int, error) {
+	child, status, err := uu.checkLinkedChild(ctx, parent, childUID)
+	if err != nil {
+		return []models.ParentInvitation{}, status, fmt.Errorf("UserUsecase.GetChildInvitations: %s", err)
+	}
+	invitations, err := uu.psql.GetChildInvitations(ctx, child.ID)
+	if err != nil {
+		return []models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetChildInvitations: failed to get invitations with err: %s", err)
+	}
+	return invitations, http.StatusOK, nil
+}
+
+// GetReceivedInvitations returns pending invitations sent to parent's email
+func (uu *userUsecase) GetReceivedInvitations(ctx context.Context, parent models.Parent) ([]models.ParentInvitation, int, error) {
+	invitations, err := uu.psql.GetInvitationsByEmail(ctx, parent.Email, models.InvitationPending)
+	if err != nil {
+		return []models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetReceivedInvitations: failed to get invitations with err: %s", err)
+	}
+	return invitations, http.StatusOK, nil
+}
+
+// getReceivedInvitation returns invitation if it was sent to parent's email
+func (uu *userUsecase) getReceivedInvitation(ctx context.Context, parent models.Parent, id uint64) (models.ParentInvitation, int, error) {
+	invitation, err := uu.psql.GetParentInvitationByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return models.ParentInvitation{}, http.StatusNotFound, fmt.Errorf("invitation not found")
+	} else if err != nil {
+		return models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("failed to get invitation with err: %s", err)
+	}
+	if !strings.EqualFold(invitation.Email, parent.Email) {
+		return models.ParentInvitation{}, http.StatusForbidden, fmt.Errorf("invitation was sent to another email")
+	}
+	return invitation, http.StatusOK, nil
+}
+
+func (uu *userUsecase) AcceptInvitation(ctx context.Context, parent models.Parent, id uint64) (int, error) {
+	if !parent.EmailVerified {
+		return http.StatusForbidden, fmt.Errorf("UserUsecase.AcceptInvitation: parent email isn't verified")
+	}
+	invitation, status, err := uu.getReceivedInvitation(ctx, parent, id)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.AcceptInvitation: %s", err)
+	}
+	_, err = uu.psql.CheckParentChildren(ctx, parent.ID, invitation.ChildID)
+	if err == nil {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.AcceptInvitation: parent is already linked to child")
+	} else if err != pgx.ErrNoRows {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.AcceptInvitation: failed to check parent-child pair with err: %s", err)
+	}
+
+	err = uu.psql.AcceptParentInvitation(ctx, invitation.ID, parent.ID)
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.AcceptInvitation: invitation isn't pending")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.AcceptInvitation: failed to accept invitation with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+func (uu *userUsecase) DeclineInvitation(ctx context.Context, parent models.Parent, id uint64) (int, error) {
+	invitation, status, err := uu.getReceivedInvitation(ctx, parent, id)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.DeclineInvitation: %s", err)
+	}
+	err = uu.psql.UpdateParentInvitationStatus(ctx, invitation.ID,
+		[]string{models.InvitationPending, models.InvitationAccepted}, models.InvitationDeclined, 0)
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.DeclineInvitation: invitation has been already resolved")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.DeclineInvitation: failed to decline invitation with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// RevokeInvitation cancels invitation by any parent linked to child
+func (uu *userUsecase) RevokeInvitation(ctx context.Context, parent models.Parent, id uint64) (int, error) {
+	invitation, err := uu.psql.GetParentInvitationByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.RevokeInvitation: invitation not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.RevokeInvitation: failed to get invitation with err: %s", err)
+	}
+	_, status, err := uu.checkLinkedChild(ctx, parent, invitation.ChildUserID)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.RevokeInvitation: %s", err)
+	}
+
+	err = uu.psql.UpdateParentInvitationStatus(ctx, invitation.ID,
+		[]string{models.InvitationPending, models.InvitationAccepted}, models.InvitationRevoked, 0)
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.RevokeInvitation: invitation has been already resolved")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.RevokeInvitation: failed to revoke invitation with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// UnlinkParent removes link between child and parent. Parent can remove only own link,
+// child always keeps at least one linked parent
+func (uu *userUsecase) UnlinkParent(ctx context.Context, parent models.Parent, childUID uint64) (int, error) {
+	child, status, err := uu.checkLinkedChild(ctx, parent, childUID)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.UnlinkParent: %s", err)
+	}
+
+	linkedParents, err := uu.psql.GetChildParents(ctx, child.UserID)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.UnlinkParent: failed to get child's parents with err: %s", err)
+	}
+	if len(linkedParents) < 2 {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.UnlinkParent: child can't be left without parent")
+	}
+
+	err = uu.psql.DeleteParentChildLink(ctx, parent.ID, child.ID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.UnlinkParent: parent isn't linked to child")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.UnlinkParent: failed to delete link with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// GetAcceptedInvitations returns invitations waiting for admissions approval
+func (uu *userUsecase) GetAcceptedInvitations(ctx context.Context) ([]models.ParentInvitation, int, error) {
+	invitations, err := uu.psql.GetInvitationsByStatus(ctx, models.InvitationAccepted)
+	if err != nil {
+		return []models.ParentInvitation{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetAcceptedInvitations: failed to get invitations with err: %s", err)
+	}
+	return invitations, http.StatusOK, nil
+}
+
+func (uu *userUsecase) ApproveInvitation(ctx context.Context, managerID uint64, id uint64) (int, error) {
+	invitation, err := uu.psql.GetParentInvitationByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.ApproveInvitation: invitation not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ApproveInvitation: failed to get invitation with err: %s", err)
+	}
+	if invitation.Status != models.InvitationAccepted {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.ApproveInvitation: only accepted invitation can be approved")
+	}
+	_, err = uu.psql.CheckParentChildren(ctx, invitation.InviteeID, invitation.ChildID)
+	if err == nil {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.ApproveInvitation: parent is already linked to child")
+	} else if err != pgx.ErrNoRows {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ApproveInvitation: failed to check parent-child pair with err: %s", err)
+	}
+
+	err = uu.psql.ApproveParentInvitation(ctx, invitation.ID, managerID)
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.ApproveInvitation: invitation has been already resolved")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ApproveInvitation: failed to approve invitation with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+func (uu *userUsecase) RejectInvitation(ctx context.Context, managerID uint64, id uint64) (int, error) {
+	err := uu.psql.UpdateParentInvitationStatus(ctx, id,
+		[]string{models.InvitationAccepted}, models.InvitationRejected, managerID)
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.RejectInvitation: only accepted invitation can be rejected")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.RejectInvitation: failed to reject invitation with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
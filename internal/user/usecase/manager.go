@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/hasher"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/mailer"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx"
+)
+
+const expPasswordResetTokenTime = 86400
+
+// reset tokens share redis with email verification tokens
+func passwordResetKey(token string) string {
+	return "password-reset:" + token
+}
+
+func (uu *userUsecase) getManager(ctx context.Context, uid uint64) (models.User, int, error) {
+	manager, err := uu.psql.GetUserByID(ctx, uid)
+	if err == pgx.ErrNoRows {
+		return models.User{}, http.StatusNotFound, fmt.Errorf("manager not found")
+	} else if err != nil {
+		return models.User{}, http.StatusInternalServerError, fmt.Errorf("failed to get manager with err: %s", err)
+	}
+	if manager.Role != models.ManagerRole {
+		return models.User{}, http.StatusNotFound, fmt.Errorf("user %d isn't manager", uid)
+	}
+	return manager, http.StatusOK, nil
+}
+
+func (uu *userUsecase) UpdateManager(ctx context.Context, uid uint64, update models.ManagerUpdateReq) (models.User, int, error) {
+	if update.FirstName == "" || update.SecondName == "" || update.Phone == "" {
+		return models.User{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.UpdateManager: first name, second name and phone are required")
+	}
+	manager, status, err := uu.getManager(ctx, uid)
+	if err != nil {
+		return models.User{}, status, fmt.Errorf("UserUsecase.UpdateManager: %s", err)
+	}
+
+	manager.FirstName = update.FirstName
+	manager.SecondName = update.SecondName
+	manager.LastName = update.LastName
+	manager.Phone = update.Phone
+	err = uu.psql.UpdateUserWithoutEmail(ctx, manager)
+	if err != nil {
+		return models.User{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.UpdateManager: failed to update manager with err: %s", err)
+	}
+	return manager, http.StatusOK, nil
+}
+
+// DeactivateManager blocks manager's login, revokes his sessions and returns
+// his claimed requests to common queue
+func (uu *userUsecase) DeactivateManager(ctx context.Context, uid uint64) (int, error) {
+	manager, status, err := uu.getManager(ctx, uid)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.DeactivateManager: %s", err)
+	}
+	if manager.Deactivated {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.DeactivateManager: manager has been already deactivated")
+	}
+
+	err = uu.psql.DeactivateManager(ctx, uid, "to common queue")
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.DeactivateManager: manager has been already deactivated")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.DeactivateManager: failed to deactivate manager with err: %s", err)
+	}
+
+	// sessions are rejected for deactivated user anyway, so failure here isn't critical
+	err = uu.rdsSession.DeleteUserSessions(ctx, manager.Email)
+	if err != nil {
+		uu.logger.Errorf("UserUsecase.DeactivateManager: failed to delete sessions of manager %d with err: %s", uid, err)
+	}
+	return http.StatusOK, nil
+}
+
+func (uu *userUsecase) ReactivateManager(ctx context.Context, uid uint64) (int, error) {
+	manager, status, err := uu.getManager(ctx, uid)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.ReactivateManager: %s", err)
+	}
+	if !manager.Deactivated {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.ReactivateManager: manager isn't deactivated")
+	}
+
+	err = uu.psql.ReactivateManager(ctx, uid)
+	if err == pgx.ErrNoRows {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.ReactivateManager: manager isn't deactivated")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ReactivateManager: failed to reactivate manager with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// DeleteManager reassigns manager's in-flight requests to another active manager
+// or to common queue if toManagerID is 0 and deletes manager
+func (uu *userUsecase) DeleteManager(ctx context.Context, uid uint64, toManagerID uint64) (int, error) {
+	manager, status, err := uu.getManager(ctx, uid)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.DeleteManager: %s", err)
+	}
+
+	message := "to common queue"
+	if toManagerID != 0 {
+		if toManagerID == uid {
+			return http.StatusBadRequest, fmt.Errorf("UserUsecase.DeleteManager: requests can't be reassigned to deleted manager")
+		}
+		toManager, status, err := uu.getManager(ctx, toManagerID)
+		if err != nil {
+			return status, fmt.Errorf("UserUsecase.DeleteManager: %s", err)
+		}
+		if toManager.Deactivated {
+			return http.StatusConflict, fmt.Errorf("UserUsecase.DeleteManager: requests can't be reassigned to deactivated manager")
+		}
+		message = fmt.Sprintf("to manager %d", toManagerID)
+	}
+
+	err = uu.psql.DeleteManager(ctx, uid, toManagerID, message)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.DeleteManager: manager not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.DeleteManager: failed to delete manager with err: %s", err)
+	}
+
+	err = uu.rdsSession.DeleteUserSessions(ctx, manager.Email)
+	if err != nil {
+		uu.logger.Errorf("UserUsecase.DeleteManager: failed to delete sessions of manager %d with err: %s", uid, err)
+	}
+	return http.StatusOK, nil
+}
+
+func (uu *userUsecase) SendManagerPasswordReset(ctx context.Context, uid uint64) (int, error) {
+	manager, status, err := uu.getManager(ctx, uid)
+	if err != nil {
+		return status, fmt.Errorf("UserUsecase.SendManagerPasswordReset: %s", err)
+	}
+
+	token, err := uuid.NewRandom()
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.SendManagerPasswordReset: failed to generate token with err: %s", err)
+	}
+	err = uu.rdsUser.AddUserToken(ctx, passwordResetKey(token.String()), manager.Email, expPasswordResetTokenTime)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.SendManagerPasswordReset: failed to save token to redis with err: %s", err)
+	}
+
+	err = mailer.SendPasswordResetEmail(manager.Email, manager.FirstName, manager.SecondName, token.String())
+	if err != nil {
+		_, delErr := uu.rdsUser.GetUserAndDelete(ctx, passwordResetKey(token.String()))
+		if delErr != nil {
+			uu.logger.Errorf("UserUsecase.SendManagerPasswordReset: failed to delete password reset token for manager %d", uid)
+		}
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.SendManagerPasswordReset: failed to send email with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// ResetPassword sets new password by token from email and revokes all user's sessions.
+// Password is checked before token is used, so user can retry with the same link
+func (uu *userUsecase) ResetPassword(ctx context.Context, resetReq models.PasswordResetReq) (int, error) {
+	if !validatePassword(resetReq.Password) {
+		return http.StatusBadRequest, fmt.Errorf("UserUsecase.ResetPassword: invalid password")
+	}
+	if _, err := uuid.Parse(resetReq.Token); err != nil {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.ResetPassword: invalid password reset token")
+	}
+	userEmail, err := uu.rdsUser.GetUserAndDelete(ctx, passwordResetKey(resetReq.Token))
+	if err != nil {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.ResetPassword: failed to get password reset token")
+	}
+	user, err := uu.psql.GetUserByEmail(ctx, userEmail)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.ResetPassword: user not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ResetPassword: failed to get user with err: %s", err)
+	}
+
+	hashedPswd, err := hasher.HashAndSalt(resetReq.Password)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ResetPassword: failed to hash password with err: %s", err)
+	}
+	err = uu.psql.UpdateUserPswd(ctx, user.ID, hashedPswd)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.ResetPassword: failed to update password with err: %s", err)
+	}
+
+	err = uu.rdsSession.DeleteUserSessions(ctx, user.Email)
+	if err != nil {
+		uu.logger.Errorf("UserUsecase.ResetPassword: failed to delete sessions of user %d with err: %s", user.ID, err)
+	}
+	return http.StatusOK, nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/VoyakinH/lokle_backend/internal/user/repository"
+	"github.com/google/uuid"
+)
+
+// fakeRedisUserRepository counts used tokens
+type fakeRedisUserRepository struct {
+	repository.IRedisUserRepository
+	usedTokens int
+}
+
+func (fr *fakeRedisUserRepository) AddUserToken(ctx context.Context, key string, email string, exp time.Duration) error {
+	return nil
+}
+
+func (fr *fakeRedisUserRepository) GetUserAndDelete(ctx context.Context, key string) (string, error) {
+	fr.usedTokens++
+	return "", nil
+}
+
+func TestResetPasswordKeepsTokenOnInvalidPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{name: "empty password", password: ""},
+		{name: "one char password", password: "a"},
+		{name: "short password", password: strings.Repeat("a", minPasswordLen-1)},
+		{name: "too long password", password: strings.Repeat("a", maxPasswordLen+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rdsUser := &fakeRedisUserRepository{}
+			uu := &userUsecase{rdsUser: rdsUser}
+
+			status, err := uu.ResetPassword(context.Background(), models.PasswordResetReq{
+				Token:    uuid.NewString(),
+				Password: tt.password,
+			})
+			if status != http.StatusBadRequest || err == nil {
+				t.Errorf("expected status %d with error, got %d with err: %v", http.StatusBadRequest, status, err)
+			}
+			if rdsUser.usedTokens != 0 {
+				t.Errorf("token mustn't be used for invalid password")
+			}
+		})
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{password: "", want: false},
+		{password: "1234567", want: false},
+		{password: "12345678", want: true},
+		// length is counted in letters, not bytes
+		{password: "пароль12", want: true},
+		{password: strings.Repeat("a", maxPasswordLen), want: true},
+		{password: strings.Repeat("a", maxPasswordLen+1), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := validatePassword(tt.password); got != tt.want {
+			t.Errorf("validatePassword(%q) = %t, want %t", tt.password, got, tt.want)
+		}
+	}
+}
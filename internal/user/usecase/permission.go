@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/VoyakinH/lokle_backend/internal/models"
+	"github.com/jackc/pgx"
+)
+
+func (uu *userUsecase) GetUserPermissions(ctx context.Context, uid uint64) ([]models.UserPermission, int, error) {
+	permissions, err := uu.psql.GetUserPermissions(ctx, uid)
+	if err != nil {
+		return []models.UserPermission{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetUserPermissions: failed to get permissions with err: %s", err)
+	}
+	return permissions, http.StatusOK, nil
+}
+
+// GrantPermission gives extra access to staff, parents and children can't get it
+func (uu *userUsecase) GrantPermission(ctx context.Context, adminID uint64, req models.PermissionReq) (int, error) {
+	permission := models.Permission(req.Permission)
+	if !permission.IsKnown() {
+		return http.StatusBadRequest, fmt.Errorf("UserUsecase.GrantPermission: unknown permission %s", req.Permission)
+	}
+	user, err := uu.psql.GetUserByID(ctx, req.UserID)
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.GrantPermission: user not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.GrantPermission: failed to get user with err: %s", err)
+	}
+	if user.Role != models.ManagerRole && user.Role != models.AdminRole {
+		return http.StatusConflict, fmt.Errorf("UserUsecase.GrantPermission: permission can't be granted to %s", user.Role.String())
+	}
+
+	err = uu.psql.GrantPermission(ctx, models.UserPermission{
+		UserID:     user.ID,
+		Permission: permission,
+		GrantedBy:  adminID,
+	})
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.GrantPermission: failed to grant permission with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+func (uu *userUsecase) RevokePermission(ctx context.Context, uid uint64, permission string) (int, error) {
+	err := uu.psql.RevokePermission(ctx, uid, models.Permission(permission))
+	if err == pgx.ErrNoRows {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.RevokePermission: permission not found")
+	} else if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.RevokePermission: failed to revoke permission with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+	"unicode/utf8"
 
+	"github.com/VoyakinH/lokle_backend/config"
 	"github.com/VoyakinH/lokle_backend/internal/models"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/crypt"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/hasher"
 	"github.com/VoyakinH/lokle_backend/internal/pkg/mailer"
+	"github.com/VoyakinH/lokle_backend/internal/pkg/tools"
 	"github.com/VoyakinH/lokle_backend/internal/user/repository"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx"
@@ -35,6 +38,30 @@ type IUserUsecase interface {
 	CheckParentChild(context.Context, uint64, uint64) (bool, int, error)
 	GetParentChildren(context.Context, uint64) (models.ChildWithRegReqList, int, error)
 	GetManagers(context.Context) ([]models.User, int, error)
+	GetNotificationSettings(context.Context, uint64) (models.NotificationSettings, int, error)
+	UpdateNotificationSettings(context.Context, uint64, models.NotificationSettings) (int, error)
+	UpdateChildProfile(context.Context, models.Child, models.ChildProfileUpdate) (models.Child, int, error)
+	GetChildParents(context.Context, uint64) ([]models.ChildParent, int, error)
+	InviteParent(context.Context, models.Parent, models.ParentInviteReq) (models.ParentInvitation, int, error)
+	GetChildInvitations(context.Context, models.Parent, uint64) ([]models.ParentInvitation, int, error)
+	GetReceivedInvitations(context.Context, models.Parent) ([]models.ParentInvitation, int, error)
+	AcceptInvitation(context.Context, models.Parent, uint64) (int, error)
+	DeclineInvitation(context.Context, models.Parent, uint64) (int, error)
+	RevokeInvitation(context.Context, models.Parent, uint64) (int, error)
+	UnlinkParent(context.Context, models.Parent, uint64) (int, error)
+	GetAcceptedInvitations(context.Context) ([]models.ParentInvitation, int, error)
+	ApproveInvitation(context.Context, uint64, uint64) (int, error)
+	RejectInvitation(context.Context, uint64, uint64) (int, error)
+	UpdateManager(context.Context, uint64, models.ManagerUpdateReq) (models.User, int, error)
+	DeactivateManager(context.Context, uint64) (int, error)
+	ReactivateManager(context.Context, uint64) (int, error)
+	DeleteManager(context.Context, uint64, uint64) (int, error)
+	SendManagerPasswordReset(context.Context, uint64) (int, error)
+	ResetPassword(context.Context, models.PasswordResetReq) (int, error)
+	GetUserDirectory(context.Context, models.UserDirectoryFilter) (models.UserDirectory, int, error)
+	GetUserPermissions(context.Context, uint64) ([]models.UserPermission, int, error)
+	GrantPermission(context.Context, uint64, models.PermissionReq) (int, error)
+	RevokePermission(context.Context, uint64, string) (int, error)
 }
 
 type userUsecase struct {
@@ -58,6 +85,11 @@ func NewUserUsecase(pr repository.IPostgresqlRepository,
 
 const expVerifiedTokenTime = 604800
 
+const (
+	DefaultUserDirectoryLimit = 20
+	MaxUserDirectoryLimit     = 100
+)
+
 func (uu *userUsecase) CreateSession(ctx context.Context, email string, sessionExpire time.Duration) (string, int, error) {
 	sessionID, err := uuid.NewRandom()
 	if err != nil {
@@ -94,6 +126,9 @@ func (uu *userUsecase) CheckSession(ctx context.Context, cookie string) (models.
 	} else if err != nil {
 		return models.User{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.CheckSession: failed to check email in db with err: %s", err)
 	}
+	if user.Deactivated {
+		return models.User{}, http.StatusForbidden, fmt.Errorf("UserUsecase.CheckSession: user is deactivated")
+	}
 
 	return user, http.StatusOK, nil
 }
@@ -127,6 +162,9 @@ func (uu *userUsecase) CheckUser(ctx context.Context, credentials models.Credent
 	if err != nil || status != http.StatusOK {
 		return models.User{}, status, fmt.Errorf("UserUsecase.CheckUser: %s", err)
 	}
+	if user.Deactivated {
+		return models.User{}, http.StatusForbidden, fmt.Errorf("UserUsecase.CheckUser: user is deactivated")
+	}
 
 	return user, http.StatusOK, nil
 }
@@ -157,7 +195,21 @@ func (uu *userUsecase) createVerificationEmail(ctx context.Context, parent model
 	return nil
 }
 
+// bcrypt uses only first 72 bytes of password
+const (
+	minPasswordLen = 8
+	maxPasswordLen = 72
+)
+
+// validatePassword checks passwords set by users on signup and password reset
+func validatePassword(password string) bool {
+	return utf8.RuneCountInString(password) >= minPasswordLen && len(password) <= maxPasswordLen
+}
+
 func (uu *userUsecase) CreateParentUser(ctx context.Context, parent models.User) (models.User, int, error) {
+	if !validatePassword(parent.Password) {
+		return models.User{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.CreateParentUser: invalid password")
+	}
 	_, err := uu.psql.GetUserByEmail(ctx, parent.Email)
 	if err == nil {
 		return models.User{}, http.StatusConflict, fmt.Errorf("UserUsecase.CreateParentUser: parent with same email already exists")
@@ -188,6 +240,10 @@ func (uu *userUsecase) CreateParentUser(ctx context.Context, parent models.User)
 }
 
 func (uu *userUsecase) VerifyEmail(ctx context.Context, token string) (int, error) {
+	// password reset tokens are stored in the same redis with prefix
+	if _, err := uuid.Parse(token); err != nil {
+		return http.StatusNotFound, fmt.Errorf("UserUsecase.VerifyEmail: invalid email verification token")
+	}
 	userEmail, err := uu.rdsUser.GetUserAndDelete(ctx, token)
 	if err != nil {
 		return http.StatusNotFound, fmt.Errorf("UserUsecase.VerifyEmail: failed to get email verification token")
@@ -298,6 +354,9 @@ func (uu *userUsecase) CreateParent(ctx context.Context, uid uint64) (models.Par
 }
 
 func (uu *userUsecase) CreateManager(ctx context.Context, manager models.User) (models.User, int, error) {
+	if !validatePassword(manager.Password) {
+		return models.User{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.CreateManager: invalid password")
+	}
 	_, err := uu.psql.GetUserByEmail(ctx, manager.Email)
 	if err == nil {
 		return models.User{}, http.StatusConflict, fmt.Errorf("UserUsecase.CreateManager: manager with same email already exists")
@@ -356,3 +415,96 @@ func (uu *userUsecase) GetManagers(ctx context.Context) ([]models.User, int, err
 	}
 	return respList, http.StatusOK, nil
 }
+
+func (uu *userUsecase) GetNotificationSettings(ctx context.Context, uid uint64) (models.NotificationSettings, int, error) {
+	settings, err := uu.psql.GetNotificationSettings(ctx, uid)
+	if err == pgx.ErrNoRows {
+		return models.NotificationSettings{}, http.StatusNotFound, fmt.Errorf("UserUsecase.GetNotificationSettings: user not found")
+	} else if err != nil {
+		return models.NotificationSettings{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetNotificationSettings: failed to get settings with err: %s", err)
+	}
+	return settings, http.StatusOK, nil
+}
+
+func (uu *userUsecase) UpdateNotificationSettings(ctx context.Context, uid uint64, settings models.NotificationSettings) (int, error) {
+	err := uu.psql.UpdateNotificationSettings(ctx, uid, settings)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("UserUsecase.UpdateNotificationSettings: failed to update settings with err: %s", err)
+	}
+	return http.StatusOK, nil
+}
+
+// profile fields by names used in user.child_self_edit_fields config
+var childProfileFields = map[string]func(*models.Child, *models.ChildProfileUpdate) (*string, string){
+	"first_name": func(c *models.Child, u *models.ChildProfileUpdate) (*string, string) {
+		return &c.FirstName, u.FirstName
+	},
+	"second_name": func(c *models.Child, u *models.ChildProfileUpdate) (*string, string) {
+		return &c.SecondName, u.SecondName
+	},
+	"last_name": func(c *models.Child, u *models.ChildProfileUpdate) (*string, string) {
+		return &c.LastName, u.LastName
+	},
+	"phone": func(c *models.Child, u *models.ChildProfileUpdate) (*string, string) {
+		return &c.Phone, u.Phone
+	},
+	"place_of_residence": func(c *models.Child, u *models.ChildProfileUpdate) (*string, string) {
+		return &c.PlaceOfResidence, u.PlaceOfResidence
+	},
+	"place_of_registration": func(c *models.Child, u *models.ChildProfileUpdate) (*string, string) {
+		return &c.PlaceOfRegistration, u.PlaceOfRegistration
+	},
+}
+
+// UpdateChildProfile applies child's own changes, other fields are changed only through admissions
+func (uu *userUsecase) UpdateChildProfile(ctx context.Context, child models.Child, update models.ChildProfileUpdate) (models.Child, int, error) {
+	allowed := make(map[string]bool, len(config.User.ChildSelfEditFields))
+	for _, field := range config.User.ChildSelfEditFields {
+		allowed[field] = true
+	}
+
+	for name, field := range childProfileFields {
+		current, value := field(&child, &update)
+		if value == "" || value == *current {
+			continue
+		}
+		if !allowed[name] {
+			return models.Child{}, http.StatusForbidden, fmt.Errorf("UserUsecase.UpdateChildProfile: field %s can't be changed by child", name)
+		}
+		if name == "phone" && !tools.ValidatePhone(value) {
+			return models.Child{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.UpdateChildProfile: invalid phone")
+		}
+		*current = value
+	}
+
+	err := uu.psql.UpdateChildProfile(ctx, child)
+	if err != nil {
+		return models.Child{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.UpdateChildProfile: failed to update child with err: %s", err)
+	}
+	return child, http.StatusOK, nil
+}
+
+func (uu *userUsecase) GetChildParents(ctx context.Context, uid uint64) ([]models.ChildParent, int, error) {
+	parents, err := uu.psql.GetChildParents(ctx, uid)
+	if err != nil {
+		return []models.ChildParent{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetChildParents: failed to get child's parents with err: %s", err)
+	}
+	return parents, http.StatusOK, nil
+}
+
+func (uu *userUsecase) GetUserDirectory(ctx context.Context, filter models.UserDirectoryFilter) (models.UserDirectory, int, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultUserDirectoryLimit
+	}
+	if filter.Limit > MaxUserDirectoryLimit {
+		return models.UserDirectory{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.GetUserDirectory: limit %d is too big", filter.Limit)
+	}
+	if filter.CreatedTo != 0 && filter.CreatedFrom > filter.CreatedTo {
+		return models.UserDirectory{}, http.StatusBadRequest, fmt.Errorf("UserUsecase.GetUserDirectory: invalid creation date range")
+	}
+	directory, err := uu.psql.GetUserDirectory(ctx, filter)
+	if err != nil {
+		return models.UserDirectory{}, http.StatusInternalServerError, fmt.Errorf("UserUsecase.GetUserDirectory: failed to get users with err: %s", err)
+	}
+	return directory, http.StatusOK, nil
+}